@@ -21,12 +21,24 @@ import (
 
 var (
 	flagset     = flag.NewFlagSet("", flag.ExitOnError)
-	connectFlag = flagset.String("s", "localhost", "host[:port] of dcrwallet gRPC server")
+	connectFlag = flagset.String("s", "localhost", "host[:port] of dcrwallet gRPC (or JSON-RPC) server")
 	certFlag    = flagset.String("c", filepath.Join(dcrutil.AppDataDir("dcrwallet", false), "rpc.cert"), "dcrwallet RPC certificate path")
 	testnetFlag = flagset.Bool("testnet", false, "use testnet network")
+	networkFlag = flagset.String("network", "", "mainnet, testnet or simnet (default mainnet or testnet with -testnet)")
 	walletPass  = flagset.String("wpass", "", "wallet passphrase")
+	accountFlag = flagset.String("account", "", "wallet account number or name (default account 0)")
+	minConfFlag = flagset.Int("minconf", 0, "confirmations required of outputs funding a contract")
+	feeRateFlag = flagset.Float64("feerate", 0, "fee rate in DCR/kB (default the wallet's rate)")
+	nodeFlag    = flagset.String("node", "", "host[:port] of dcrd JSON-RPC server for gettx of non-wallet transactions")
+	nodeCert    = flagset.String("nodecert", filepath.Join(dcrutil.AppDataDir("dcrd", false), "rpc.cert"), "dcrd RPC certificate path")
+	jsonRPCFlag = flagset.Bool("jsonrpc", false, "use the dcrwallet JSON-RPC API rather than gRPC (-c \"\" for no TLS)")
+	rpcUser     = flagset.String("rpcuser", "", "dcrwallet JSON-RPC and dcrd RPC username")
+	rpcPass     = flagset.String("rpcpass", "", "dcrwallet JSON-RPC and dcrd RPC password")
 )
 
+// network is the network chosen with the -network or -testnet flags
+var network libs.Network
+
 // There are two directions that the atomic swap can be performed, as the
 // initiator can be on either chain.  This tool only deals with creating the
 // Decred transactions for these swaps.  A second tool should be used for the
@@ -129,6 +141,11 @@ func run() error {
 		flagset.Usage()
 		return fmt.Errorf("unexpected argument: %s", flagset.Arg(0))
 	}
+	var err error
+	network, err = getNetwork()
+	if err != nil {
+		return err
+	}
 
 	switch args[0] {
 	case "initiate":
@@ -159,6 +176,24 @@ func run() error {
 	return fmt.Errorf("unexpected argument: %s", flagset.Arg(0))
 }
 
+// getNetwork gets the network from the -network and -testnet flags
+func getNetwork() (libs.Network, error) {
+	if *networkFlag == "" {
+		if *testnetFlag {
+			return libs.Testnet, nil
+		}
+		return libs.Mainnet, nil
+	}
+	n, err := libs.ParseNetwork(*networkFlag)
+	if err != nil {
+		return n, err
+	}
+	if *testnetFlag && n != libs.Testnet {
+		return n, fmt.Errorf("-testnet conflicts with -network %s", *networkFlag)
+	}
+	return n, nil
+}
+
 func initiate(args []string) error {
 	amountF64, err := strconv.ParseFloat(args[2], 64)
 	if err != nil {
@@ -174,12 +209,20 @@ func initiate(args []string) error {
 	rpcinfo.HostPort = *connectFlag
 	rpcinfo.WalletPass = *walletPass
 	rpcinfo.Certs = *certFlag
+	rpcinfo.JSONRPC = *jsonRPCFlag
+	rpcinfo.User = *rpcUser
+	rpcinfo.Pass = *rpcPass
 
-	err = dcr.PingRPC(*testnetFlag, rpcinfo)
+	_, err = dcr.PingRPC(network, rpcinfo)
 	if err != nil {
 		return fmt.Errorf("Ping RPC: error: %v", err)
 	}
 
+	opts, err := walletOptions()
+	if err != nil {
+		return err
+	}
+
 	secret := libs.GetRand32()
 	secretHash, err := libs.Hash256(secret)
 	if err != nil {
@@ -190,9 +233,10 @@ func initiate(args []string) error {
 	params.SecretHash = secretHash
 	params.CP2Addr = args[1]
 	params.CP2Amount = int64(amount)
+	params.WalletOptions = opts
 
 	var result *libs.InitiateResult
-	result, err = dcr.Initiate(*testnetFlag, rpcinfo, params)
+	result, err = dcr.Initiate(network, rpcinfo, params)
 	if err != nil {
 		return fmt.Errorf("Initiate: %v", err)
 	}
@@ -210,7 +254,7 @@ func initiate(args []string) error {
 		return err
 	}
 	if doPublish {
-		txHash, err := dcr.Publish(*testnetFlag, rpcinfo, result.ContractTx)
+		txHash, err := dcr.Publish(network, rpcinfo, result.ContractTx)
 		if err != nil {
 			return err
 		}
@@ -234,19 +278,28 @@ func participate(args []string) error {
 	rpcinfo.HostPort = *connectFlag
 	rpcinfo.WalletPass = *walletPass
 	rpcinfo.Certs = *certFlag
+	rpcinfo.JSONRPC = *jsonRPCFlag
+	rpcinfo.User = *rpcUser
+	rpcinfo.Pass = *rpcPass
 
-	err = dcr.PingRPC(*testnetFlag, rpcinfo)
+	_, err = dcr.PingRPC(network, rpcinfo)
 	if err != nil {
 		return fmt.Errorf("Ping RPC: error: %v", err)
 	}
 
+	opts, err := walletOptions()
+	if err != nil {
+		return err
+	}
+
 	var params libs.ParticipateParams
 	params.SecretHash = args[3]
 	params.CP1Addr = args[1]
 	params.CP1Amount = int64(amount)
+	params.WalletOptions = opts
 
 	var result *libs.ParticipateResult
-	result, err = dcr.Participate(*testnetFlag, rpcinfo, params)
+	result, err = dcr.Participate(network, rpcinfo, params)
 	if err != nil {
 		return fmt.Errorf("Participate: %v", err)
 	}
@@ -262,7 +315,7 @@ func participate(args []string) error {
 		return err
 	}
 	if doPublish {
-		txHash, err := dcr.Publish(*testnetFlag, rpcinfo, result.ContractTx)
+		txHash, err := dcr.Publish(network, rpcinfo, result.ContractTx)
 		if err != nil {
 			return err
 		}
@@ -276,20 +329,29 @@ func redeem(args []string) error {
 	var rpcinfo libs.RPCInfo
 	rpcinfo.HostPort = *connectFlag
 	rpcinfo.Certs = *certFlag
+	rpcinfo.JSONRPC = *jsonRPCFlag
+	rpcinfo.User = *rpcUser
+	rpcinfo.Pass = *rpcPass
 	rpcinfo.WalletPass = *walletPass
 
-	err := dcr.PingRPC(*testnetFlag, rpcinfo)
+	_, err := dcr.PingRPC(network, rpcinfo)
 	if err != nil {
 		return fmt.Errorf("Ping RPC: error: %v", err)
 	}
 
+	opts, err := walletOptions()
+	if err != nil {
+		return err
+	}
+
 	var params libs.RedeemParams
 	params.Contract = args[1]
 	params.ContractTx = args[2]
 	params.Secret = args[3]
+	params.WalletOptions = opts
 
 	var result *libs.RedeemResult
-	result, err = dcr.Redeem(*testnetFlag, rpcinfo, params)
+	result, err = dcr.Redeem(network, rpcinfo, params)
 	if err != nil {
 		return fmt.Errorf("Redeem: %v", err)
 	}
@@ -303,7 +365,7 @@ func redeem(args []string) error {
 		return err
 	}
 	if doPublish {
-		txHash, err := dcr.Publish(*testnetFlag, rpcinfo, result.RedeemTx)
+		txHash, err := dcr.Publish(network, rpcinfo, result.RedeemTx)
 		if err != nil {
 			return err
 		}
@@ -317,19 +379,28 @@ func refund(args []string) error {
 	var rpcinfo libs.RPCInfo
 	rpcinfo.HostPort = *connectFlag
 	rpcinfo.Certs = *certFlag
+	rpcinfo.JSONRPC = *jsonRPCFlag
+	rpcinfo.User = *rpcUser
+	rpcinfo.Pass = *rpcPass
 	rpcinfo.WalletPass = *walletPass
 
-	err := dcr.PingRPC(*testnetFlag, rpcinfo)
+	_, err := dcr.PingRPC(network, rpcinfo)
 	if err != nil {
 		return fmt.Errorf("Ping RPC: error: %v", err)
 	}
 
+	opts, err := walletOptions()
+	if err != nil {
+		return err
+	}
+
 	var params libs.RefundParams
 	params.Contract = args[1]
 	params.ContractTx = args[2]
+	params.WalletOptions = opts
 
 	var result *libs.RefundResult
-	result, err = dcr.Refund(*testnetFlag, rpcinfo, params)
+	result, err = dcr.Refund(network, rpcinfo, params)
 	if err != nil {
 		return fmt.Errorf("Refund: %v", err)
 	}
@@ -343,7 +414,7 @@ func refund(args []string) error {
 		return err
 	}
 	if doPublish {
-		txHash, err := dcr.Publish(*testnetFlag, rpcinfo, result.RefundTx)
+		txHash, err := dcr.Publish(network, rpcinfo, result.RefundTx)
 		if err != nil {
 			return err
 		}
@@ -367,16 +438,26 @@ func getTx(args []string) error {
 	var rpcinfo libs.RPCInfo
 	rpcinfo.HostPort = *connectFlag
 	rpcinfo.Certs = *certFlag
+	rpcinfo.JSONRPC = *jsonRPCFlag
+	rpcinfo.User = *rpcUser
+	rpcinfo.Pass = *rpcPass
 	rpcinfo.WalletPass = *walletPass
+	rpcinfo.NodeHostPort = *nodeFlag
+	rpcinfo.NodeCerts = *nodeCert
 
 	txid := args[1]
 
-	result, err := dcr.GetTx(*testnetFlag, rpcinfo, txid)
+	result, err := dcr.GetTx(network, rpcinfo, txid)
 	if err != nil {
 		return fmt.Errorf("getTx: %v", err)
 	}
 	fmt.Printf("Confirmations: %d\n", result.Confirmations)
 	fmt.Printf("Block hash:    %s\n", result.Blockhash)
+	fmt.Printf("Block index:   %d\n", result.Blockindex)
+	fmt.Printf("Block time:    %d\n", result.Blocktime)
+	fmt.Printf("Time:          %d\n", result.Time)
+	fmt.Printf("Time received: %d\n", result.TimeReceived)
+	fmt.Printf("Transaction:   %s\n", result.Hex)
 	return nil
 }
 
@@ -384,9 +465,12 @@ func newAddress(args []string) error {
 	var rpcinfo libs.RPCInfo
 	rpcinfo.HostPort = *connectFlag
 	rpcinfo.Certs = *certFlag
+	rpcinfo.JSONRPC = *jsonRPCFlag
+	rpcinfo.User = *rpcUser
+	rpcinfo.Pass = *rpcPass
 	rpcinfo.WalletPass = *walletPass
 
-	addr, err := dcr.GetNewAddress(*testnetFlag, rpcinfo)
+	addr, err := dcr.GetNewAddress(network, rpcinfo)
 	if err != nil {
 		return fmt.Errorf("GetNewAddress: error: %v", err)
 	}
@@ -394,6 +478,20 @@ func newAddress(args []string) error {
 	return nil
 }
 
+// walletOptions gets the wallet account, confirmation & fee rate options from
+// the flags
+func walletOptions() (libs.WalletOptions, error) {
+	var opts libs.WalletOptions
+	feeRate, err := dcr.NewAmount(*feeRateFlag)
+	if err != nil {
+		return opts, fmt.Errorf("failed to decode fee rate: %v", err)
+	}
+	opts.Account = *accountFlag
+	opts.RequiredConfs = int32(*minConfFlag)
+	opts.FeePerKb = int64(feeRate)
+	return opts, nil
+}
+
 func askPublishTx(name string) (bool, error) {
 	reader := bufio.NewReader(os.Stdin)
 	for {
@@ -26,9 +26,13 @@ var (
 	rpcuserFlag = flagset.String("rpcuser", "", "username for wallet RPC authentication")
 	rpcpassFlag = flagset.String("rpcpass", "", "password for wallet RPC authentication")
 	testnetFlag = flagset.Bool("testnet", false, "use testnet network")
+	networkFlag = flagset.String("network", "", "mainnet, testnet or regtest (default mainnet or testnet with -testnet)")
 	walletPass  = flagset.String("wpass", "", "wallet passphrase")
 )
 
+// network is the network chosen with the -network or -testnet flags
+var network libs.Network
+
 // There are two directions that the atomic swap can be performed, as the
 // initiator can be on either chain.  This tool only deals with creating the
 // Zcoin transactions for these swaps.  A second tool should be used for the
@@ -131,6 +135,11 @@ func run() error {
 		flagset.Usage()
 		return fmt.Errorf("unexpected argument: %s", flagset.Arg(0))
 	}
+	var err error
+	network, err = getNetwork()
+	if err != nil {
+		return err
+	}
 
 	switch args[0] {
 	case "initiate":
@@ -161,6 +170,24 @@ func run() error {
 	return fmt.Errorf("unexpected argument: %s", flagset.Arg(0))
 }
 
+// getNetwork gets the network from the -network and -testnet flags
+func getNetwork() (libs.Network, error) {
+	if *networkFlag == "" {
+		if *testnetFlag {
+			return libs.Testnet, nil
+		}
+		return libs.Mainnet, nil
+	}
+	n, err := libs.ParseNetwork(*networkFlag)
+	if err != nil {
+		return n, err
+	}
+	if *testnetFlag && n != libs.Testnet {
+		return n, fmt.Errorf("-testnet conflicts with -network %s", *networkFlag)
+	}
+	return n, nil
+}
+
 func initiate(args []string) error {
 	amountF64, err := strconv.ParseFloat(args[2], 64)
 	if err != nil {
@@ -178,7 +205,7 @@ func initiate(args []string) error {
 	rpcinfo.Pass = *rpcpassFlag
 	rpcinfo.WalletPass = *walletPass
 
-	err = ltc.PingRPC(*testnetFlag, rpcinfo)
+	_, err = ltc.PingRPC(network, rpcinfo)
 	if err != nil {
 		return fmt.Errorf("Ping RPC: error: %v", err)
 	}
@@ -195,7 +222,7 @@ func initiate(args []string) error {
 	params.CP2Amount = int64(amount)
 
 	var result *libs.InitiateResult
-	result, err = ltc.Initiate(*testnetFlag, rpcinfo, params)
+	result, err = ltc.Initiate(network, rpcinfo, params)
 	if err != nil {
 		return fmt.Errorf("Initiate: %v", err)
 	}
@@ -213,7 +240,7 @@ func initiate(args []string) error {
 		return err
 	}
 	if doPublish {
-		txHash, err := ltc.Publish(*testnetFlag, rpcinfo, result.ContractTx)
+		txHash, err := ltc.Publish(network, rpcinfo, result.ContractTx)
 		if err != nil {
 			return err
 		}
@@ -239,7 +266,7 @@ func participate(args []string) error {
 	rpcinfo.Pass = *rpcpassFlag
 	rpcinfo.WalletPass = *walletPass
 
-	err = ltc.PingRPC(*testnetFlag, rpcinfo)
+	_, err = ltc.PingRPC(network, rpcinfo)
 	if err != nil {
 		return fmt.Errorf("Ping RPC: error: %v", err)
 	}
@@ -250,7 +277,7 @@ func participate(args []string) error {
 	params.CP1Amount = int64(amount)
 
 	var result *libs.ParticipateResult
-	result, err = ltc.Participate(*testnetFlag, rpcinfo, params)
+	result, err = ltc.Participate(network, rpcinfo, params)
 	if err != nil {
 		return fmt.Errorf("Participate: %v", err)
 	}
@@ -266,7 +293,7 @@ func participate(args []string) error {
 		return err
 	}
 	if doPublish {
-		txHash, err := ltc.Publish(*testnetFlag, rpcinfo, result.ContractTx)
+		txHash, err := ltc.Publish(network, rpcinfo, result.ContractTx)
 		if err != nil {
 			return err
 		}
@@ -283,7 +310,7 @@ func redeem(args []string) error {
 	rpcinfo.Pass = *rpcpassFlag
 	rpcinfo.WalletPass = *walletPass
 
-	err := ltc.PingRPC(*testnetFlag, rpcinfo)
+	_, err := ltc.PingRPC(network, rpcinfo)
 	if err != nil {
 		return fmt.Errorf("Ping RPC: error: %v", err)
 	}
@@ -294,7 +321,7 @@ func redeem(args []string) error {
 	params.Secret = args[3]
 
 	var result *libs.RedeemResult
-	result, err = ltc.Redeem(*testnetFlag, rpcinfo, params)
+	result, err = ltc.Redeem(network, rpcinfo, params)
 	if err != nil {
 		return fmt.Errorf("Redeem: %v", err)
 	}
@@ -308,7 +335,7 @@ func redeem(args []string) error {
 		return err
 	}
 	if doPublish {
-		txHash, err := ltc.Publish(*testnetFlag, rpcinfo, result.RedeemTx)
+		txHash, err := ltc.Publish(network, rpcinfo, result.RedeemTx)
 		if err != nil {
 			return err
 		}
@@ -325,7 +352,7 @@ func refund(args []string) error {
 	rpcinfo.Pass = *rpcpassFlag
 	rpcinfo.WalletPass = *walletPass
 
-	err := ltc.PingRPC(*testnetFlag, rpcinfo)
+	_, err := ltc.PingRPC(network, rpcinfo)
 	if err != nil {
 		return fmt.Errorf("Ping RPC: error: %v", err)
 	}
@@ -335,7 +362,7 @@ func refund(args []string) error {
 	params.ContractTx = args[2]
 
 	var result *libs.RefundResult
-	result, err = ltc.Refund(*testnetFlag, rpcinfo, params)
+	result, err = ltc.Refund(network, rpcinfo, params)
 	if err != nil {
 		return fmt.Errorf("Refund: %v", err)
 	}
@@ -349,7 +376,7 @@ func refund(args []string) error {
 		return err
 	}
 	if doPublish {
-		txHash, err := ltc.Publish(*testnetFlag, rpcinfo, result.RefundTx)
+		txHash, err := ltc.Publish(network, rpcinfo, result.RefundTx)
 		if err != nil {
 			return err
 		}
@@ -376,7 +403,7 @@ func auditContract(args []string) error {
 	params.ContractTx = args[2]
 
 	var result *libs.AuditResult
-	result, err := ltc.AuditContract(*testnetFlag, params)
+	result, err := ltc.AuditContract(network, params)
 	if err != nil {
 		return err
 	}
@@ -414,7 +441,7 @@ func getTx(args []string) error {
 
 	txid := args[1]
 
-	result, err := ltc.GetTx(*testnetFlag, rpcinfo, txid)
+	result, err := ltc.GetTx(network, rpcinfo, txid)
 	if err != nil {
 		return fmt.Errorf("getTx: %v", err)
 	}
@@ -435,7 +462,7 @@ func newAddress(args []string) error {
 	rpcinfo.Pass = *rpcpassFlag
 	rpcinfo.WalletPass = *walletPass
 
-	addr, err := ltc.GetNewAddress(*testnetFlag, rpcinfo)
+	addr, err := ltc.GetNewAddress(network, rpcinfo)
 	if err != nil {
 		return fmt.Errorf("GetNewAddress: error: %v", err)
 	}
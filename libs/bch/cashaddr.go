@@ -0,0 +1,72 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package bch
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	"github.com/cpacia/bchutil"
+)
+
+// Bitcoin Cash nodes hand out and accept CashAddr addresses but the script
+// and signing code works with the legacy btcutil address types. Addresses
+// are decoded into legacy types on the way in and encoded as CashAddr on the
+// way out.
+
+// decodeAddress decodes a CashAddr (with or without the network prefix) or a
+// legacy base58 address into a legacy btcutil address
+func decodeAddress(addr string, chainParams *chaincfg.Params) (btcutil.Address, error) {
+	cashAddr, cashErr := bchutil.DecodeAddress(addr, chainParams)
+	if cashErr == nil {
+		return toLegacyAddress(cashAddr, chainParams)
+	}
+	legacyAddr, err := btcutil.DecodeAddress(addr, chainParams)
+	if err != nil {
+		return nil, fmt.Errorf("not a CashAddr (%v) or legacy (%v) address", cashErr, err)
+	}
+	return legacyAddr, nil
+}
+
+// toLegacyAddress converts a CashAddr address type to the equivalent legacy
+// btcutil address type. Legacy addresses are returned unchanged
+func toLegacyAddress(addr btcutil.Address, chainParams *chaincfg.Params) (btcutil.Address, error) {
+	switch a := addr.(type) {
+	case *bchutil.CashAddressPubKeyHash:
+		return btcutil.NewAddressPubKeyHash(a.Hash160()[:], chainParams)
+	case *bchutil.CashAddressScriptHash:
+		return btcutil.NewAddressScriptHashFromHash(a.Hash160()[:], chainParams)
+	}
+	return addr, nil
+}
+
+// encodeCashAddress encodes a legacy P2PKH or P2SH address as a CashAddr
+// string including the network prefix
+func encodeCashAddress(addr btcutil.Address, chainParams *chaincfg.Params) (string, error) {
+	var cashAddr btcutil.Address
+	var err error
+	switch a := addr.(type) {
+	case *btcutil.AddressPubKeyHash:
+		cashAddr, err = bchutil.NewCashAddressPubKeyHash(a.Hash160()[:], chainParams)
+	case *btcutil.AddressScriptHash:
+		cashAddr, err = bchutil.NewCashAddressScriptHashFromHash(a.Hash160()[:], chainParams)
+	case *bchutil.CashAddressPubKeyHash, *bchutil.CashAddressScriptHash:
+		cashAddr = addr
+	default:
+		err = errors.New("address is not P2PKH or P2SH")
+	}
+	if err != nil {
+		return "", err
+	}
+	encoded := cashAddr.EncodeAddress()
+	prefix, ok := bchutil.Prefixes[chainParams.Name]
+	if ok && !strings.HasPrefix(encoded, prefix+":") {
+		encoded = prefix + ":" + encoded
+	}
+	return encoded, nil
+}
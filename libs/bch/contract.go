@@ -0,0 +1,151 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package bch
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DesWurstes/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/devwarrior777/atomicswap/libs"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// contractArgs specifies the common parameters used to create the initiator's
+// and participant's contract.
+type contractArgs struct {
+	them       *btcutil.AddressPubKeyHash
+	amount     btcutil.Amount
+	locktime   int64
+	secretHash []byte
+}
+
+// builtContract houses the details regarding a contract and the contract
+// payment transaction, as well as the transaction to perform a refund.
+type builtContract struct {
+	contract     []byte
+	contractP2SH btcutil.Address
+	contractTx   *wire.MsgTx
+	contractFee  btcutil.Amount
+}
+
+// buildContract creates a contract for the parameters specified in args, using
+// wallet RPC to generate an internal address to redeem the refund and to sign
+// the payment to the contract transaction.
+func buildContract(network libs.Network, rpcclient *rpcClient, args *contractArgs) (*builtContract, error) {
+	refundAddr, err := getRawChangeAddress(network, rpcclient)
+	if err != nil {
+		return nil, fmt.Errorf("getrawchangeaddress: %v", err)
+	}
+	refundAddrH, ok := refundAddr.(interface {
+		Hash160() *[ripemd160.Size]byte
+	})
+	if !ok {
+		return nil, errors.New("unable to create hash160 from change address")
+	}
+
+	contract, err := atomicSwapContract(refundAddrH.Hash160(), args.them.Hash160(),
+		args.locktime, args.secretHash)
+	if err != nil {
+		return nil, err
+	}
+	contractP2SH, err := btcutil.NewAddressScriptHash(contract, getChainParams(network))
+	if err != nil {
+		return nil, err
+	}
+	contractP2SHPkScript, err := txscript.PayToAddrScript(contractP2SH)
+	if err != nil {
+		return nil, err
+	}
+
+	feePerKb, _, err := getFeePerKb(rpcclient)
+	if err != nil {
+		return nil, err
+	}
+
+	unsignedContract := wire.NewMsgTx(txVersion)
+	unsignedContract.AddTxOut(wire.NewTxOut(int64(args.amount), contractP2SHPkScript))
+	unsignedContract, contractFee, err := fundRawTransaction(rpcclient, unsignedContract, feePerKb)
+	if err != nil {
+		return nil, fundsError(rpcclient, args.amount, fmt.Errorf("fundrawtransaction: %v", err))
+	}
+	done := rpcclient.observe("signrawtransaction")
+	contractTx, complete, err := rpcclient.SignRawTransaction(unsignedContract)
+	done(err)
+	if err != nil {
+		return nil, fmt.Errorf("signrawtransaction: %v", err)
+	}
+	if !complete {
+		return nil, errors.New("signrawtransaction: failed to completely sign contract transaction")
+	}
+
+	return &builtContract{
+		contract,
+		contractP2SH,
+		contractTx,
+		contractFee,
+	}, nil
+}
+
+// atomicSwapContract returns an output script that may be redeemed by one of
+// two signature scripts:
+//
+//   <their sig> <their pubkey> <initiator secret> 1
+//
+//   <my sig> <my pubkey> 0
+//
+// The first signature script is the normal redemption path done by the other
+// party and requires the initiator's secret.  The second signature script is
+// the refund path performed by us, but the refund can only be performed after
+// locktime.
+func atomicSwapContract(pkhMe, pkhThem *[ripemd160.Size]byte, locktime int64, secretHash []byte) ([]byte, error) {
+	b := txscript.NewScriptBuilder()
+
+	b.AddOp(txscript.OP_IF) // Normal redeem path
+	{
+		// Require initiator's secret to be a known length that the redeeming
+		// party can audit.  This is used to prevent fraud attacks between two
+		// currencies that have different maximum data sizes.
+		b.AddOp(txscript.OP_SIZE)
+		b.AddInt64(secretSize)
+		b.AddOp(txscript.OP_EQUALVERIFY)
+
+		// Require initiator's secret to be known to redeem the output.
+		b.AddOp(txscript.OP_SHA256)
+		b.AddData(secretHash)
+		b.AddOp(txscript.OP_EQUALVERIFY)
+
+		// Verify their signature is being used to redeem the output.  This
+		// would normally end with OP_EQUALVERIFY OP_CHECKSIG but this has been
+		// moved outside of the branch to save a couple bytes.
+		b.AddOp(txscript.OP_DUP)
+		b.AddOp(txscript.OP_HASH160)
+		b.AddData(pkhThem[:])
+	}
+	b.AddOp(txscript.OP_ELSE) // Refund path
+	{
+		// Verify locktime and drop it off the stack (which is not done by
+		// CLTV).
+		b.AddInt64(locktime)
+		b.AddOp(txscript.OP_CHECKLOCKTIMEVERIFY)
+		b.AddOp(txscript.OP_DROP)
+
+		// Verify our signature is being used to redeem the output.  This would
+		// normally end with OP_EQUALVERIFY OP_CHECKSIG but this has been moved
+		// outside of the branch to save a couple bytes.
+		b.AddOp(txscript.OP_DUP)
+		b.AddOp(txscript.OP_HASH160)
+		b.AddData(pkhMe[:])
+	}
+	b.AddOp(txscript.OP_ENDIF)
+
+	// Complete the signature check.
+	b.AddOp(txscript.OP_EQUALVERIFY)
+	b.AddOp(txscript.OP_CHECKSIG)
+
+	return b.Script()
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package bch
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/DesWurstes/btcd/txscript"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcwallet/wallet/txrules"
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// Build a transaction that can refund the coins back to the contract creator
+func refund(network libs.Network, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs.RefundResult, error) {
+	chainParams := getChainParams(network)
+
+	contract, err := hex.DecodeString(params.Contract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract: %v", err)
+	}
+
+	contractTxBytes, err := hex.DecodeString(params.ContractTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract transaction: %v", err)
+	}
+	var contractTx wire.MsgTx
+	err = contractTx.Deserialize(bytes.NewReader(contractTxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract transaction: %v", err)
+	}
+
+	pushes, err := txscript.ExtractAtomicSwapDataPushes(0, contract)
+	if err != nil {
+		return nil, err
+	}
+	if pushes == nil {
+		return nil, errors.New("contract is not an atomic swap script recognized by this tool")
+	}
+
+	rpcclient, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		rpcclient.Shutdown()
+		rpcclient.WaitForShutdown()
+	}()
+
+	end := rpcclient.session.Begin()
+	defer end()
+	release, err := unlockWallet(rpcclient, rpcinfo.WalletPass)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	feePerKb, minFeePerKb, err := getFeePerKb(rpcclient)
+	if err != nil {
+		return nil, err
+	}
+
+	contractP2SH, err := btcutil.NewAddressScriptHash(contract, chainParams)
+	if err != nil {
+		return nil, err
+	}
+	contractP2SHPkScript, err := txscript.PayToAddrScript(contractP2SH)
+	if err != nil {
+		return nil, err
+	}
+
+	contractTxHash := contractTx.TxHash()
+	contractOutPoint := wire.OutPoint{Hash: contractTxHash, Index: ^uint32(0)}
+	for i, o := range contractTx.TxOut {
+		if bytes.Equal(o.PkScript, contractP2SHPkScript) {
+			contractOutPoint.Index = uint32(i)
+			break
+		}
+	}
+	if contractOutPoint.Index == ^uint32(0) {
+		return nil, errors.New("contract tx does not contain a P2SH contract payment")
+	}
+
+	refundAddress, err := getRawChangeAddress(network, rpcclient)
+	if err != nil {
+		return nil, fmt.Errorf("getrawchangeaddress: %v", err)
+	}
+	refundOutScript, err := txscript.PayToAddrScript(refundAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	refundAddr, err := btcutil.NewAddressPubKeyHash(pushes.RefundHash160[:], chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	refundTx := wire.NewMsgTx(txVersion)
+	refundTx.LockTime = uint32(pushes.LockTime)
+	refundTx.AddTxOut(wire.NewTxOut(0, refundOutScript)) // amount set below
+	refundSize := estimateRefundSerializeSize(contract, refundTx.TxOut)
+	refundFee := txrules.FeeForSerializeSize(feePerKb, refundSize)
+	refundTx.TxOut[0].Value = contractTx.TxOut[contractOutPoint.Index].Value - int64(refundFee)
+	if txrules.IsDustOutput(refundTx.TxOut[0], minFeePerKb) {
+		return nil, fmt.Errorf("refund output value of %v is dust", btcutil.Amount(refundTx.TxOut[0].Value))
+	}
+
+	txIn := wire.NewTxIn(&contractOutPoint, nil, nil)
+	txIn.Sequence = 0
+	refundTx.AddTxIn(txIn)
+
+	refundSig, refundPubKey, err := createSig(network, refundTx, 0, contract, refundAddr, rpcclient,
+		contractTx.TxOut[contractOutPoint.Index].Value)
+	if err != nil {
+		return nil, err
+	}
+	refundSigScript, err := refundP2SHContract(contract, refundSig, refundPubKey)
+	if err != nil {
+		return nil, err
+	}
+	refundTx.TxIn[0].SignatureScript = refundSigScript
+
+	if verify {
+		e, err := txscript.NewEngine(contractTx.TxOut[contractOutPoint.Index].PkScript,
+			refundTx, 0, txscript.StandardVerifyFlags, txscript.NewSigCache(10),
+			txscript.NewTxSigHashes(refundTx), contractTx.TxOut[contractOutPoint.Index].Value)
+		if err != nil {
+			return nil, err
+		}
+		err = e.Execute()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var refundBuf bytes.Buffer
+	refundBuf.Grow(refundTx.SerializeSize())
+	refundTx.Serialize(&refundBuf)
+	strRefundTx := hex.EncodeToString(refundBuf.Bytes())
+
+	var refundTxHash chainhash.Hash
+	refundTxHash = refundTx.TxHash()
+	strRefundTxHash := refundTxHash.String()
+
+	var result = &libs.RefundResult{}
+
+	result.RefundTx = strRefundTx
+	result.RefundTxHash = strRefundTxHash
+	result.RefundFee = int64(refundFee)
+	result.RefundFeePerKb = calcFeePerKb(refundFee, refundTx.SerializeSize())
+
+	return result, nil
+}
+
+// refundP2SHContract returns the signature script to refund a contract output
+// using the contract author's signature after the locktime has been reached.
+// This function assumes P2SH and appends the contract as the final data push.
+func refundP2SHContract(contract, sig, pubkey []byte) ([]byte, error) {
+	b := txscript.NewScriptBuilder()
+	b.AddData(sig)
+	b.AddData(pubkey)
+	b.AddInt64(0)
+	b.AddData(contract)
+	return b.Script()
+}
@@ -0,0 +1,527 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package bch
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	rpc "github.com/btcsuite/btcd/rpcclient"
+	txscript2 "github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/cpacia/bchutil"
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// startRPC - starts a new RPC client for the network and address specified
+//            along with rpc user & rpc password, in RPCInfo
+func startRPC(network libs.Network, rpcinfo libs.RPCInfo) (*rpcClient, error) {
+	hostport, err := getNormalizedAddress(network, rpcinfo.HostPort)
+	if err != nil {
+		return nil, fmt.Errorf("wallet server address: %v", err)
+	}
+	connConfig := &rpc.ConnConfig{
+		Host:         hostport,
+		User:         rpcinfo.User,
+		Pass:         rpcinfo.Pass,
+		DisableTLS:   true, // bitcoin-like coins abandoned SSL for RPC
+		HTTPPostMode: true,
+	}
+	c, err := rpc.New(connConfig, nil)
+	if err != nil {
+		return nil, fmt.Errorf("rpc connect: %v", err)
+	}
+	client := &rpcClient{
+		Client:   c,
+		observer: rpcinfo.Observer,
+		log:      rpcinfo.Log,
+		session:  libs.Session("bch", network, hostport),
+	}
+	err = checkChain(network, client)
+	if err != nil {
+		stopRPC(client)
+		return nil, err
+	}
+	return client, nil
+}
+
+// stopRPC - Explicit stop when not using defer()
+func stopRPC(client *rpcClient) {
+	client.Shutdown()
+	client.WaitForShutdown()
+}
+
+// rpcClient is a wallet RPC client that tells the RPCInfo observer of its calls
+// and logs to the RPCInfo log. Its session serializes the wallet-mutating
+// commands of the wallet
+type rpcClient struct {
+	*rpc.Client
+	observer libs.RPCObserver
+	log      *libs.Log
+	session  *libs.WalletSession
+}
+
+// RawRequest makes a JSON-RPC request
+func (c *rpcClient) RawRequest(method string, params []json.RawMessage) (json.RawMessage, error) {
+	done := c.observer.Observe(method)
+	result, err := c.Client.RawRequest(method, params)
+	done(err)
+	return result, err
+}
+
+// observe tells the observer of a call made with a typed client method
+func (c *rpcClient) observe(method string) func(err error) {
+	return c.observer.Observe(method)
+}
+
+///////////////
+// RPC funcs //
+///////////////
+
+// walletLock allows access to an encrypted wallet for 't' seconds
+// If 'p' == "" (empty string) we assume the wallet is not encrypted
+func walletLock(rpcclient *rpcClient, p string, t int) error {
+	if len(p) == 0 {
+		return nil
+	}
+	pass, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	timeout, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	params := []json.RawMessage{pass, timeout}
+	_, err = rpcclient.RawRequest("walletpassphrase", params)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Re-lock an unlocked (encrypted) wallet
+// If 'p' == "" (empty string) we assume the wallet is not encrypted
+func walletUnlock(rpcclient *rpcClient, p string) {
+	if len(p) == 0 {
+		return
+	}
+	_, _ = rpcclient.RawRequest("walletlock", nil)
+}
+
+// unlockTimeout is the longest (seconds) an encrypted wallet is unlocked for
+// the commands of this process. The wallet is locked again as soon as the last
+// command needing it ends; the timeout only bounds a failed relock
+const unlockTimeout = 60
+
+// unlockWallet unlocks an encrypted wallet for a command and returns the func
+// ending the command's need of it. The wallet stays unlocked while any command
+// of the wallet session needs it and is locked when the last one ends
+// If 'p' == "" (empty string) we assume the wallet is not encrypted
+func unlockWallet(rpcclient *rpcClient, p string) (func(), error) {
+	if len(p) == 0 {
+		return func() {}, nil
+	}
+	err := rpcclient.session.Unlock(func() error {
+		return walletLock(rpcclient, p, unlockTimeout)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		rpcclient.session.Release(func() { walletUnlock(rpcclient, p) })
+	}, nil
+}
+
+// getBlockCount calls the getblockcount JSON-RPC method. It is
+// currently used as a simple 'ping' to discover if node RPC is available
+func getBlockCount(rpcclient *rpcClient) (int, error) {
+	rawResp, err := rpcclient.RawRequest("getblockcount", nil)
+	if err != nil {
+		return -1, err
+	}
+	var blockCount int
+	err = json.Unmarshal(rawResp, &blockCount)
+	if err != nil {
+		return -1, err
+	}
+	return blockCount, nil
+}
+
+// checkChain checks the node is on the chain of the network with the
+// getblockchaininfo JSON-RPC method
+func checkChain(network libs.Network, rpcclient *rpcClient) error {
+	info, err := getBlockchainInfo(rpcclient)
+	if err != nil {
+		return fmt.Errorf("getblockchaininfo: %v", err)
+	}
+	return libs.CheckChain(network, info.Chain, libs.CoreChainName(network))
+}
+
+// blockchainInfo is the part of the getblockchaininfo result used
+type blockchainInfo struct {
+	Chain         string `json:"chain"`
+	Blocks        int64  `json:"blocks"`
+	BestBlockhash string `json:"bestblockhash"`
+	MedianTime    int64  `json:"mediantime"`
+}
+
+// getBlockchainInfo calls the getblockchaininfo JSON-RPC method
+func getBlockchainInfo(rpcclient *rpcClient) (*blockchainInfo, error) {
+	rawResp, err := rpcclient.RawRequest("getblockchaininfo", nil)
+	if err != nil {
+		return nil, err
+	}
+	var info blockchainInfo
+	err = json.Unmarshal(rawResp, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// getNodeInfo gets the chain, version and RPC capabilities of the node from
+// getblockchaininfo, getnetworkinfo and the help for the methods used
+func getNodeInfo(rpcclient *rpcClient) (*libs.PingResult, error) {
+	info, err := getBlockchainInfo(rpcclient)
+	if err != nil {
+		return nil, fmt.Errorf("getblockchaininfo: %v", err)
+	}
+	rawResp, err := rpcclient.RawRequest("getnetworkinfo", nil)
+	if err != nil {
+		return nil, fmt.Errorf("getnetworkinfo: %v", err)
+	}
+	var netInfo struct {
+		Version         int64  `json:"version"`
+		Subversion      string `json:"subversion"`
+		ProtocolVersion int64  `json:"protocolversion"`
+	}
+	err = json.Unmarshal(rawResp, &netInfo)
+	if err != nil {
+		return nil, fmt.Errorf("getnetworkinfo: %v", err)
+	}
+
+	result := &libs.PingResult{
+		Chain:           info.Chain,
+		Blocks:          info.Blocks,
+		Version:         strconv.FormatInt(netInfo.Version, 10),
+		UserAgent:       netInfo.Subversion,
+		ProtocolVersion: netInfo.ProtocolVersion,
+	}
+	fundHelp := getHelp(rpcclient, "fundrawtransaction")
+	result.EstimateSmartFee = getHelp(rpcclient, "estimatesmartfee") != ""
+	result.FundFeeRate = strings.Contains(fundHelp, "feeRate")
+	result.ChangeType = strings.Contains(fundHelp, "change_type")
+	return result, nil
+}
+
+// getHelp gets the help text of a JSON-RPC method. It is empty if the node
+// does not have the method
+func getHelp(rpcclient *rpcClient, method string) string {
+	param, err := json.Marshal(method)
+	if err != nil {
+		return ""
+	}
+	rawResp, err := rpcclient.RawRequest("help", []json.RawMessage{param})
+	if err != nil {
+		return ""
+	}
+	var help string
+	err = json.Unmarshal(rawResp, &help)
+	if err != nil || strings.HasPrefix(help, "help: unknown command") {
+		return ""
+	}
+	return help
+}
+
+func getTransaction(rpcclient *rpcClient, txid string) (*libs.GetTxResult, error) {
+	txidBytes, err := json.Marshal(txid)
+	if err != nil {
+		return nil, err
+	}
+	param := []json.RawMessage{txidBytes}
+	rawResp, err := rpcclient.RawRequest("gettransaction", param)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Confirmations uint64 `json:"confirmations"`
+		Blockhash     string `json:"blockhash"`
+		Blockindex    int    `json:"blockindex"`
+		Blocktime     uint64 `json:"blocktime"`
+		Time          uint64 `json:"time"`
+		TimeReceived  uint64 `json:"timereceived"`
+		Hex           string `json:"hex"`
+	}
+
+	err = json.Unmarshal(rawResp, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var result libs.GetTxResult
+	result.Confirmations = resp.Confirmations
+	result.Blockhash = resp.Blockhash
+	result.Blockindex = resp.Blockindex
+	result.Blocktime = resp.Blocktime
+	result.Time = resp.Time
+	result.TimeReceived = resp.TimeReceived
+	result.Hex = resp.Hex
+	return &result, nil
+}
+
+// getNewAddress calls the getnewaddress JSON-RPC method.  Bitcoin Cash nodes
+// return a CashAddr which is decoded to the legacy P2PKH address type used by
+// the script code.
+func getNewAddress(network libs.Network, rpcclient *rpcClient) (btcutil.Address, error) {
+	chainParams := getChainParams(network)
+	rawResp, err := rpcclient.RawRequest("getnewaddress", nil)
+	if err != nil {
+		return nil, err
+	}
+	var addrStr string
+	err = json.Unmarshal(rawResp, &addrStr)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := decodeAddress(addrStr, chainParams)
+	if err != nil {
+		return nil, err
+	}
+	if !addr.IsForNet(chainParams) {
+		return nil, fmt.Errorf("address %v is not intended for use on %v",
+			addrStr, chainParams.Name)
+	}
+	if _, ok := addr.(*btcutil.AddressPubKeyHash); !ok {
+		return nil, fmt.Errorf("getnewaddress: address %v is not P2PKH",
+			addrStr)
+	}
+	return addr, nil
+}
+
+// getRawChangeAddress calls the getrawchangeaddress JSON-RPC method.  Bitcoin
+// Cash nodes take no address type parameter and return a CashAddr.
+func getRawChangeAddress(network libs.Network, rpcclient *rpcClient) (btcutil.Address, error) {
+	chainParams := getChainParams(network)
+	rawResp, err := rpcclient.RawRequest("getrawchangeaddress", nil)
+	if err != nil {
+		return nil, err
+	}
+	var addrStr string
+	err = json.Unmarshal(rawResp, &addrStr)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := decodeAddress(addrStr, chainParams)
+	if err != nil {
+		return nil, err
+	}
+	if !addr.IsForNet(chainParams) {
+		return nil, fmt.Errorf("address %v is not intended for use on %v",
+			addrStr, chainParams.Name)
+	}
+	if _, ok := addr.(*btcutil.AddressPubKeyHash); !ok {
+		return nil, fmt.Errorf("getrawchangeaddress: address %v is not P2PKH",
+			addrStr)
+	}
+	return addr, nil
+}
+
+// getFeePerKb queries the wallet for the transaction relay fee/kB to use and
+// the minimum mempool relay fee.  It first tries to get the user-set fee in the
+// wallet.  If unset, it attempts to find an estimate using estimatefee 6.  If
+// both of these fail, it falls back to mempool relay fee policy.
+func getFeePerKb(rpcclient *rpcClient) (useFee, relayFee btcutil.Amount, err error) {
+	var netInfoResp struct {
+		RelayFee float64 `json:"relayfee"`
+	}
+	var walletInfoResp struct {
+		PayTxFee float64 `json:"paytxfee"`
+	}
+	var estimateResp struct {
+		FeeRate float64 `json:"feerate"`
+	}
+
+	netInfoRawResp, err := rpcclient.RawRequest("getnetworkinfo", nil)
+	if err == nil {
+		err = json.Unmarshal(netInfoRawResp, &netInfoResp)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	walletInfoRawResp, err := rpcclient.RawRequest("getwalletinfo", nil)
+	if err == nil {
+		err = json.Unmarshal(walletInfoRawResp, &walletInfoResp)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	relayFee, err = btcutil.NewAmount(netInfoResp.RelayFee)
+	if err != nil {
+		return 0, 0, err
+	}
+	payTxFee, err := btcutil.NewAmount(walletInfoResp.PayTxFee)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Use user-set wallet fee when set and not lower than the network relay
+	// fee.
+	if payTxFee != 0 {
+		maxFee := payTxFee
+		if relayFee > maxFee {
+			maxFee = relayFee
+		}
+		return maxFee, relayFee, nil
+	}
+
+	params := []json.RawMessage{[]byte("6")}
+	estimateRawResp, err := rpcclient.RawRequest("estimatesmartfee", params)
+	if err == nil {
+		err = json.Unmarshal(estimateRawResp, &estimateResp)
+		if err == nil && estimateResp.FeeRate > 0 {
+			useFee, err = btcutil.NewAmount(estimateResp.FeeRate)
+			if relayFee > useFee {
+				useFee = relayFee
+			}
+			return useFee, relayFee, err
+		}
+	}
+
+	// Bitcoin ABC removed estimatesmartfee and estimatefee takes no
+	// confirmation target
+	var estimateFee float64
+	estimateRawResp, err = rpcclient.RawRequest("estimatefee", nil)
+	if err == nil {
+		err = json.Unmarshal(estimateRawResp, &estimateFee)
+		if err == nil && estimateFee > 0 {
+			useFee, err = btcutil.NewAmount(estimateFee)
+			if relayFee > useFee {
+				useFee = relayFee
+			}
+			return useFee, relayFee, err
+		}
+	}
+
+	rpcclient.log.Warn("falling back to mempool relay fee policy")
+	return relayFee, relayFee, nil
+}
+
+// fundRawTransaction calls the fundrawtransaction JSON-RPC method.  It is
+// implemented manually as client support is currently missing from the
+// btcd/rpcclient package.  Some Bitcoin Cash nodes (Bitcoin Unlimited) reject
+// the options parameter; in that case the call is retried without options and
+// the node's own fee policy is used.
+func fundRawTransaction(rpcclient *rpcClient, tx *wire.MsgTx, feePerKb btcutil.Amount) (fundedTx *wire.MsgTx, fee btcutil.Amount, err error) {
+	var buf bytes.Buffer
+	buf.Grow(tx.SerializeSize())
+	tx.Serialize(&buf)
+	param0, err := json.Marshal(hex.EncodeToString(buf.Bytes()))
+	if err != nil {
+		return nil, 0, err
+	}
+	param1, err := json.Marshal(struct {
+		FeeRate float64 `json:"feeRate"`
+	}{
+		FeeRate: feePerKb.ToBTC(),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	params := []json.RawMessage{param0, param1}
+	rawResp, err := rpcclient.RawRequest("fundrawtransaction", params)
+	if err != nil {
+		params = []json.RawMessage{param0}
+		rawResp, err = rpcclient.RawRequest("fundrawtransaction", params)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	var resp struct {
+		Hex       string  `json:"hex"`
+		Fee       float64 `json:"fee"`
+		ChangePos float64 `json:"changepos"`
+	}
+	err = json.Unmarshal(rawResp, &resp)
+	if err != nil {
+		return nil, 0, err
+	}
+	fundedTxBytes, err := hex.DecodeString(resp.Hex)
+	if err != nil {
+		return nil, 0, err
+	}
+	fundedTx = &wire.MsgTx{}
+	err = fundedTx.Deserialize(bytes.NewReader(fundedTxBytes))
+	if err != nil {
+		return nil, 0, err
+	}
+	feeAmount, err := btcutil.NewAmount(resp.Fee)
+	if err != nil {
+		return nil, 0, err
+	}
+	return fundedTx, feeAmount, nil
+}
+
+// fundsError makes a libs.FundsError of an insufficient funds error from
+// fundrawtransaction, with the wallet balance as the available amount
+func fundsError(rpcclient *rpcClient, required btcutil.Amount, err error) error {
+	if !libs.IsInsufficientFunds(err) {
+		return err
+	}
+	fundsErr := &libs.FundsError{Required: int64(required), Available: -1, Err: err}
+	rawResp, err := rpcclient.RawRequest("getbalance", nil)
+	if err != nil {
+		return fundsErr
+	}
+	var balance float64
+	err = json.Unmarshal(rawResp, &balance)
+	if err != nil {
+		return fundsErr
+	}
+	available, err := btcutil.NewAmount(balance)
+	if err == nil {
+		fundsErr.Available = int64(available)
+	}
+	return fundsErr
+}
+
+// createSig creates and returns the serialized raw signature and compressed
+// pubkey for a transaction input signature.  Bitcoin Cash signatures commit to
+// the amount of the output being spent and use the fork id sighash, so the
+// signing is done with bchutil rather than txscript.  This requires dumping a
+// private key and signing in the client, rather than letting the wallet sign.
+func createSig(network libs.Network, tx *wire.MsgTx, idx int, pkScript []byte, addr btcutil.Address,
+	rpcclient *rpcClient, amount int64) (sig, pubkey []byte, err error) {
+
+	done := rpcclient.observe("dumpprivkey")
+	wif, err := rpcclient.DumpPrivKey(addr)
+	done(err)
+	if err != nil {
+		return nil, nil, err
+	}
+	sig, err = bchutil.RawTxInSignature(tx, idx, pkScript, txscript2.SigHashAll, wif.PrivKey, amount)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, wif.PrivKey.PubKey().SerializeCompressed(), nil
+}
+
+func sendRawTransaction(rpcclient *rpcClient, tx *wire.MsgTx) (*chainhash.Hash, error) {
+	done := rpcclient.observe("sendrawtransaction")
+	txHash, err := rpcclient.SendRawTransaction(tx, false)
+	done(err)
+	if err != nil {
+		return nil, fmt.Errorf("sendrawtransaction: %v", err)
+	}
+	return txHash, nil
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package bch
+
+import (
+	"crypto/sha256"
+	"net"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// Get a normalized address from `addr' which can be of form Host[:Port]`
+func getNormalizedAddress(network libs.Network, addr string) (hostport string, err error) {
+	host, port, origErr := net.SplitHostPort(addr)
+	if origErr == nil {
+		return net.JoinHostPort(host, port), nil
+	}
+	defaultPort := getWalletPort(network)
+	addr = net.JoinHostPort(addr, defaultPort)
+	_, _, err = net.SplitHostPort(addr)
+	if err != nil {
+		return "", origErr
+	}
+	return addr, nil
+}
+
+// networks are the networks the library supports
+var networks = []libs.Network{libs.Mainnet, libs.Testnet, libs.Regtest}
+
+// checkNetwork checks the network is one the library supports
+func checkNetwork(network libs.Network) error {
+	return libs.CheckNetwork(network, networks...)
+}
+
+// Get the default wallet port
+func getWalletPort(network libs.Network) string {
+	switch network {
+	case libs.Testnet:
+		return "18332"
+	case libs.Regtest:
+		return "18443"
+	}
+	return "8332"
+}
+
+// Get all of the chain parameters for a network
+func getChainParams(network libs.Network) *chaincfg.Params {
+	switch network {
+	case libs.Testnet:
+		return &chaincfg.TestNet3Params
+	case libs.Regtest:
+		return &chaincfg.RegressionNetParams
+	}
+	return &chaincfg.MainNetParams
+}
+
+func sha256Hash(x []byte) []byte {
+	h := sha256.Sum256(x)
+	return h[:]
+}
+
+func calcFeePerKb(absoluteFee btcutil.Amount, serializeSize int) float64 {
+	return float64(absoluteFee) / float64(serializeSize) / 1e5
+}
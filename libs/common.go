@@ -4,7 +4,9 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/rand"
+	"strings"
 	"time"
 )
 
@@ -14,6 +16,55 @@ const hexstr32len = 32 * 2
 // for making transactions and other wallet functionality for
 // atomic swaps
 
+// Network is the coin network commands are made on. Not every coin has
+// every network
+type Network int
+
+// Networks
+const (
+	Mainnet Network = iota
+	Testnet
+	Regtest
+	Simnet
+	Signet
+)
+
+var networkNames = map[Network]string{
+	Mainnet: "mainnet",
+	Testnet: "testnet",
+	Regtest: "regtest",
+	Simnet:  "simnet",
+	Signet:  "signet",
+}
+
+func (n Network) String() string {
+	name, ok := networkNames[n]
+	if !ok {
+		return fmt.Sprintf("network(%d)", int(n))
+	}
+	return name
+}
+
+// ParseNetwork gets a network from its name
+func ParseNetwork(name string) (Network, error) {
+	for n, s := range networkNames {
+		if strings.EqualFold(name, s) {
+			return n, nil
+		}
+	}
+	return Mainnet, fmt.Errorf("unknown network %q", name)
+}
+
+// CheckNetwork checks a network is one of the networks a coin supports
+func CheckNetwork(network Network, supported ...Network) error {
+	for _, n := range supported {
+		if network == n {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not supported", network)
+}
+
 // RPCInfo is RPC information passed into commands
 // HostPort:	If no  port specified defaults to the coin's default
 // 				port for the network
@@ -23,6 +74,33 @@ type RPCInfo struct {
 	HostPort   string // RPC host[:port] can be ipv4 [ipv6]
 	WalletPass string // Wallet-passphrase
 	Certs      string // DCR Wallet
+
+	JSONRPC      bool   // DCR use the wallet JSON-RPC API with User & Pass rather than gRPC
+	NodeHostPort string // DCR dcrd JSON-RPC host[:port] for non-wallet transactions
+	NodeCerts    string // DCR dcrd JSON-RPC certificate
+
+	Observer RPCObserver // Told of each wallet & node RPC call - may be nil
+	Log      *Log        // Log of the command - nil for the default logger
+}
+
+// RPCObserver is told of each call a command makes to a wallet or node, such
+// as for metrics or tracing. It returns the func told of the call's error
+type RPCObserver func(method string) func(err error)
+
+// Observe tells the observer of a call. A nil observer ignores it
+func (o RPCObserver) Observe(method string) func(err error) {
+	if o == nil {
+		return func(error) {}
+	}
+	return o(method)
+}
+
+// WalletOptions are optional wallet settings for the commands that fund or
+// pay out of a contract. Coins that do not support an option ignore it (dcr)
+type WalletOptions struct {
+	Account       string // Wallet account number or name - default account 0
+	RequiredConfs int32  // Confirmations needed by outputs funding a contract
+	FeePerKb      int64  // Fee rate (sats/kB) - 0 uses the coin's default rate
 }
 
 //InitiateParams is passed to the Initiate function
@@ -30,6 +108,7 @@ type InitiateParams struct {
 	SecretHash string // Hash of the shared secret
 	CP2Addr    string // Counterparty 2 (Participant) Adddress
 	CP2Amount  int64  // Amount (sats) to pay into Participant redeemable contract
+	WalletOptions
 }
 
 //InitiateResult is returned from the Initiate function
@@ -48,6 +127,7 @@ type ParticipateParams struct {
 	SecretHash string
 	CP1Addr    string // Counterparty 1 (Initiator) contract Adddress
 	CP1Amount  int64  // Amount (sats) to pay into Initiator redeemable contract
+	WalletOptions
 }
 
 //ParticipateResult is returned from the Participate command
@@ -66,6 +146,7 @@ type RedeemParams struct {
 	Secret     string
 	Contract   string
 	ContractTx string
+	WalletOptions
 }
 
 // RedeemResult is returned from the Redeem command
@@ -80,6 +161,7 @@ type RedeemResult struct {
 type RefundParams struct {
 	Contract   string
 	ContractTx string
+	WalletOptions
 }
 
 // RefundResult is returned from Refund command
@@ -115,6 +197,100 @@ type GetTxResult struct {
 	Time          uint64
 	TimeReceived  uint64
 	Hex           string
+	Locked        bool // InstantSend or ChainLock locked (dash) - final without confirmations
+}
+
+// WatchParams is passed to the WatchContract command
+type WatchParams struct {
+	Contract     string
+	ContractTxid string
+	SpendTxid    string // spend found by an earlier poll
+	ScanHeight   int64  // next block searched for the spend (dcr)
+	Import       bool   // import the contract script to the wallet watch-only
+	Rescan       bool   // rescan the chain on import for a mined contract
+}
+
+// WatchResult is returned from the WatchContract command. It is the state of a
+// contract on the chain at one poll
+type WatchResult struct {
+	Blocks             int64  // Best block height
+	BestBlockhash      string // Best block hash
+	MedianTime         int64  // Median time past of the best block (dcr, eth: block time)
+	Found              bool   // Contract tx is in the mempool or the chain
+	Confirmations      int64  // Contract tx confirmations - 0 in the mempool
+	Blockhash          string // Block of the contract tx
+	RefundLocktime     int64
+	Spent              bool   // Contract is spent, in the mempool or the chain
+	SpendTxid          string // Empty while a spend is not found (eth: always)
+	SpendTx            string
+	SpendConfirmations int64
+	Redeemed           bool   // Spent by a redeem, otherwise by a refund
+	Secret             string // Secret revealed by the redeem
+	ScannedHeight      int64  // Last block searched for the spend (dcr)
+}
+
+// PingResult is returned from the PingRPC command. It describes the node the
+// wallet RPC is connected to
+type PingResult struct {
+	Chain           string // Chain the node is on (main, test.. dcr net name, eth chain id)
+	Blocks          int64  // Best block height
+	Version         string // Node version
+	UserAgent       string // Node user agent (subversion)
+	ProtocolVersion int64  // Node p2p protocol version
+	Capabilities
+}
+
+// Capabilities are node RPC features the commands use when available
+type Capabilities struct {
+	EstimateSmartFee bool // Node has estimatesmartfee
+	FundFeeRate      bool // fundrawtransaction takes a feeRate option
+	ChangeType       bool // fundrawtransaction takes a change_type option
+	DynamicFees      bool // ETH chain has EIP-1559 base fees
+}
+
+// FundsError is returned when the wallet has too little to fund a contract.
+// Amounts are in the coin's smallest unit (gwei for ETH). Available is -1 if
+// the wallet balance is not known
+type FundsError struct {
+	Required  int64
+	Available int64
+	Err       error
+}
+
+func (e *FundsError) Error() string {
+	return fmt.Sprintf("insufficient funds: required %d available %d: %v", e.Required, e.Available, e.Err)
+}
+
+// Unwrap gets the wallet error
+func (e *FundsError) Unwrap() error {
+	return e.Err
+}
+
+// IsInsufficientFunds checks if a wallet error is an insufficient funds error
+func IsInsufficientFunds(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "insufficient funds")
+}
+
+var coreChainNames = map[Network]string{
+	Mainnet: "main",
+	Testnet: "test",
+	Regtest: "regtest",
+	Signet:  "signet",
+}
+
+// CoreChainName gets the chain name a bitcoin core-like node reports in
+// getblockchaininfo for a network
+func CoreChainName(network Network) string {
+	return coreChainNames[network]
+}
+
+// CheckChain checks the chain a node reports is the chain of the network
+// the command was made on
+func CheckChain(network Network, chain, want string) error {
+	if chain != want {
+		return fmt.Errorf("node is on chain %q not %s (%q)", chain, network, want)
+	}
+	return nil
 }
 
 // GetRand32 creates a 32-'byte' pseudo random hex string
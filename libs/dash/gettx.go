@@ -0,0 +1,23 @@
+package dash
+
+import (
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+func getTx(network libs.Network, rpcinfo libs.RPCInfo, txid string) (*libs.GetTxResult, error) {
+	rpcclient, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		rpcclient.Shutdown()
+		rpcclient.WaitForShutdown()
+	}()
+
+	result, err := getTransaction(rpcclient, txid)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
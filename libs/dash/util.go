@@ -0,0 +1,102 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package dash
+
+import (
+	"crypto/sha256"
+	"net"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// Get a normalized address from `addr' which can be of form Host[:Port]`
+func getNormalizedAddress(network libs.Network, addr string) (hostport string, err error) {
+	host, port, origErr := net.SplitHostPort(addr)
+	if origErr == nil {
+		return net.JoinHostPort(host, port), nil
+	}
+	defaultPort := getWalletPort(network)
+	addr = net.JoinHostPort(addr, defaultPort)
+	_, _, err = net.SplitHostPort(addr)
+	if err != nil {
+		return "", origErr
+	}
+	return addr, nil
+}
+
+// networks are the networks the library supports
+var networks = []libs.Network{libs.Mainnet, libs.Testnet, libs.Regtest}
+
+// checkNetwork checks the network is one the library supports
+func checkNetwork(network libs.Network) error {
+	return libs.CheckNetwork(network, networks...)
+}
+
+// Get the default wallet port
+func getWalletPort(network libs.Network) string {
+	switch network {
+	case libs.Testnet:
+		return "19998"
+	case libs.Regtest:
+		return "19898"
+	}
+	return "9998"
+}
+
+// Dash chain parameters. Only the fields used to encode and decode
+// addresses and keys differ from bitcoin
+var (
+	mainNetParams = newChainParams(&chaincfg.MainNetParams, "dash-mainnet", 0xbd6b0cbf, 0x4c, 0x10, 0xcc)
+	testNetParams = newChainParams(&chaincfg.TestNet3Params, "dash-testnet", 0xffcae2ce, 0x8c, 0x13, 0xef)
+	regTestParams = newChainParams(&chaincfg.RegressionNetParams, "dash-regtest", 0xdcb7c1fc, 0x8c, 0x13, 0xef)
+)
+
+func newChainParams(base *chaincfg.Params, name string, net uint32, pubKeyHashAddrID, scriptHashAddrID, privateKeyID byte) *chaincfg.Params {
+	params := *base
+	params.Name = name
+	params.Net = wire.BitcoinNet(net)
+	params.DefaultPort = ""
+	params.DNSSeeds = nil
+	params.Checkpoints = nil
+	params.PubKeyHashAddrID = pubKeyHashAddrID
+	params.ScriptHashAddrID = scriptHashAddrID
+	params.PrivateKeyID = privateKeyID
+	params.Bech32HRPSegwit = "" // no segwit
+	return &params
+}
+
+// Regtest uses the testnet address ids so is not registered. Addresses are
+// decoded against the params passed and need no registration
+func init() {
+	for _, params := range []*chaincfg.Params{mainNetParams, testNetParams} {
+		err := chaincfg.Register(params)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Get all of the chain parameters for a network
+func getChainParams(network libs.Network) *chaincfg.Params {
+	switch network {
+	case libs.Testnet:
+		return testNetParams
+	case libs.Regtest:
+		return regTestParams
+	}
+	return mainNetParams
+}
+
+func sha256Hash(x []byte) []byte {
+	h := sha256.Sum256(x)
+	return h[:]
+}
+
+func calcFeePerKb(absoluteFee btcutil.Amount, serializeSize int) float64 {
+	return float64(absoluteFee) / float64(serializeSize) / 1e5
+}
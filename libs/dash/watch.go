@@ -0,0 +1,49 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package dash
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// watchContract gets the state of a contract on the chain with the contract
+// watcher of the bitcoin-like coins
+func watchContract(network libs.Network, rpcinfo libs.RPCInfo, params libs.WatchParams) (*libs.WatchResult, error) {
+	contract, err := hex.DecodeString(params.Contract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract: %v", err)
+	}
+	pushes, err := txscript.ExtractAtomicSwapDataPushes(0, contract)
+	if err != nil {
+		return nil, err
+	}
+	if pushes == nil {
+		return nil, errors.New("contract is not an atomic swap script recognized by this tool")
+	}
+	pkScript, err := txscript.NewScriptBuilder().AddOp(txscript.OP_HASH160).
+		AddData(btcutil.Hash160(contract)).AddOp(txscript.OP_EQUAL).Script()
+	if err != nil {
+		return nil, err
+	}
+
+	rpcclient, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	defer stopRPC(rpcclient)
+
+	return libs.WatchContract(rpcclient, libs.WatchedContract{
+		PkScript:      pkScript,
+		SecretHash:    pushes.SecretHash[:],
+		LockTime:      pushes.LockTime,
+		ExtractSecret: extractSecret,
+	}, params)
+}
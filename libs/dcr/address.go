@@ -5,29 +5,23 @@
 package dcr
 
 import (
-	"context"
-
-	"github.com/decred/dcrwallet/rpc/walletrpc"
 	"github.com/devwarrior777/atomicswap/libs"
 )
 
 // newaddress gets a new wallet address from the controlled wallet
-func newaddress(testnet bool, rpcinfo libs.RPCInfo) (string, error) {
-	wallet, err := startRPC(testnet, rpcinfo)
+func newaddress(network libs.Network, rpcinfo libs.RPCInfo) (string, error) {
+	wallet, err := startRPC(network, rpcinfo)
 	if err != nil {
 		return "", err
 	}
 	defer wallet.stopRPC()
-	ctx := context.Background()
+	end := wallet.session().Begin()
+	defer end()
 
-	nar, err := wallet.client.NextAddress(ctx, &walletrpc.NextAddressRequest{
-		Account:   0, // TODO
-		Kind:      walletrpc.NextAddressRequest_BIP0044_INTERNAL,
-		GapPolicy: walletrpc.NextAddressRequest_GAP_POLICY_WRAP,
-	})
+	addr, err := wallet.nextAddress("")
 	if err != nil {
 		return "", err
 	}
 
-	return nar.Address, nil
+	return addr.EncodeAddress(), nil
 }
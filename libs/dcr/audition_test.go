@@ -0,0 +1,103 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package dcr
+
+import (
+	"testing"
+
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// Testnet swap transactions made with fixed keys. The contract transaction
+// pays 1.23456789 DCR to the contract and the redemption transaction spends
+// it with the secret. Both pass the script engine with verifyFlags.
+const (
+	testContract = "6382012088c020fe12dcbe232d81ec55b71699cb906ae55d210df22373833e3c" +
+		"04bf01b7c7801e8876a914e9fd2560fd18d6545c20e614362adb38da2ee77667" +
+		"0440f80a5db17576a91425a0836fd943de677aa0cb7fa75b61edd007a0ba6888" +
+		"ac"
+
+	testContractTx = "0100000001012a1ae13d7457b623ad620d03d513a1ca1c5271db2d8e180a7760" +
+		"b4ea69f6ef0100000000ffffffff0215cd5b0700000000000017a914e385b411" +
+		"56580e250348d7e4042cac4045b068a18797828a070000000000001976a91425" +
+		"a0836fd943de677aa0cb7fa75b61edd007a0ba88ac00000000000000000180b2" +
+		"e60e0000000000000000ffffffff6b4830450221009cf1c728d4add6f8164a50" +
+		"57cee94d84bf75b1f5e3ec785e98e353e91175cda502201b5b76ebd9a9501747" +
+		"68f5db04c6577a6f70b81e98fd05c198f9d7a66f64fca1012102a8cdc0bcb2aa" +
+		"054951e3ee7e8b345e654f8124d2e759458408829e230dcf38c1"
+
+	testRedeemTx = "0100000001c27a6e4546930681870fb8dced3c97d7e20798e9f148b1a46121b6" +
+		"eeb179439c0000000000ffffffff01e5575b070000000000001976a914e9fd25" +
+		"60fd18d6545c20e614362adb38da2ee77688ac00000000000000000115cd5b07" +
+		"0000000000000000fffffffff0483045022100967af707a301553c2a662c4e0b" +
+		"fe4fce271c046c0ec23e19b0886304e58c901702203ddcbc70578a03f97f1f5b" +
+		"bc70c5c22978c7d881214037c6bcb195d40c2e78e9012103a8e07101e2a8ac27" +
+		"c711b60ad00730c5818be4ca22f58faafac4c0a6be6e1f92203d26fd854bc711" +
+		"a931d06821ded2c9963408520c7129ce47a07f1edd4bd8d18c514c6163820120" +
+		"88c020fe12dcbe232d81ec55b71699cb906ae55d210df22373833e3c04bf01b7" +
+		"c7801e8876a914e9fd2560fd18d6545c20e614362adb38da2ee776670440f80a" +
+		"5db17576a91425a0836fd943de677aa0cb7fa75b61edd007a0ba6888ac"
+
+	testContractP2SH  = "TctFqbxkcv4R3NyydLBhGQPJRSzWed91QRv"
+	testRecipientAddr = "TsnMMB9da7rbqDHUqs2BeKCPHcv54VbUvGT"
+	testRefundAddr    = "TsUT5mKro8ydt7KA2RYbYPjCReEJU4fGoA5"
+	testLocktime      = 1561000000
+	testContractValue = 123456789
+
+	testSecret     = "3d26fd854bc711a931d06821ded2c9963408520c7129ce47a07f1edd4bd8d18c"
+	testSecretHash = "fe12dcbe232d81ec55b71699cb906ae55d210df22373833e3c04bf01b7c7801e"
+)
+
+func TestAuditContract(t *testing.T) {
+	result, err := AuditContract(libs.Testnet, libs.AuditParams{
+		Contract:   testContract,
+		ContractTx: testContractTx,
+	})
+	if err != nil {
+		t.Fatalf("AuditContract: %v", err)
+	}
+	if result.ContractAddress != testContractP2SH {
+		t.Errorf("contract address %s, want %s", result.ContractAddress, testContractP2SH)
+	}
+	if result.ContractAmount != testContractValue {
+		t.Errorf("contract amount %d, want %d", result.ContractAmount, testContractValue)
+	}
+	if result.ContractRecipientAddress != testRecipientAddr {
+		t.Errorf("recipient %s, want %s", result.ContractRecipientAddress, testRecipientAddr)
+	}
+	if result.ContractRefundAddress != testRefundAddr {
+		t.Errorf("refund address %s, want %s", result.ContractRefundAddress, testRefundAddr)
+	}
+	if result.ContractRefundLocktime != testLocktime {
+		t.Errorf("locktime %d, want %d", result.ContractRefundLocktime, testLocktime)
+	}
+	if result.ContractSecretHash != testSecretHash {
+		t.Errorf("secret hash %s, want %s", result.ContractSecretHash, testSecretHash)
+	}
+}
+
+func TestAuditContractErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		network    libs.Network
+		contract   string
+		contractTx string
+	}{
+		{"bad contract hex", libs.Testnet, "zz", testContractTx},
+		{"bad contract tx hex", libs.Testnet, testContract, "zz"},
+		{"truncated contract tx", libs.Testnet, testContract, testContractTx[:100]},
+		{"contract not paid by tx", libs.Testnet, testContract, testRedeemTx},
+		{"unsupported network", libs.Regtest, testContract, testContractTx},
+	}
+	for _, test := range tests {
+		_, err := AuditContract(test.network, libs.AuditParams{
+			Contract:   test.contract,
+			ContractTx: test.contractTx,
+		})
+		if err == nil {
+			t.Errorf("%s: no error", test.name)
+		}
+	}
+}
@@ -5,25 +5,25 @@
 package dcr
 
 import (
-	"bytes"
-	"context"
 	"fmt"
 
-	"github.com/decred/dcrwallet/rpc/walletrpc"
-
 	"github.com/decred/dcrd/dcrutil"
 	"github.com/decred/dcrd/txscript"
 	"github.com/decred/dcrd/wire"
+	"github.com/devwarrior777/atomicswap/libs"
 	"golang.org/x/crypto/ripemd160"
 )
 
 // contractArgs specifies the common parameters used to create the initiator's
-// and participant's contract.
+// and participant's contract.  The contract is funded with the wallet options
+// account, required confirmations and fee rate.  A zero fee rate uses the
+// wallet's fee rate
 type contractArgs struct {
 	them       *dcrutil.AddressPubKeyHash
 	amount     dcrutil.Amount
 	locktime   int64
 	secretHash []byte
+	opts       libs.WalletOptions
 }
 
 // builtContract houses the details regarding a contract and the contract
@@ -38,20 +38,10 @@ type builtContract struct {
 // buildContract creates a contract for the parameters specified in args, using
 // wallet RPC to generate an internal address to redeem the refund and to sign
 // the payment to the contract transaction.
-func buildContract(testnet bool, c walletrpc.WalletServiceClient, args *contractArgs, p string) (*builtContract, error) {
-	ctx := context.Background()
-	passphrase := []byte(p)
-	chainParams := getChainParams(testnet)
-
-	nar, err := c.NextAddress(ctx, &walletrpc.NextAddressRequest{
-		Account:   0, // TODO
-		Kind:      walletrpc.NextAddressRequest_BIP0044_INTERNAL,
-		GapPolicy: walletrpc.NextAddressRequest_GAP_POLICY_WRAP,
-	})
-	if err != nil {
-		return nil, err
-	}
-	refundAddr, err := dcrutil.DecodeAddress(nar.Address)
+func buildContract(network libs.Network, wallet walletClient, args *contractArgs, p string) (*builtContract, error) {
+	chainParams := getChainParams(network)
+
+	refundAddr, err := wallet.nextAddress(args.opts.Account)
 	if err != nil {
 		return nil, err
 	}
@@ -73,37 +63,17 @@ func buildContract(testnet bool, c walletrpc.WalletServiceClient, args *contract
 		return nil, err
 	}
 
-	ctr, err := c.ConstructTransaction(ctx, &walletrpc.ConstructTransactionRequest{
-		SourceAccount: 0, // TODO
-		NonChangeOutputs: []*walletrpc.ConstructTransactionRequest_Output{{
-			Destination: &walletrpc.ConstructTransactionRequest_OutputDestination{
-				Script:        contractP2SHPkScript,
-				ScriptVersion: 0,
-			},
-			Amount: int64(args.amount),
-		}},
-	})
-	if err != nil {
-		return nil, err
-	}
-	contractFee := dcrutil.Amount(ctr.TotalPreviousOutputAmount - ctr.TotalOutputAmount)
-	str, err := c.SignTransaction(ctx, &walletrpc.SignTransactionRequest{
-		Passphrase:            passphrase,
-		SerializedTransaction: ctr.UnsignedTransaction,
-	})
-	if err != nil {
-		return nil, err
-	}
-	var contractTx wire.MsgTx
-	err = contractTx.Deserialize(bytes.NewReader(str.Transaction))
+	unsignedContract := wire.NewMsgTx()
+	unsignedContract.AddTxOut(wire.NewTxOut(int64(args.amount), contractP2SHPkScript))
+	contractTx, contractFee, err := wallet.fundTransaction(unsignedContract, args.opts, p)
 	if err != nil {
-		return nil, err
+		return nil, fundsError(wallet, args.amount, args.opts, err)
 	}
 
 	return &builtContract{
 		contract,
 		contractP2SH,
-		contractTx,
+		*contractTx,
 		contractFee,
 	}, nil
 }
@@ -0,0 +1,40 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package dcr
+
+import (
+	"testing"
+)
+
+func TestExtractSecret(t *testing.T) {
+	secret, err := ExtractSecret(testRedeemTx, testSecretHash)
+	if err != nil {
+		t.Fatalf("ExtractSecret: %v", err)
+	}
+	if secret != testSecret {
+		t.Fatalf("secret %s, want %s", secret, testSecret)
+	}
+}
+
+func TestExtractSecretErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		redemptionTx string
+		secretHash   string
+	}{
+		{"bad tx hex", "zz", testSecretHash},
+		{"truncated tx", testRedeemTx[:100], testSecretHash},
+		{"bad secret hash hex", testRedeemTx, "zz"},
+		{"short secret hash", testRedeemTx, testSecretHash[:62]},
+		{"no secret in tx", testContractTx, testSecretHash},
+		{"other secret hash", testRedeemTx, testSecret},
+	}
+	for _, test := range tests {
+		_, err := ExtractSecret(test.redemptionTx, test.secretHash)
+		if err == nil {
+			t.Errorf("%s: no error", test.name)
+		}
+	}
+}
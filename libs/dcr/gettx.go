@@ -1,44 +1,62 @@
 package dcr
 
 import (
-	"context"
 	"encoding/hex"
 	"errors"
 
-	"github.com/decred/dcrwallet/rpc/walletrpc"
 	"github.com/devwarrior777/atomicswap/libs"
 )
 
 const hexstr32 = 32 * 2
 
-func getTx(testnet bool, rpcinfo libs.RPCInfo, txid string) (*libs.GetTxResult, error) {
+func getTx(network libs.Network, rpcinfo libs.RPCInfo, txid string) (*libs.GetTxResult, error) {
 	if len(txid) != hexstr32 {
 		return nil, errors.New("txid: bad length")
 	}
-	txidBytes, err := hex.DecodeString(txid)
+	_, err := hex.DecodeString(txid)
 	if err != nil {
 		return nil, err
 	}
-	wireTxHash := byteRev(txidBytes)
 
-	wallet, err := startRPC(testnet, rpcinfo)
+	wallet, err := startRPC(network, rpcinfo)
 	if err != nil {
 		return nil, err
 	}
 	defer wallet.stopRPC()
-	ctx := context.Background()
 
-	var gtr *walletrpc.GetTransactionResponse
-	gtr, err = wallet.client.GetTransaction(ctx, &walletrpc.GetTransactionRequest{
-		TransactionHash: wireTxHash,
-	})
+	result, err := wallet.getTransaction(txid)
+	if err == errTxNotFound && rpcinfo.NodeHostPort != "" {
+		// not a wallet transaction
+		return getNodeTx(network, rpcinfo, txid)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getNodeTx gets info on a transaction from the dcrd node
+func getNodeTx(network libs.Network, rpcinfo libs.RPCInfo, txid string) (*libs.GetTxResult, error) {
+	node, err := startNodeRPC(network, rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	defer node.stopRPC()
+
+	tx, err := node.getRawTransaction(txid)
 	if err != nil {
 		return nil, err
 	}
 
 	result := &libs.GetTxResult{}
-	result.Confirmations = uint64(gtr.Confirmations)
-	result.Blockhash = hex.EncodeToString(byteRev(gtr.BlockHash))
+	result.Confirmations = tx.Confirmations
+	result.Blockhash = tx.BlockHash
+	result.Blockindex = tx.BlockIndex
+	result.Blocktime = tx.Blocktime
+	result.Time = tx.Time
+	result.TimeReceived = tx.Time
+	result.Hex = tx.Hex
 
 	return result, nil
 }
@@ -19,8 +19,8 @@ import (
 
 // initiate builds a contract & a contract transaction depending on the secret hash parameter
 // passed in
-func initiate(testnet bool, rpcinfo libs.RPCInfo, params libs.InitiateParams) (*libs.InitiateResult, error) {
-	chainParams := getChainParams(testnet)
+func initiate(network libs.Network, rpcinfo libs.RPCInfo, params libs.InitiateParams) (*libs.InitiateResult, error) {
+	chainParams := getChainParams(network)
 
 	cp2Addr, err := dcrutil.DecodeAddress(params.CP2Addr)
 	if err != nil {
@@ -46,22 +46,30 @@ func initiate(testnet bool, rpcinfo libs.RPCInfo, params libs.InitiateParams) (*
 		return nil, errors.New("secret hash has wrong size")
 	}
 
+	err = checkWalletOptions(params.WalletOptions)
+	if err != nil {
+		return nil, err
+	}
+
 	// locktime after 500,000,000 (Tue Nov  5 00:53:20 1985 UTC) is interpreted
 	// as a unix time rather than a block height.
 	locktime := time.Now().Add(48 * time.Hour).Unix()
 	// locktime := time.Now().Add(48 * time.Minute).Unix() //Test
 
-	wallet, err := startRPC(testnet, rpcinfo)
+	wallet, err := startRPC(network, rpcinfo)
 	if err != nil {
 		return nil, err
 	}
 	defer wallet.stopRPC()
+	end := wallet.session().Begin()
+	defer end()
 
-	b, err := buildContract(testnet, wallet.client, &contractArgs{
+	b, err := buildContract(network, wallet, &contractArgs{
 		them:       cp2AddrP2PKH,
 		amount:     cp2Amount,
 		locktime:   locktime,
 		secretHash: secretHash,
+		opts:       params.WalletOptions,
 	}, rpcinfo.WalletPass)
 	if err != nil {
 		return nil, err
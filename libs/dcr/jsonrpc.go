@@ -0,0 +1,411 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package dcr
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/decred/dcrd/txscript"
+	"github.com/decred/dcrd/wire"
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// rpcClient is a JSON-RPC client for dcrd and dcrwallet
+type rpcClient struct {
+	url       string
+	user      string
+	pass      string
+	transport *http.Transport
+	client    *http.Client
+	observer  libs.RPCObserver
+}
+
+// newRPCClient makes a JSON-RPC client for the server at hostport.  The
+// server certificate is read from certPath.  An empty certPath connects
+// without TLS.  The observer, which may be nil, is told of each call
+func newRPCClient(hostport, certPath, user, pass string, observer libs.RPCObserver) (*rpcClient, error) {
+	transport := &http.Transport{}
+	url := "http://" + hostport
+	if certPath != "" {
+		pem, err := ioutil.ReadFile(certPath)
+		if err != nil {
+			return nil, fmt.Errorf("open certificate: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in %s", certPath)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+		url = "https://" + hostport
+	}
+	return &rpcClient{
+		url:       url,
+		user:      user,
+		pass:      pass,
+		transport: transport,
+		client:    &http.Client{Transport: transport},
+		observer:  observer,
+	}, nil
+}
+
+// stopRPC closes the client connections
+func (c *rpcClient) stopRPC() {
+	c.transport.CloseIdleConnections()
+}
+
+// rpcError is an error returned by a JSON-RPC server
+type rpcError struct {
+	Method  string
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("%s: %d: %s", e.Method, e.Code, e.Message)
+}
+
+// errRPCNoTxInfo is the JSON-RPC error code for an unknown transaction
+const errRPCNoTxInfo = -5
+
+// call makes a JSON-RPC request and unmarshals the result into result
+func (c *rpcClient) call(method string, params []interface{}, result interface{}) error {
+	done := c.observer.Observe(method)
+	err := c.request(method, params, result)
+	done(err)
+	return err
+}
+
+// request makes a JSON-RPC request for call
+func (c *rpcClient) request(method string, params []interface{}, result interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, err := json.Marshal(struct {
+		JSONRPC string        `json:"jsonrpc"`
+		ID      int           `json:"id"`
+		Method  string        `json:"method"`
+		Params  []interface{} `json:"params"`
+	}{"1.0", 1, method, params})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.user, c.pass)
+	httpResp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	err = json.Unmarshal(respBody, &resp)
+	if err != nil {
+		if httpResp.StatusCode != http.StatusOK {
+			return fmt.Errorf("%s: %s", method, httpResp.Status)
+		}
+		return fmt.Errorf("%s: %v", method, err)
+	}
+	if resp.Error != nil {
+		resp.Error.Method = method
+		return resp.Error
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, result)
+}
+
+// isRPCError checks if err is a JSON-RPC error with the code
+func isRPCError(err error, code int) bool {
+	e, ok := err.(*rpcError)
+	return ok && e.Code == code
+}
+
+// unlockTimeout is the longest (seconds) the wallet is unlocked to sign. The
+// wallet is locked again as soon as no command of this process is signing
+const unlockTimeout = 60
+
+// jsonWallet is a dcrwallet JSON-RPC API client and implements walletClient
+type jsonWallet struct {
+	*rpcClient
+	sess *libs.WalletSession
+}
+
+// startJSONRPC - starts a new dcrwallet JSON-RPC client for the network and
+// address specified along with rpc user & rpc password, in RPCInfo. Certs is
+// the path of the wallet RPC certificate - if empty TLS is not used
+func startJSONRPC(network libs.Network, rpcinfo libs.RPCInfo) (*jsonWallet, error) {
+	hostport, err := normalizeAddress(rpcinfo.HostPort, getJSONRPCPort(network))
+	if err != nil {
+		return nil, fmt.Errorf("wallet server address: %v", err)
+	}
+	client, err := newRPCClient(hostport, rpcinfo.Certs, rpcinfo.User, rpcinfo.Pass, rpcinfo.Observer)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonWallet{rpcClient: client, sess: libs.Session("dcr", network, hostport)}, nil
+}
+
+// accountName gets the name of an account.  The JSON-RPC API selects accounts
+// by name so of the account numbers only the default account 0 is known
+func accountName(account string) (string, error) {
+	if account == "" || account == "0" {
+		return "default", nil
+	}
+	if _, err := strconv.ParseUint(account, 10, 32); err == nil {
+		return "", fmt.Errorf("account %s: the wallet JSON-RPC API needs an account name", account)
+	}
+	return account, nil
+}
+
+// unlock unlocks an encrypted wallet to sign and returns a func to lock it
+// again.  The wallet is only locked once no other signing of the wallet
+// session needs it.  If 'p' == "" (empty string) we assume the wallet is not
+// encrypted
+func (w *jsonWallet) unlock(p string) (func(), error) {
+	if len(p) == 0 {
+		return func() {}, nil
+	}
+	err := w.sess.Unlock(func() error {
+		return w.call("walletpassphrase", []interface{}{p, unlockTimeout}, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		w.sess.Release(func() { _ = w.call("walletlock", nil, nil) })
+	}, nil
+}
+
+func (w *jsonWallet) session() *libs.WalletSession {
+	return w.sess
+}
+
+func (w *jsonWallet) ping() error {
+	return w.call("walletinfo", nil, nil)
+}
+
+// currentNet calls the getcurrentnet JSON-RPC method
+func (w *jsonWallet) currentNet() (wire.CurrencyNet, error) {
+	var net uint32
+	err := w.call("getcurrentnet", nil, &net)
+	if err != nil {
+		return 0, err
+	}
+	return wire.CurrencyNet(net), nil
+}
+
+// walletInfo calls the version and getblockcount JSON-RPC methods
+func (w *jsonWallet) walletInfo() (*libs.PingResult, error) {
+	var versions map[string]struct {
+		VersionString string `json:"versionstring"`
+	}
+	err := w.call("version", nil, &versions)
+	if err != nil {
+		return nil, err
+	}
+	var blocks int64
+	err = w.call("getblockcount", nil, &blocks)
+	if err != nil {
+		return nil, err
+	}
+	return &libs.PingResult{
+		Blocks:  blocks,
+		Version: versions["dcrwalletjsonrpcapi"].VersionString,
+	}, nil
+}
+
+// nextAddress calls the getrawchangeaddress JSON-RPC method
+func (w *jsonWallet) nextAddress(account string) (dcrutil.Address, error) {
+	name, err := accountName(account)
+	if err != nil {
+		return nil, err
+	}
+	var addr string
+	err = w.call("getrawchangeaddress", []interface{}{name}, &addr)
+	if err != nil {
+		return nil, err
+	}
+	return dcrutil.DecodeAddress(addr)
+}
+
+// fundTransaction adds inputs and change to the transaction with the
+// fundrawtransaction JSON-RPC method and signs it with signrawtransaction
+func (w *jsonWallet) fundTransaction(tx *wire.MsgTx, opts libs.WalletOptions, p string) (*wire.MsgTx, dcrutil.Amount, error) {
+	name, err := accountName(opts.Account)
+	if err != nil {
+		return nil, 0, err
+	}
+	var buf bytes.Buffer
+	buf.Grow(tx.SerializeSize())
+	tx.Serialize(&buf)
+	fundOpts := struct {
+		ChangeAccount string   `json:"changeaccount"`
+		FeeRate       *float64 `json:"feerate,omitempty"`
+		ConfTarget    int32    `json:"conf_target"`
+	}{
+		ChangeAccount: name,
+		ConfTarget:    opts.RequiredConfs,
+	}
+	if opts.FeePerKb != 0 {
+		feeRate := dcrutil.Amount(opts.FeePerKb).ToCoin()
+		fundOpts.FeeRate = &feeRate
+	}
+	var fundResp struct {
+		Hex string  `json:"hex"`
+		Fee float64 `json:"fee"`
+	}
+	err = w.call("fundrawtransaction", []interface{}{hex.EncodeToString(buf.Bytes()), name, fundOpts}, &fundResp)
+	if err != nil {
+		return nil, 0, err
+	}
+	fee, err := dcrutil.NewAmount(fundResp.Fee)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	lock, err := w.unlock(p)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer lock()
+	var signResp struct {
+		Hex      string `json:"hex"`
+		Complete bool   `json:"complete"`
+	}
+	err = w.call("signrawtransaction", []interface{}{fundResp.Hex}, &signResp)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !signResp.Complete {
+		return nil, 0, errors.New("signrawtransaction: failed to completely sign transaction")
+	}
+	signedTx, err := decodeTx(signResp.Hex)
+	if err != nil {
+		return nil, 0, err
+	}
+	return signedTx, fee, nil
+}
+
+// balance calls the getbalance JSON-RPC method
+func (w *jsonWallet) balance(account string, requiredConfs int32) (dcrutil.Amount, error) {
+	name, err := accountName(account)
+	if err != nil {
+		return 0, err
+	}
+	var balances struct {
+		Balances []struct {
+			Spendable float64 `json:"spendable"`
+		} `json:"balances"`
+	}
+	err = w.call("getbalance", []interface{}{name, requiredConfs}, &balances)
+	if err != nil {
+		return 0, err
+	}
+	if len(balances.Balances) == 0 {
+		return 0, fmt.Errorf("getbalance: no balance for account %s", name)
+	}
+	return dcrutil.NewAmount(balances.Balances[0].Spendable)
+}
+
+// createSignature creates the signature and compressed pubkey for a
+// transaction input.  The JSON-RPC API has no way to sign an input for a
+// script so this dumps the private key and signs in the client
+func (w *jsonWallet) createSignature(tx *wire.MsgTx, idx int, pkScript []byte, addr dcrutil.Address, p string) (sig, pubkey []byte, err error) {
+	lock, err := w.unlock(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer lock()
+	var privKey string
+	err = w.call("dumpprivkey", []interface{}{addr.EncodeAddress()}, &privKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	wif, err := dcrutil.DecodeWIF(privKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	sig, err = txscript.RawTxInSignature(tx, idx, pkScript, txscript.SigHashAll, wif.PrivKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, wif.SerializePubKey(), nil
+}
+
+// getTransaction calls the gettransaction JSON-RPC method
+func (w *jsonWallet) getTransaction(txid string) (*libs.GetTxResult, error) {
+	var resp struct {
+		Confirmations uint64 `json:"confirmations"`
+		Blockhash     string `json:"blockhash"`
+		Blockindex    int    `json:"blockindex"`
+		Blocktime     uint64 `json:"blocktime"`
+		Time          uint64 `json:"time"`
+		TimeReceived  uint64 `json:"timereceived"`
+		Hex           string `json:"hex"`
+	}
+	err := w.call("gettransaction", []interface{}{txid}, &resp)
+	if isRPCError(err, errRPCNoTxInfo) {
+		return nil, errTxNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result libs.GetTxResult
+	result.Confirmations = resp.Confirmations
+	result.Blockhash = resp.Blockhash
+	result.Blockindex = resp.Blockindex
+	result.Blocktime = resp.Blocktime
+	result.Time = resp.Time
+	result.TimeReceived = resp.TimeReceived
+	result.Hex = resp.Hex
+	return &result, nil
+}
+
+// publishTransaction calls the sendrawtransaction JSON-RPC method
+func (w *jsonWallet) publishTransaction(tx []byte) (string, error) {
+	var txid string
+	err := w.call("sendrawtransaction", []interface{}{hex.EncodeToString(tx)}, &txid)
+	if err != nil {
+		return "", err
+	}
+	return txid, nil
+}
+
+// decodeTx decodes a hex serialized transaction
+func decodeTx(s string) (*wire.MsgTx, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var tx wire.MsgTx
+	err = tx.Deserialize(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
@@ -0,0 +1,380 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package dcr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/chainec"
+	"github.com/decred/dcrd/dcrec"
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/decred/dcrd/txscript"
+	"github.com/decred/dcrd/wire"
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+type testMethod func(params []json.RawMessage) (interface{}, *rpcError)
+
+// testWallet is a dcrwallet JSON-RPC server with an encrypted wallet
+type testWallet struct {
+	t        *testing.T
+	methods  map[string]testMethod
+	unlocked bool
+	calls    []string
+}
+
+// newTestWallet starts a dcrwallet JSON-RPC server answering the methods and
+// returns the RPCInfo to reach it
+func newTestWallet(t *testing.T, methods map[string]testMethod) (*testWallet, libs.RPCInfo) {
+	w := &testWallet{t: t, methods: methods}
+	srv := httptest.NewServer(w)
+	t.Cleanup(srv.Close)
+	return w, libs.RPCInfo{
+		User:       "user",
+		Pass:       "pass",
+		HostPort:   strings.TrimPrefix(srv.URL, "http://"),
+		WalletPass: "123",
+		JSONRPC:    true,
+	}
+}
+
+func (w *testWallet) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		w.t.Errorf("bad request: %v", err)
+		http.Error(rw, "bad request", http.StatusBadRequest)
+		return
+	}
+	w.calls = append(w.calls, req.Method)
+
+	var result interface{}
+	var rpcErr *rpcError
+	switch req.Method {
+	case "getcurrentnet":
+		result = wire.TestNet3
+	case "walletpassphrase":
+		var pass string
+		json.Unmarshal(req.Params[0], &pass)
+		if pass != "123" {
+			rpcErr = &rpcError{Code: -14, Message: "invalid passphrase"}
+			break
+		}
+		w.unlocked = true
+	case "walletlock":
+		w.unlocked = false
+	case "dumpprivkey", "signrawtransaction":
+		if !w.unlocked {
+			rpcErr = &rpcError{Code: -13, Message: "wallet is locked"}
+			break
+		}
+		fallthrough
+	default:
+		method, ok := w.methods[req.Method]
+		if !ok {
+			rpcErr = &rpcError{Code: -32601, Message: "method not found"}
+			break
+		}
+		result, rpcErr = method(req.Params)
+	}
+	json.NewEncoder(rw).Encode(map[string]interface{}{
+		"result": result,
+		"error":  rpcErr,
+		"id":     1,
+	})
+}
+
+func testKey(t *testing.T, b byte) (chainec.PrivateKey, *dcrutil.AddressPubKeyHash, string) {
+	privKey, pubKey := chainec.Secp256k1.PrivKeyFromBytes(bytes.Repeat([]byte{b}, 32))
+	addr, err := dcrutil.NewAddressPubKeyHash(dcrutil.Hash160(pubKey.SerializeCompressed()),
+		getChainParams(libs.Testnet), dcrec.STEcdsaSecp256k1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wif, err := dcrutil.NewWIF(privKey, getChainParams(libs.Testnet), dcrec.STEcdsaSecp256k1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return privKey, addr, wif.String()
+}
+
+func serializeTx(tx *wire.MsgTx) string {
+	var buf bytes.Buffer
+	tx.Serialize(&buf)
+	return hex.EncodeToString(buf.Bytes())
+}
+
+func TestJSONRPCInitiate(t *testing.T) {
+	_, changeAddr, _ := testKey(t, 1)
+	_, partAddr, _ := testKey(t, 2)
+
+	var fundOpts map[string]interface{}
+	w, rpcinfo := newTestWallet(t, map[string]testMethod{
+		"getrawchangeaddress": func(params []json.RawMessage) (interface{}, *rpcError) {
+			var account string
+			json.Unmarshal(params[0], &account)
+			if account != "swaps" {
+				return nil, &rpcError{Code: -4, Message: "account not found"}
+			}
+			return changeAddr.EncodeAddress(), nil
+		},
+		"fundrawtransaction": func(params []json.RawMessage) (interface{}, *rpcError) {
+			var txHex, account string
+			json.Unmarshal(params[0], &txHex)
+			json.Unmarshal(params[1], &account)
+			json.Unmarshal(params[2], &fundOpts)
+			tx, err := decodeTx(txHex)
+			if err != nil || account != "swaps" {
+				return nil, &rpcError{Code: -8, Message: "bad fundrawtransaction"}
+			}
+			tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, 0, nil))
+			return map[string]interface{}{"hex": serializeTx(tx), "fee": 0.0001}, nil
+		},
+		"signrawtransaction": func(params []json.RawMessage) (interface{}, *rpcError) {
+			var txHex string
+			json.Unmarshal(params[0], &txHex)
+			return map[string]interface{}{"hex": txHex, "complete": true}, nil
+		},
+	})
+
+	secretHash, err := libs.Hash256(libs.GetRand32())
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := Initiate(libs.Testnet, rpcinfo, libs.InitiateParams{
+		SecretHash: secretHash,
+		CP2Addr:    partAddr.EncodeAddress(),
+		CP2Amount:  1e8,
+		WalletOptions: libs.WalletOptions{
+			Account:       "swaps",
+			RequiredConfs: 1,
+			FeePerKb:      2e4,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Initiate: %v", err)
+	}
+	if w.unlocked {
+		t.Error("wallet left unlocked")
+	}
+	if fundOpts["conf_target"] != 1.0 || fundOpts["feerate"] != 0.0002 || fundOpts["changeaccount"] != "swaps" {
+		t.Errorf("fundrawtransaction options %v", fundOpts)
+	}
+	if result.ContractFee != 1e4 {
+		t.Errorf("contract fee %d", result.ContractFee)
+	}
+
+	audit, err := AuditContract(libs.Testnet, libs.AuditParams{
+		Contract:   result.Contract,
+		ContractTx: result.ContractTx,
+	})
+	if err != nil {
+		t.Fatalf("AuditContract: %v", err)
+	}
+	if audit.ContractAmount != 1e8 || audit.ContractSecretHash != secretHash ||
+		audit.ContractRecipientAddress != partAddr.EncodeAddress() ||
+		audit.ContractRefundAddress != changeAddr.EncodeAddress() {
+		t.Errorf("audit %+v", audit)
+	}
+
+	_, err = Initiate(libs.Testnet, rpcinfo, libs.InitiateParams{
+		SecretHash:    secretHash,
+		CP2Addr:       partAddr.EncodeAddress(),
+		CP2Amount:     1e8,
+		WalletOptions: libs.WalletOptions{Account: "1"},
+	})
+	if err == nil {
+		t.Error("initiated from an account number")
+	}
+}
+
+func TestJSONRPCRedeem(t *testing.T) {
+	_, refundAddr, _ := testKey(t, 3)
+	_, recipientAddr, recipientWIF := testKey(t, 4)
+	_, redeemAddr, _ := testKey(t, 5)
+
+	secret := libs.GetRand32()
+	secretHash, err := libs.Hash256(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretHashBytes, _ := hex.DecodeString(secretHash)
+	locktime := time.Now().Add(24 * time.Hour).Unix()
+	contract, err := atomicSwapContract(refundAddr.Hash160(), recipientAddr.Hash160(),
+		locktime, secretHashBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contractP2SH, err := dcrutil.NewAddressScriptHash(contract, getChainParams(libs.Testnet))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkScript, err := txscript.PayToAddrScript(contractP2SH)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contractTx := wire.NewMsgTx()
+	contractTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, 0, nil))
+	contractTx.AddTxOut(wire.NewTxOut(1e8, pkScript))
+
+	w, rpcinfo := newTestWallet(t, map[string]testMethod{
+		"getrawchangeaddress": func(params []json.RawMessage) (interface{}, *rpcError) {
+			return redeemAddr.EncodeAddress(), nil
+		},
+		"dumpprivkey": func(params []json.RawMessage) (interface{}, *rpcError) {
+			var addr string
+			json.Unmarshal(params[0], &addr)
+			if addr != recipientAddr.EncodeAddress() {
+				return nil, &rpcError{Code: -4, Message: "address not found in wallet"}
+			}
+			return recipientWIF, nil
+		},
+	})
+
+	// Redeem verifies the redemption with the script engine
+	result, err := Redeem(libs.Testnet, rpcinfo, libs.RedeemParams{
+		Secret:     secret,
+		Contract:   hex.EncodeToString(contract),
+		ContractTx: serializeTx(contractTx),
+	})
+	if err != nil {
+		t.Fatalf("Redeem: %v", err)
+	}
+	if w.unlocked {
+		t.Error("wallet left unlocked")
+	}
+	redeemTx, err := decodeTx(result.RedeemTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipientPkScript, _ := txscript.PayToAddrScript(recipientAddr)
+	if !bytes.Equal(redeemTx.TxOut[0].PkScript, recipientPkScript) {
+		t.Error("redemption does not pay the recipient address")
+	}
+
+	// an account pays a new address of the account
+	accountResult, err := Redeem(libs.Testnet, rpcinfo, libs.RedeemParams{
+		Secret:        secret,
+		Contract:      hex.EncodeToString(contract),
+		ContractTx:    serializeTx(contractTx),
+		WalletOptions: libs.WalletOptions{Account: "swaps"},
+	})
+	if err != nil {
+		t.Fatalf("Redeem: %v", err)
+	}
+	accountTx, err := decodeTx(accountResult.RedeemTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	redeemPkScript, _ := txscript.PayToAddrScript(redeemAddr)
+	if !bytes.Equal(accountTx.TxOut[0].PkScript, redeemPkScript) {
+		t.Error("redemption does not pay the account address")
+	}
+
+	extracted, err := ExtractSecret(result.RedeemTx, secretHash)
+	if err != nil {
+		t.Fatalf("ExtractSecret: %v", err)
+	}
+	if extracted != secret {
+		t.Errorf("extracted secret %s", extracted)
+	}
+}
+
+func TestJSONRPCGetTx(t *testing.T) {
+	_, rpcinfo := newTestWallet(t, map[string]testMethod{
+		"gettransaction": func(params []json.RawMessage) (interface{}, *rpcError) {
+			var txid string
+			json.Unmarshal(params[0], &txid)
+			if txid != testNodeTxid {
+				return nil, &rpcError{Code: errRPCNoTxInfo, Message: "No information for transaction"}
+			}
+			return map[string]interface{}{
+				"confirmations": 2,
+				"blockhash":     "0000000000000000000000000000000000000000000000000000000000000001",
+				"blockindex":    1,
+				"blocktime":     1561000100,
+				"time":          1561000000,
+				"timereceived":  1561000000,
+				"hex":           "0100",
+			}, nil
+		},
+	})
+
+	result, err := GetTx(libs.Testnet, rpcinfo, testNodeTxid)
+	if err != nil {
+		t.Fatalf("GetTx: %v", err)
+	}
+	want := libs.GetTxResult{
+		Confirmations: 2,
+		Blockhash:     "0000000000000000000000000000000000000000000000000000000000000001",
+		Blockindex:    1,
+		Blocktime:     1561000100,
+		Time:          1561000000,
+		TimeReceived:  1561000000,
+		Hex:           "0100",
+	}
+	if *result != want {
+		t.Errorf("got %+v, want %+v", *result, want)
+	}
+
+	_, err = GetTx(libs.Testnet, rpcinfo, strings.Repeat("00", 32))
+	if err != errTxNotFound {
+		t.Errorf("non-wallet transaction without a node: %v", err)
+	}
+
+	// non-wallet transactions are looked up on the node
+	node := newTestNode(t)
+	rpcinfo.NodeHostPort = node.NodeHostPort
+	rpcinfo.NodeCerts = node.NodeCerts
+	_, err = GetTx(libs.Testnet, rpcinfo, strings.Repeat("00", 32))
+	if err == nil || !strings.Contains(err.Error(), "getrawtransaction") {
+		t.Errorf("non-wallet transaction with a node: %v", err)
+	}
+}
+
+func TestJSONRPCPing(t *testing.T) {
+	_, rpcinfo := newTestWallet(t, map[string]testMethod{
+		"walletinfo": func(params []json.RawMessage) (interface{}, *rpcError) {
+			return map[string]interface{}{"unlocked": false}, nil
+		},
+		"version": func(params []json.RawMessage) (interface{}, *rpcError) {
+			return map[string]interface{}{
+				"dcrwalletjsonrpcapi": map[string]interface{}{"versionstring": "6.2.0"},
+			}, nil
+		},
+		"getblockcount": func(params []json.RawMessage) (interface{}, *rpcError) {
+			return 300000, nil
+		},
+	})
+
+	result, err := PingRPC(libs.Testnet, rpcinfo)
+	if err != nil {
+		t.Fatalf("PingRPC: %v", err)
+	}
+	want := libs.PingResult{
+		Chain:   "testnet3",
+		Blocks:  300000,
+		Version: "6.2.0",
+	}
+	if *result != want {
+		t.Errorf("got %+v, want %+v", *result, want)
+	}
+
+	// the test wallet is on testnet
+	_, err = PingRPC(libs.Mainnet, rpcinfo)
+	if err == nil || !strings.Contains(err.Error(), "wallet is on") {
+		t.Errorf("mainnet ping of a testnet wallet: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package dcr
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// node is a dcrd JSON-RPC client.  The wallet gRPC API only knows about the
+// wallet's own transactions so others are looked up on the node
+type node struct {
+	*rpcClient
+}
+
+// startNodeRPC - starts a new dcrd JSON-RPC client for the network and the
+// node address & certs path, along with rpc user & rpc password, in RPCInfo
+func startNodeRPC(network libs.Network, rpcinfo libs.RPCInfo) (*node, error) {
+	if rpcinfo.NodeHostPort == "" {
+		return nil, errors.New("no dcrd node address")
+	}
+	hostport, err := normalizeAddress(rpcinfo.NodeHostPort, getNodePort(network))
+	if err != nil {
+		return nil, fmt.Errorf("node server address: %v", err)
+	}
+	certPath := rpcinfo.NodeCerts
+	if certPath == "" {
+		//default path
+		certPath = filepath.Join(dcrutil.AppDataDir("dcrd", false), "rpc.cert")
+	}
+	client, err := newRPCClient(hostport, certPath, rpcinfo.User, rpcinfo.Pass, rpcinfo.Observer)
+	if err != nil {
+		return nil, err
+	}
+	return &node{client}, nil
+}
+
+// rawTransaction is the verbose getrawtransaction result
+type rawTransaction struct {
+	Hex           string `json:"hex"`
+	Txid          string `json:"txid"`
+	BlockHash     string `json:"blockhash"`
+	BlockIndex    int    `json:"blockindex"`
+	Confirmations uint64 `json:"confirmations"`
+	Time          uint64 `json:"time"`
+	Blocktime     uint64 `json:"blocktime"`
+	BlockHeight   int64  `json:"blockheight"`
+	Vin           []struct {
+		Txid string `json:"txid"`
+		Vout uint32 `json:"vout"`
+	} `json:"vin"`
+	Vout []struct {
+		N            uint32 `json:"n"`
+		ScriptPubKey struct {
+			Hex string `json:"hex"`
+		} `json:"scriptPubKey"`
+	} `json:"vout"`
+}
+
+// spends checks if the transaction spends an output
+func (tx *rawTransaction) spends(txid string, vout uint32) bool {
+	for _, in := range tx.Vin {
+		if in.Txid == txid && in.Vout == vout {
+			return true
+		}
+	}
+	return false
+}
+
+// getRawTransaction calls the verbose getrawtransaction JSON-RPC method.  The
+// node needs a transaction index (--txindex) for mined transactions that are
+// not in the wallet
+func (n *node) getRawTransaction(txid string) (*rawTransaction, error) {
+	var tx rawTransaction
+	err := n.call("getrawtransaction", []interface{}{txid, 1}, &tx)
+	if err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package dcr
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+const testNodeTxid = "a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90"
+
+// newTestNode starts a TLS JSON-RPC server answering getrawtransaction for
+// testNodeTxid and returns the RPCInfo to reach it
+func newTestNode(t *testing.T) libs.RPCInfo {
+	return startTestNode(t, func(method string, params []interface{}) string {
+		if method != "getrawtransaction" || len(params) != 2 {
+			t.Errorf("unexpected request %s %v", method, params)
+			return ""
+		}
+		if params[0] != testNodeTxid {
+			return `{"result":null,"error":{"code":-5,"message":"No information available about transaction"},"id":1}`
+		}
+		return `{"result":{"hex":"0100","txid":"` + testNodeTxid + `",` +
+			`"blockhash":"000000000000000012d5a4cc0c6e9cf23c0d4a1c3fd1a0b0a1b2c3d4e5f60718",` +
+			`"blockheight":300000,"blockindex":3,"confirmations":6,` +
+			`"time":1561000000,"blocktime":1561000000},"error":null,"id":1}`
+	})
+}
+
+// startTestNode starts a TLS JSON-RPC server with user and pass credentials
+// answering requests with the response body from respond, an empty body is a
+// bad request. It returns the RPCInfo to reach it
+func startTestNode(t *testing.T, respond func(method string, params []interface{}) string) libs.RPCInfo {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "user" || pass != "pass" {
+			http.Error(w, "401 Unauthorized.", http.StatusUnauthorized)
+			return
+		}
+		var req struct {
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			t.Errorf("bad request: %v", err)
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		body := respond(req.Method, req.Params)
+		if body == "" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	certFile, err := ioutil.TempFile("", "dcrd-rpc-cert")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Remove(certFile.Name()) })
+	err = pem.Encode(certFile, &pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile.Close()
+
+	return libs.RPCInfo{
+		User:         "user",
+		Pass:         "pass",
+		NodeHostPort: strings.TrimPrefix(srv.URL, "https://"),
+		NodeCerts:    certFile.Name(),
+	}
+}
+
+func TestGetNodeTx(t *testing.T) {
+	rpcinfo := newTestNode(t)
+
+	result, err := getNodeTx(libs.Testnet, rpcinfo, testNodeTxid)
+	if err != nil {
+		t.Fatalf("getNodeTx: %v", err)
+	}
+	want := libs.GetTxResult{
+		Confirmations: 6,
+		Blockhash:     "000000000000000012d5a4cc0c6e9cf23c0d4a1c3fd1a0b0a1b2c3d4e5f60718",
+		Blockindex:    3,
+		Blocktime:     1561000000,
+		Time:          1561000000,
+		TimeReceived:  1561000000,
+		Hex:           "0100",
+	}
+	if *result != want {
+		t.Fatalf("got %+v, want %+v", *result, want)
+	}
+
+	_, err = getNodeTx(libs.Testnet, rpcinfo, strings.Repeat("00", 32))
+	if err == nil || !strings.Contains(err.Error(), "No information available") {
+		t.Fatalf("unknown transaction: %v", err)
+	}
+
+	rpcinfo.Pass = "wrong"
+	_, err = getNodeTx(libs.Testnet, rpcinfo, testNodeTxid)
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("bad credentials: %v", err)
+	}
+}
@@ -20,8 +20,8 @@ import (
 // participate builds a contract & a contract transaction depending upon the hash of the
 // (shared) secret. The participant will know the secret only when initiator redeems the
 // contract made here
-func participate(testnet bool, rpcinfo libs.RPCInfo, params libs.ParticipateParams) (*libs.ParticipateResult, error) {
-	chainParams := getChainParams(testnet)
+func participate(network libs.Network, rpcinfo libs.RPCInfo, params libs.ParticipateParams) (*libs.ParticipateResult, error) {
+	chainParams := getChainParams(network)
 
 	cp1Addr, err := dcrutil.DecodeAddress(params.CP1Addr)
 	if err != nil {
@@ -46,22 +46,30 @@ func participate(testnet bool, rpcinfo libs.RPCInfo, params libs.ParticipatePara
 		return nil, errors.New("secret hash has wrong size")
 	}
 
+	err = checkWalletOptions(params.WalletOptions)
+	if err != nil {
+		return nil, err
+	}
+
 	// locktime after 500,000,000 (Tue Nov  5 00:53:20 1985 UTC) is interpreted
 	// as a unix time rather than a block height.
 	locktime := time.Now().Add(24 * time.Hour).Unix()
 	// locktime := time.Now().Add(24 * time.Minute).Unix() //Test
 
-	wallet, err := startRPC(testnet, rpcinfo)
+	wallet, err := startRPC(network, rpcinfo)
 	if err != nil {
 		return nil, err
 	}
 	defer wallet.stopRPC()
+	end := wallet.session().Begin()
+	defer end()
 
-	b, err := buildContract(testnet, wallet.client, &contractArgs{
+	b, err := buildContract(network, wallet, &contractArgs{
 		them:       cp1AddrP2PKH,
 		amount:     cp1Amount,
 		locktime:   locktime,
 		secretHash: secretHash,
+		opts:       params.WalletOptions,
 	}, rpcinfo.WalletPass)
 	if err != nil {
 		return nil, err
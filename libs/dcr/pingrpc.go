@@ -8,18 +8,24 @@ import (
 	"github.com/devwarrior777/atomicswap/libs"
 )
 
-// pingrpc tests if wallet node RPC is available
-func pingrpc(testnet bool, rpcinfo libs.RPCInfo) error {
-	wallet, err := startRPC(testnet, rpcinfo)
+// pingrpc tests if wallet node RPC is available and gets the wallet RPC API
+// version and best block. The network of the wallet is checked by startRPC
+func pingrpc(network libs.Network, rpcinfo libs.RPCInfo) (*libs.PingResult, error) {
+	wallet, err := startRPC(network, rpcinfo)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer wallet.stopRPC()
 
 	err = wallet.ping()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	result, err := wallet.walletInfo()
+	if err != nil {
+		return nil, err
+	}
+	result.Chain = getChainParams(network).Name
+	return result, nil
 }
@@ -6,7 +6,6 @@ package dcr
 
 import (
 	"bytes"
-	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -15,18 +14,14 @@ import (
 	"github.com/decred/dcrd/dcrutil"
 	"github.com/decred/dcrd/txscript"
 	"github.com/decred/dcrd/wire"
-	"github.com/decred/dcrwallet/rpc/walletrpc"
 	"github.com/decred/dcrwallet/wallet/txrules"
 	"github.com/devwarrior777/atomicswap/libs"
 )
 
 // Build a transaction that can redeem the coins in the passed in contract using
 // the (shared) secret
-func redeem(testnet bool, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs.RedeemResult, error) {
-	chainParams := getChainParams(testnet)
-
-	// get params suitable for dcr functions
-	passphrase := []byte(rpcinfo.WalletPass)
+func redeem(network libs.Network, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs.RedeemResult, error) {
+	chainParams := getChainParams(network)
 
 	contract, err := hex.DecodeString(params.Contract)
 	if err != nil {
@@ -48,6 +43,12 @@ func redeem(testnet bool, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs
 		return nil, fmt.Errorf("failed to decode secret: %v", err)
 	}
 
+	err = checkWalletOptions(params.WalletOptions)
+	if err != nil {
+		return nil, err
+	}
+	redeemFeePerKb := getFeePerKb(params.WalletOptions)
+
 	pushes, err := txscript.ExtractAtomicSwapDataPushes(
 		txscript.DefaultScriptVersion, contract)
 	if err != nil {
@@ -74,11 +75,29 @@ func redeem(testnet bool, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs
 		return nil, errors.New("transaction does not contain a contract output")
 	}
 
-	outScript, err := txscript.PayToAddrScript(recipientAddr)
+	rpcinfo.Log.Debug("redeem", libs.F("recipient_address", recipientAddr.EncodeAddress()))
+
+	wallet, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	defer wallet.stopRPC()
+	end := wallet.session().Begin()
+	defer end()
+
+	// pay the redeemed coins to the contract recipient address unless an
+	// account is selected, then to a new internal address of the account
+	var redeemAddr dcrutil.Address = recipientAddr
+	if params.Account != "" {
+		redeemAddr, err = wallet.nextAddress(params.Account)
+		if err != nil {
+			return nil, err
+		}
+	}
+	outScript, err := txscript.PayToAddrScript(redeemAddr)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Printf("recipient address: %s\n", recipientAddr.EncodeAddress())
 
 	contractTxHash := contractTx.TxHash()
 	contractOutPoint := wire.OutPoint{
@@ -92,36 +111,19 @@ func redeem(testnet bool, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs
 	redeemTx.AddTxIn(wire.NewTxIn(&contractOutPoint, 0, nil))
 	redeemTx.AddTxOut(wire.NewTxOut(0, outScript)) // amount set below
 	redeemSize := estimateRedeemSerializeSize(contract, redeemTx.TxOut)
-	redeemFee := txrules.FeeForSerializeSize(feePerKb, redeemSize)
+	redeemFee := txrules.FeeForSerializeSize(redeemFeePerKb, redeemSize)
 	redeemTx.TxOut[0].Value = contractTx.TxOut[contractOutIdx].Value - int64(redeemFee)
-	if txrules.IsDustOutput(redeemTx.TxOut[0], feePerKb) {
+	if txrules.IsDustOutput(redeemTx.TxOut[0], txrules.DefaultRelayFeePerKb) {
 		return nil, fmt.Errorf("redeem output value of %v is dust", dcrutil.Amount(redeemTx.TxOut[0].Value))
 	}
 
-	wallet, err := startRPC(testnet, rpcinfo)
-	if err != nil {
-		return nil, err
-	}
-	defer wallet.stopRPC()
-	ctx := context.Background()
-
-	var buf bytes.Buffer
-	buf.Grow(redeemTx.SerializeSize())
-	redeemTx.Serialize(&buf)
-
-	redeemSig, err := wallet.client.CreateSignature(ctx, &walletrpc.CreateSignatureRequest{
-		Passphrase:            passphrase,
-		Address:               recipientAddr.EncodeAddress(),
-		SerializedTransaction: buf.Bytes(),
-		InputIndex:            0,
-		HashType:              walletrpc.CreateSignatureRequest_SIGHASH_ALL,
-		PreviousPkScript:      contract,
-	})
+	redeemSig, redeemPubKey, err := wallet.createSignature(redeemTx, 0, contract,
+		recipientAddr, rpcinfo.WalletPass)
 	if err != nil {
 		return nil, err
 	}
-	redeemSigScript, err := redeemP2SHContract(contract, redeemSig.Signature,
-		redeemSig.PublicKey, secret)
+	redeemSigScript, err := redeemP2SHContract(contract, redeemSig,
+		redeemPubKey, secret)
 	if err != nil {
 		return nil, err
 	}
@@ -146,7 +148,6 @@ func redeem(testnet bool, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs
 	redeemTx.Serialize(&redeemBuf)
 	strRefundTx := hex.EncodeToString(redeemBuf.Bytes())
 
-	redeemFeePerKb := calcFeePerKb(redeemFee, redeemTx.SerializeSize())
 	redeemTxHash := redeemTx.TxHash()
 	strRedeemTxHash := redeemTxHash.String()
 
@@ -155,7 +156,7 @@ func redeem(testnet bool, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs
 	result.RedeemTx = strRefundTx
 	result.RedeemTxHash = strRedeemTxHash
 	result.RedeemFee = int64(redeemFee)
-	result.RedeemFeePerKb = redeemFeePerKb
+	result.RedeemFeePerKb = calcFeePerKb(redeemFee, redeemTx.SerializeSize())
 
 	return result, nil
 }
@@ -6,7 +6,6 @@ package dcr
 
 import (
 	"bytes"
-	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -16,17 +15,13 @@ import (
 	"github.com/decred/dcrd/dcrutil"
 	"github.com/decred/dcrd/txscript"
 	"github.com/decred/dcrd/wire"
-	"github.com/decred/dcrwallet/rpc/walletrpc"
 	"github.com/decred/dcrwallet/wallet/txrules"
 	"github.com/devwarrior777/atomicswap/libs"
 )
 
 // Build a transaction that can refund the coins back to the contract creator
-func refund(testnet bool, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs.RefundResult, error) {
-	chainParams := getChainParams(testnet)
-
-	// get params suitable for dcr functions
-	passphrase := []byte(rpcinfo.WalletPass)
+func refund(network libs.Network, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs.RefundResult, error) {
+	chainParams := getChainParams(network)
 
 	contract, err := hex.DecodeString(params.Contract)
 	if err != nil {
@@ -43,6 +38,12 @@ func refund(testnet bool, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs
 		return nil, fmt.Errorf("failed to decode contract transaction: %v", err)
 	}
 
+	err = checkWalletOptions(params.WalletOptions)
+	if err != nil {
+		return nil, err
+	}
+	refundFeePerKb := getFeePerKb(params.WalletOptions)
+
 	//--->
 	contractP2SH, err := dcrutil.NewAddressScriptHash(contract, chainParams)
 	if err != nil {
@@ -65,22 +66,16 @@ func refund(testnet bool, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs
 		return nil, errors.New("contract tx does not contain a P2SH contract payment")
 	}
 
-	wallet, err := startRPC(testnet, rpcinfo)
+	wallet, err := startRPC(network, rpcinfo)
 	if err != nil {
 		return nil, err
 	}
 	defer wallet.stopRPC()
-	ctx := context.Background()
+	end := wallet.session().Begin()
+	defer end()
 
-	nar, err := wallet.client.NextAddress(ctx, &walletrpc.NextAddressRequest{
-		Account:   0, // TODO
-		Kind:      walletrpc.NextAddressRequest_BIP0044_INTERNAL,
-		GapPolicy: walletrpc.NextAddressRequest_GAP_POLICY_WRAP,
-	})
-	if err != nil {
-		return nil, err
-	}
-	refundAddress, err := dcrutil.DecodeAddress(nar.Address)
+	// pay the refunded coins to the selected account
+	refundAddress, err := wallet.nextAddress(params.Account)
 	if err != nil {
 		return nil, err
 	}
@@ -104,9 +99,9 @@ func refund(testnet bool, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs
 	refundTx.LockTime = uint32(pushes.LockTime)
 	refundTx.AddTxOut(wire.NewTxOut(0, refundOutScript)) // amount set below
 	refundSize := estimateRefundSerializeSize(contract, refundTx.TxOut)
-	refundFee := txrules.FeeForSerializeSize(feePerKb, refundSize)
+	refundFee := txrules.FeeForSerializeSize(refundFeePerKb, refundSize)
 	refundTx.TxOut[0].Value = contractTx.TxOut[contractOutPoint.Index].Value - int64(refundFee)
-	if txrules.IsDustOutput(refundTx.TxOut[0], feePerKb) {
+	if txrules.IsDustOutput(refundTx.TxOut[0], txrules.DefaultRelayFeePerKb) {
 		return nil, fmt.Errorf("refund output value of %v is dust", dcrutil.Amount(refundTx.TxOut[0].Value))
 	}
 
@@ -114,23 +109,13 @@ func refund(testnet bool, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs
 	txIn.Sequence = 0
 	refundTx.AddTxIn(txIn)
 
-	var buf bytes.Buffer
-	buf.Grow(refundTx.SerializeSize())
-	refundTx.Serialize(&buf)
-
-	refundSig, err := wallet.client.CreateSignature(ctx, &walletrpc.CreateSignatureRequest{
-		Passphrase:            passphrase,
-		Address:               refundAddr.EncodeAddress(),
-		SerializedTransaction: buf.Bytes(),
-		InputIndex:            0,
-		HashType:              walletrpc.CreateSignatureRequest_SIGHASH_ALL,
-		PreviousPkScript:      contract,
-	})
+	refundSig, refundPubKey, err := wallet.createSignature(refundTx, 0, contract,
+		refundAddr, rpcinfo.WalletPass)
 	if err != nil {
 		return nil, err
 	}
-	refundSigScript, err := refundP2SHContract(contract, refundSig.Signature,
-		refundSig.PublicKey)
+	refundSigScript, err := refundP2SHContract(contract, refundSig,
+		refundPubKey)
 	if err != nil {
 		return nil, err
 	}
@@ -5,27 +5,114 @@
 package dcr
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"path"
 	"path/filepath"
+	"strconv"
+	"strings"
 
+	"github.com/decred/dcrd/chaincfg/chainhash"
 	"github.com/decred/dcrd/dcrutil"
+	"github.com/decred/dcrd/wire"
 
 	"github.com/decred/dcrwallet/rpc/walletrpc"
 	"github.com/devwarrior777/atomicswap/libs"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
-type wallet struct {
-	conn   *grpc.ClientConn
-	client walletrpc.WalletServiceClient
+// walletClient is the dcrwallet API used by the commands. It is implemented
+// by the gRPC API client (grpcWallet) and the JSON-RPC API client (jsonWallet)
+type walletClient interface {
+	stopRPC()
+	ping() error
+
+	// session gets the session serializing the wallet-mutating commands
+	session() *libs.WalletSession
+
+	// currentNet gets the network the wallet is on
+	currentNet() (wire.CurrencyNet, error)
+
+	// walletInfo gets the wallet RPC API version and best block height
+	walletInfo() (*libs.PingResult, error)
+
+	// nextAddress gets a new internal address of the account
+	nextAddress(account string) (dcrutil.Address, error)
+
+	// fundTransaction adds inputs from the account and change to tx and signs
+	// the result
+	fundTransaction(tx *wire.MsgTx, opts libs.WalletOptions, p string) (*wire.MsgTx, dcrutil.Amount, error)
+
+	// balance gets the spendable balance of the account
+	balance(account string, requiredConfs int32) (dcrutil.Amount, error)
+
+	// createSignature signs the transaction input spending pkScript with the
+	// key of addr and returns the signature and the compressed pubkey
+	createSignature(tx *wire.MsgTx, idx int, pkScript []byte, addr dcrutil.Address, p string) (sig, pubkey []byte, err error)
+
+	// getTransaction gets info on a wallet transaction or errTxNotFound
+	getTransaction(txid string) (*libs.GetTxResult, error)
+
+	// publishTransaction broadcasts a serialized transaction
+	publishTransaction(tx []byte) (string, error)
 }
 
-// starRPC - starts a new GRPC client for the network and address specified
+// errTxNotFound is returned by getTransaction for non-wallet transactions
+var errTxNotFound = errors.New("transaction not found in the wallet")
+
+// startRPC starts a wallet client using the gRPC API or, if RPCInfo JSONRPC
+// is set, the JSON-RPC API
+func startRPC(network libs.Network, rpcinfo libs.RPCInfo) (walletClient, error) {
+	var wallet walletClient
+	var err error
+	if rpcinfo.JSONRPC {
+		wallet, err = startJSONRPC(network, rpcinfo)
+	} else {
+		wallet, err = startGRPC(network, rpcinfo)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = checkChain(network, wallet)
+	if err != nil {
+		wallet.stopRPC()
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// checkChain checks the wallet is on the network
+func checkChain(network libs.Network, wallet walletClient) error {
+	net, err := wallet.currentNet()
+	if err != nil {
+		return fmt.Errorf("wallet network: %v", err)
+	}
+	chainParams := getChainParams(network)
+	if net != chainParams.Net {
+		return fmt.Errorf("wallet is on %v not %s (%v)", net, network, chainParams.Net)
+	}
+	return nil
+}
+
+// grpcWallet is a dcrwallet gRPC API client and implements walletClient
+type grpcWallet struct {
+	conn    *grpc.ClientConn
+	client  walletrpc.WalletServiceClient
+	network libs.Network
+	rpcinfo libs.RPCInfo
+	sess    *libs.WalletSession
+}
+
+// startGRPC - starts a new GRPC client for the network and address specified
 //            along with the certs path, in RPCInfo
-func startRPC(testnet bool, rpcinfo libs.RPCInfo) (*wallet, error) {
-	hostport, err := getNormalizedAddress(testnet, rpcinfo.HostPort)
+func startGRPC(network libs.Network, rpcinfo libs.RPCInfo) (*grpcWallet, error) {
+	hostport, err := getNormalizedAddress(network, rpcinfo.HostPort)
 	if err != nil {
 		return nil, fmt.Errorf("wallet server address: %v", err)
 	}
@@ -38,9 +125,10 @@ func startRPC(testnet bool, rpcinfo libs.RPCInfo) (*wallet, error) {
 	if err != nil {
 		return nil, fmt.Errorf("open certificate: %v", err)
 	}
-	wallet := &wallet{}
+	wallet := &grpcWallet{network: network, rpcinfo: rpcinfo, sess: libs.Session("dcr", network, hostport)}
 	// get a connection to the server
-	wallet.conn, err = grpc.Dial(hostport, grpc.WithTransportCredentials(creds))
+	wallet.conn, err = grpc.Dial(hostport, grpc.WithTransportCredentials(creds),
+		grpc.WithUnaryInterceptor(observeGRPC(rpcinfo.Observer)))
 	if err != nil {
 		return nil, fmt.Errorf("grpc dial: %v", err)
 	}
@@ -49,8 +137,20 @@ func startRPC(testnet bool, rpcinfo libs.RPCInfo) (*wallet, error) {
 	return wallet, err
 }
 
+// observeGRPC tells the observer of each dcrwallet gRPC call by its method
+// name, such as Balance
+func observeGRPC(observer libs.RPCObserver) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		done := observer.Observe(path.Base(method))
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		done(err)
+		return err
+	}
+}
+
 // stopRPC closes the client connection
-func (w *wallet) stopRPC() {
+func (w *grpcWallet) stopRPC() {
 	w.conn.Close()
 }
 
@@ -58,7 +158,11 @@ func (w *wallet) stopRPC() {
 // Miscellaneous GRPC funcs //
 //////////////////////////////
 
-func (w *wallet) ping() error {
+func (w *grpcWallet) session() *libs.WalletSession {
+	return w.sess
+}
+
+func (w *grpcWallet) ping() error {
 	request := &walletrpc.PingRequest{}
 	ctx := context.Background()
 	// ctx, cancel := context.WithCancel(context.Background())
@@ -66,3 +170,219 @@ func (w *wallet) ping() error {
 	_, err := w.client.Ping(ctx, request)
 	return err
 }
+
+func (w *grpcWallet) currentNet() (wire.CurrencyNet, error) {
+	ctx := context.Background()
+	resp, err := w.client.Network(ctx, &walletrpc.NetworkRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return wire.CurrencyNet(resp.ActiveNetwork), nil
+}
+
+func (w *grpcWallet) walletInfo() (*libs.PingResult, error) {
+	ctx := context.Background()
+	version, err := walletrpc.NewVersionServiceClient(w.conn).Version(ctx, &walletrpc.VersionRequest{})
+	if err != nil {
+		return nil, err
+	}
+	best, err := w.client.BestBlock(ctx, &walletrpc.BestBlockRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &libs.PingResult{
+		Blocks:  int64(best.Height),
+		Version: version.VersionString,
+	}, nil
+}
+
+// accountNumber gets the number of a wallet account given by number or by
+// name.  No account is the default account 0
+func (w *grpcWallet) accountNumber(account string) (uint32, error) {
+	if account == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(account, 10, 32)
+	if err == nil {
+		return uint32(n), nil
+	}
+	ctx := context.Background()
+	anr, err := w.client.AccountNumber(ctx, &walletrpc.AccountNumberRequest{
+		AccountName: account,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("account %q: %v", account, err)
+	}
+	return anr.AccountNumber, nil
+}
+
+func (w *grpcWallet) nextAddress(account string) (dcrutil.Address, error) {
+	accountNumber, err := w.accountNumber(account)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	nar, err := w.client.NextAddress(ctx, &walletrpc.NextAddressRequest{
+		Account:   accountNumber,
+		Kind:      walletrpc.NextAddressRequest_BIP0044_INTERNAL,
+		GapPolicy: walletrpc.NextAddressRequest_GAP_POLICY_WRAP,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dcrutil.DecodeAddress(nar.Address)
+}
+
+func (w *grpcWallet) fundTransaction(tx *wire.MsgTx, opts libs.WalletOptions, p string) (*wire.MsgTx, dcrutil.Amount, error) {
+	accountNumber, err := w.accountNumber(opts.Account)
+	if err != nil {
+		return nil, 0, err
+	}
+	outputs := make([]*walletrpc.ConstructTransactionRequest_Output, 0, len(tx.TxOut))
+	for _, out := range tx.TxOut {
+		outputs = append(outputs, &walletrpc.ConstructTransactionRequest_Output{
+			Destination: &walletrpc.ConstructTransactionRequest_OutputDestination{
+				Script:        out.PkScript,
+				ScriptVersion: uint32(out.Version),
+			},
+			Amount: out.Value,
+		})
+	}
+	ctx := context.Background()
+	ctr, err := w.client.ConstructTransaction(ctx, &walletrpc.ConstructTransactionRequest{
+		SourceAccount:         accountNumber,
+		RequiredConfirmations: opts.RequiredConfs,
+		FeePerKb:              int32(opts.FeePerKb),
+		NonChangeOutputs:      outputs,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	fee := dcrutil.Amount(ctr.TotalPreviousOutputAmount - ctr.TotalOutputAmount)
+	str, err := w.client.SignTransaction(ctx, &walletrpc.SignTransactionRequest{
+		Passphrase:            []byte(p),
+		SerializedTransaction: ctr.UnsignedTransaction,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	var signedTx wire.MsgTx
+	err = signedTx.Deserialize(bytes.NewReader(str.Transaction))
+	if err != nil {
+		return nil, 0, err
+	}
+	return &signedTx, fee, nil
+}
+
+func (w *grpcWallet) balance(account string, requiredConfs int32) (dcrutil.Amount, error) {
+	accountNumber, err := w.accountNumber(account)
+	if err != nil {
+		return 0, err
+	}
+	br, err := w.client.Balance(context.Background(), &walletrpc.BalanceRequest{
+		AccountNumber:         accountNumber,
+		RequiredConfirmations: requiredConfs,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return dcrutil.Amount(br.Spendable), nil
+}
+
+// fundsError makes a libs.FundsError of an insufficient balance error from
+// fundTransaction, with the spendable balance of the account as the
+// available amount
+func fundsError(wallet walletClient, required dcrutil.Amount, opts libs.WalletOptions, err error) error {
+	if !libs.IsInsufficientFunds(err) && !strings.Contains(err.Error(), "insufficient balance") &&
+		status.Code(err) != codes.ResourceExhausted {
+		return err
+	}
+	fundsErr := &libs.FundsError{Required: int64(required), Available: -1, Err: err}
+	available, err := wallet.balance(opts.Account, opts.RequiredConfs)
+	if err == nil {
+		fundsErr.Available = int64(available)
+	}
+	return fundsErr
+}
+
+func (w *grpcWallet) createSignature(tx *wire.MsgTx, idx int, pkScript []byte, addr dcrutil.Address, p string) (sig, pubkey []byte, err error) {
+	var buf bytes.Buffer
+	buf.Grow(tx.SerializeSize())
+	tx.Serialize(&buf)
+
+	ctx := context.Background()
+	csr, err := w.client.CreateSignature(ctx, &walletrpc.CreateSignatureRequest{
+		Passphrase:            []byte(p),
+		Address:               addr.EncodeAddress(),
+		SerializedTransaction: buf.Bytes(),
+		InputIndex:            uint32(idx),
+		HashType:              walletrpc.CreateSignatureRequest_SIGHASH_ALL,
+		PreviousPkScript:      pkScript,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return csr.Signature, csr.PublicKey, nil
+}
+
+func (w *grpcWallet) getTransaction(txid string) (*libs.GetTxResult, error) {
+	txHash, err := chainhash.NewHashFromStr(txid)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	gtr, err := w.client.GetTransaction(ctx, &walletrpc.GetTransactionRequest{
+		TransactionHash: txHash[:],
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, errTxNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &libs.GetTxResult{}
+	result.Confirmations = uint64(gtr.Confirmations)
+	result.Blockhash = hex.EncodeToString(byteRev(gtr.BlockHash))
+	result.Time = uint64(gtr.Transaction.Timestamp)
+	result.TimeReceived = uint64(gtr.Transaction.Timestamp)
+	result.Hex = hex.EncodeToString(gtr.Transaction.Transaction)
+	if len(gtr.BlockHash) == 0 {
+		// unmined
+		return result, nil
+	}
+
+	bir, err := w.client.BlockInfo(ctx, &walletrpc.BlockInfoRequest{
+		BlockHash: gtr.BlockHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Blocktime = uint64(bir.Timestamp)
+
+	// the position of the transaction in the block is only known to the node
+	if w.rpcinfo.NodeHostPort != "" {
+		nodeTx, err := getNodeTx(w.network, w.rpcinfo, txid)
+		if err != nil {
+			return nil, err
+		}
+		result.Blockindex = nodeTx.Blockindex
+	}
+
+	return result, nil
+}
+
+func (w *grpcWallet) publishTransaction(tx []byte) (string, error) {
+	ctx := context.Background()
+	response, err := w.client.PublishTransaction(ctx, &walletrpc.PublishTransactionRequest{
+		SignedTransaction: tx,
+	})
+	if err != nil {
+		return "", err
+	}
+	txHash, err := chainhash.NewHash(response.TransactionHash)
+	if err != nil {
+		return "", err
+	}
+	return txHash.String(), nil
+}
@@ -6,19 +6,28 @@ package dcr
 
 import (
 	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math"
 	"net"
 
 	"github.com/decred/dcrd/chaincfg"
 	"github.com/decred/dcrd/dcrutil"
+	"github.com/devwarrior777/atomicswap/libs"
 )
 
 // Get a normalized address from `addr' which can be of form Host[:Port]`
-func getNormalizedAddress(testnet bool, addr string) (hostport string, err error) {
+func getNormalizedAddress(network libs.Network, addr string) (hostport string, err error) {
+	return normalizeAddress(addr, getWalletPort(network))
+}
+
+// Get a normalized address from `addr' which can be of form Host[:Port]`
+// using the default port if none is specified
+func normalizeAddress(addr string, defaultPort string) (hostport string, err error) {
 	host, port, origErr := net.SplitHostPort(addr)
 	if origErr == nil {
 		return net.JoinHostPort(host, port), nil
 	}
-	defaultPort := getWalletPort(testnet)
 	addr = net.JoinHostPort(addr, defaultPort)
 	_, _, err = net.SplitHostPort(addr)
 	if err != nil {
@@ -27,18 +36,54 @@ func getNormalizedAddress(testnet bool, addr string) (hostport string, err error
 	return addr, nil
 }
 
+// networks are the networks the library supports. dcrwallet has no regtest
+var networks = []libs.Network{libs.Mainnet, libs.Testnet, libs.Simnet}
+
+// checkNetwork checks the network is one the library supports
+func checkNetwork(network libs.Network) error {
+	return libs.CheckNetwork(network, networks...)
+}
+
 // Get the default GPRC wallet port
-func getWalletPort(testnet bool) string {
-	if testnet {
+func getWalletPort(network libs.Network) string {
+	switch network {
+	case libs.Testnet:
 		return "19111"
+	case libs.Simnet:
+		return "19558"
 	}
 	return "9111"
 }
 
+// Get the default wallet JSON-RPC port
+func getJSONRPCPort(network libs.Network) string {
+	switch network {
+	case libs.Testnet:
+		return "19110"
+	case libs.Simnet:
+		return "19557"
+	}
+	return "9110"
+}
+
+// Get the default dcrd JSON-RPC port
+func getNodePort(network libs.Network) string {
+	switch network {
+	case libs.Testnet:
+		return "19109"
+	case libs.Simnet:
+		return "19556"
+	}
+	return "9109"
+}
+
 // Get all of the chain parameters for a network
-func getChainParams(testnet bool) *chaincfg.Params {
-	if testnet {
+func getChainParams(network libs.Network) *chaincfg.Params {
+	switch network {
+	case libs.Testnet:
 		return &chaincfg.TestNet3Params
+	case libs.Simnet:
+		return &chaincfg.SimNetParams
 	}
 	return &chaincfg.MainNetParams
 }
@@ -48,6 +93,25 @@ func sha256Hash(x []byte) []byte {
 	return h[:]
 }
 
+// checkWalletOptions checks the required confirmations and fee rate options
+func checkWalletOptions(opts libs.WalletOptions) error {
+	if opts.RequiredConfs < 0 {
+		return errors.New("required confirmations cannot be negative")
+	}
+	if opts.FeePerKb < 0 || opts.FeePerKb > math.MaxInt32 {
+		return fmt.Errorf("invalid fee rate %d atoms/kB", opts.FeePerKb)
+	}
+	return nil
+}
+
+// getFeePerKb gets the fee rate option or the default rate if it is not set
+func getFeePerKb(opts libs.WalletOptions) dcrutil.Amount {
+	if opts.FeePerKb == 0 {
+		return feePerKb
+	}
+	return dcrutil.Amount(opts.FeePerKb)
+}
+
 func calcFeePerKb(absoluteFee dcrutil.Amount, serializeSize int) float64 {
 	return float64(absoluteFee) / float64(serializeSize) / 1e5
 }
@@ -0,0 +1,182 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package dcr
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrutil"
+	"github.com/decred/dcrd/txscript"
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// watchScanBlocks is the most blocks searched for a spend in one poll
+const watchScanBlocks = 100
+
+// watchContract gets the state of a contract on the chain from the dcrd node.
+// The wallet only knows its own transactions so the spend is searched for in
+// the mempool and the blocks from the contract block, or ScanHeight, on
+func watchContract(network libs.Network, rpcinfo libs.RPCInfo, params libs.WatchParams) (*libs.WatchResult, error) {
+	contract, err := hex.DecodeString(params.Contract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract: %v", err)
+	}
+	pushes, err := txscript.ExtractAtomicSwapDataPushes(txscript.DefaultScriptVersion, contract)
+	if err != nil {
+		return nil, err
+	}
+	if pushes == nil {
+		return nil, errors.New("contract is not an atomic swap script recognized by this tool")
+	}
+	pkScript, err := txscript.NewScriptBuilder().AddOp(txscript.OP_HASH160).
+		AddData(dcrutil.Hash160(contract)).AddOp(txscript.OP_EQUAL).Script()
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := startNodeRPC(network, rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	defer node.stopRPC()
+
+	var best struct {
+		Hash   string `json:"hash"`
+		Height int64  `json:"height"`
+	}
+	err = node.call("getbestblock", nil, &best)
+	if err != nil {
+		return nil, err
+	}
+	var header struct {
+		Time int64 `json:"time"`
+	}
+	err = node.call("getblockheader", []interface{}{best.Hash, true}, &header)
+	if err != nil {
+		return nil, err
+	}
+	result := &libs.WatchResult{
+		Blocks:         best.Height,
+		BestBlockhash:  best.Hash,
+		MedianTime:     header.Time,
+		RefundLocktime: pushes.LockTime,
+	}
+
+	contractTx, err := node.getRawTransaction(params.ContractTxid)
+	if isRPCError(err, errRPCNoTxInfo) {
+		// not in the mempool or chain (yet)
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	vout := -1
+	for _, out := range contractTx.Vout {
+		if out.ScriptPubKey.Hex == hex.EncodeToString(pkScript) {
+			vout = int(out.N)
+			break
+		}
+	}
+	if vout == -1 {
+		return nil, errors.New("transaction does not contain the contract output")
+	}
+	result.Found = true
+	result.Confirmations = int64(contractTx.Confirmations)
+	result.Blockhash = contractTx.BlockHash
+
+	// gettxout is null for a spent output, the mempool included
+	var txOut *struct {
+		Confirmations int64 `json:"confirmations"`
+	}
+	err = node.call("gettxout", []interface{}{params.ContractTxid, vout, 0, true}, &txOut)
+	if err != nil {
+		return nil, err
+	}
+	if txOut != nil {
+		return result, nil
+	}
+	result.Spent = true
+
+	scanFrom := params.ScanHeight
+	if scanFrom == 0 {
+		scanFrom = contractTx.BlockHeight
+	}
+	if scanFrom == 0 {
+		scanFrom = best.Height
+	}
+	spendTx, scanned, err := node.findSpend(params.ContractTxid, uint32(vout), params.SpendTxid, scanFrom, best.Height)
+	if err != nil {
+		return nil, err
+	}
+	result.ScannedHeight = scanned
+	if spendTx == nil {
+		return result, nil
+	}
+	result.SpendTxid = spendTx.Txid
+	result.SpendTx = spendTx.Hex
+	result.SpendConfirmations = int64(spendTx.Confirmations)
+	secret, err := extractSecret(spendTx.Hex, hex.EncodeToString(pushes.SecretHash[:]))
+	if err == nil {
+		result.Redeemed = true
+		result.Secret = secret
+	}
+	return result, nil
+}
+
+// findSpend finds the transaction spending an output. The spend found by an
+// earlier poll is checked first, then blocks from scanFrom up to at most
+// watchScanBlocks blocks and the mempool. It returns the last block searched
+func (n *node) findSpend(txid string, vout uint32, spendTxid string, scanFrom, bestHeight int64) (*rawTransaction, int64, error) {
+	if spendTxid != "" {
+		tx, err := n.getRawTransaction(spendTxid)
+		if err == nil && tx.spends(txid, vout) {
+			return tx, 0, nil
+		}
+	}
+	scanned := scanFrom - 1
+	for height := scanFrom; height <= bestHeight && height < scanFrom+watchScanBlocks; height++ {
+		var hash string
+		err := n.call("getblockhash", []interface{}{height}, &hash)
+		if err != nil {
+			return nil, 0, err
+		}
+		var block struct {
+			RawTx  []*rawTransaction `json:"rawtx"`
+			RawSTx []*rawTransaction `json:"rawstx"`
+		}
+		err = n.call("getblock", []interface{}{hash, true, true}, &block)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, tx := range append(block.RawTx, block.RawSTx...) {
+			if tx.spends(txid, vout) {
+				tx.Confirmations = uint64(bestHeight - height + 1)
+				return tx, height, nil
+			}
+		}
+		scanned = height
+	}
+	var mempool []string
+	err := n.call("getrawmempool", nil, &mempool)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, memTxid := range mempool {
+		tx, err := n.getRawTransaction(memTxid)
+		if isRPCError(err, errRPCNoTxInfo) {
+			// mined or dropped since getrawmempool
+			continue
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		if tx.spends(txid, vout) {
+			return tx, scanned, nil
+		}
+	}
+	return nil, scanned, nil
+}
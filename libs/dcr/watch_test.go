@@ -0,0 +1,145 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package dcr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/decred/dcrd/wire"
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// testWatchTx is the verbose getrawtransaction JSON of a fixture transaction
+func testWatchTx(t *testing.T, txHex string, confirmations, height int64) (txid string, raw map[string]interface{}) {
+	b, err := hex.DecodeString(txHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tx wire.MsgTx
+	err = tx.Deserialize(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vin, vout []interface{}
+	for _, in := range tx.TxIn {
+		vin = append(vin, map[string]interface{}{
+			"txid": in.PreviousOutPoint.Hash.String(),
+			"vout": in.PreviousOutPoint.Index,
+		})
+	}
+	for i, out := range tx.TxOut {
+		vout = append(vout, map[string]interface{}{
+			"n":            i,
+			"scriptPubKey": map[string]string{"hex": hex.EncodeToString(out.PkScript)},
+		})
+	}
+	txid = tx.TxHash().String()
+	return txid, map[string]interface{}{
+		"hex":           txHex,
+		"txid":          txid,
+		"blockhash":     "blockhash" + txid[:8],
+		"blockheight":   height,
+		"confirmations": confirmations,
+		"vin":           vin,
+		"vout":          vout,
+	}
+}
+
+func testResponse(t *testing.T, result interface{}) string {
+	b, err := json.Marshal(map[string]interface{}{"result": result, "error": nil, "id": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestWatchContract(t *testing.T) {
+	contractTxid, contractTx := testWatchTx(t, testContractTx, 3, 100)
+	redeemTxid, redeemTx := testWatchTx(t, testRedeemTx, 1, 102)
+
+	var spent, mempool bool
+	rpcinfo := startTestNode(t, func(method string, params []interface{}) string {
+		switch method {
+		case "getbestblock":
+			return testResponse(t, map[string]interface{}{"hash": "best", "height": 102})
+		case "getblockheader":
+			return testResponse(t, map[string]interface{}{"time": 1561000100})
+		case "getrawtransaction":
+			switch params[0] {
+			case contractTxid:
+				return testResponse(t, contractTx)
+			case redeemTxid:
+				return testResponse(t, redeemTx)
+			}
+			return `{"result":null,"error":{"code":-5,"message":"No information available about transaction"},"id":1}`
+		case "gettxout":
+			if spent {
+				return testResponse(t, nil)
+			}
+			return testResponse(t, map[string]interface{}{"confirmations": 3})
+		case "getblockhash":
+			return testResponse(t, "hash")
+		case "getblock":
+			block := map[string]interface{}{"rawtx": []interface{}{contractTx}}
+			if !mempool {
+				block["rawtx"] = []interface{}{contractTx, redeemTx}
+			}
+			return testResponse(t, block)
+		case "getrawmempool":
+			if mempool {
+				return testResponse(t, []string{redeemTxid})
+			}
+			return testResponse(t, []string{})
+		}
+		t.Errorf("unexpected request %s %v", method, params)
+		return ""
+	})
+
+	params := libs.WatchParams{Contract: testContract, ContractTxid: contractTxid}
+	result, err := WatchContract(libs.Testnet, rpcinfo, params)
+	if err != nil {
+		t.Fatalf("WatchContract: %v", err)
+	}
+	if !result.Found || result.Confirmations != 3 || result.Spent || result.Blocks != 102 ||
+		result.RefundLocktime != testLocktime || result.MedianTime != 1561000100 {
+		t.Fatalf("unspent contract %+v", result)
+	}
+
+	spent, mempool = true, true
+	result, err = WatchContract(libs.Testnet, rpcinfo, params)
+	if err != nil {
+		t.Fatalf("WatchContract: %v", err)
+	}
+	if !result.Spent || result.SpendTxid != redeemTxid || !result.Redeemed || result.Secret != testSecret {
+		t.Fatalf("redeem in mempool %+v", result)
+	}
+
+	mempool = false
+	result, err = WatchContract(libs.Testnet, rpcinfo, params)
+	if err != nil {
+		t.Fatalf("WatchContract: %v", err)
+	}
+	if result.SpendTxid != redeemTxid || result.SpendConfirmations != 3 || result.ScannedHeight != 100 {
+		t.Fatalf("redeem in block %+v", result)
+	}
+
+	params.ContractTxid = redeemTxid
+	_, err = WatchContract(libs.Testnet, rpcinfo, params)
+	if err == nil {
+		t.Fatal("contract found in the redeem tx")
+	}
+
+	params.ContractTxid = testNodeTxid
+	result, err = WatchContract(libs.Testnet, rpcinfo, params)
+	if err != nil {
+		t.Fatalf("WatchContract: %v", err)
+	}
+	if result.Found {
+		t.Fatalf("unknown contract tx found %+v", result)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package doge
+
+import (
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// newaddress gets a new wallet address from the controlled wallet
+func newaddress(network libs.Network, rpcinfo libs.RPCInfo) (string, error) {
+	rpcclient, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		rpcclient.Shutdown()
+		rpcclient.WaitForShutdown()
+	}()
+	end := rpcclient.session.Begin()
+	defer end()
+
+	addr, err := getNewAddress(network, rpcclient)
+	if err != nil {
+		return "", err
+	}
+
+	return addr.String(), nil
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2013, 2014 The btcsuite developers
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package doge
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// KoinuPerDogecoin is the number of koinu in one dogecoin (1 DOGE).
+	KoinuPerDogecoin = 1e8
+
+	// MaxKoinu is the maximum transaction amount allowed in koinu. Dogecoin
+	// has no supply cap, this is the Dogecoin Core MAX_MONEY sanity limit of
+	// 10 billion DOGE.
+	MaxKoinu = 10e9 * KoinuPerDogecoin
+)
+
+// AmountUnit describes a method of converting an Amount to something
+// other than the base unit of a dogecoin.  The value of the AmountUnit
+// is the exponent component of the decadic multiple to convert from
+// an amount in dogecoin to an amount counted in units.
+type AmountUnit int
+
+// These constants define various units used when describing a dogecoin
+// monetary amount.
+const (
+	AmountMegaDOGE  AmountUnit = 6
+	AmountKiloDOGE  AmountUnit = 3
+	AmountDOGE      AmountUnit = 0
+	AmountMilliDOGE AmountUnit = -3
+	AmountMicroDOGE AmountUnit = -6
+	AmountKoinu     AmountUnit = -8
+)
+
+// String returns the unit as a string.  For recognized units, the SI
+// prefix is used, or "Koinu" for the base unit.  For all unrecognized
+// units, "1eN DOGE" is returned, where N is the AmountUnit.
+func (u AmountUnit) String() string {
+	switch u {
+	case AmountMegaDOGE:
+		return "MDOGE"
+	case AmountKiloDOGE:
+		return "kDOGE"
+	case AmountDOGE:
+		return "DOGE"
+	case AmountMilliDOGE:
+		return "mDOGE"
+	case AmountMicroDOGE:
+		return "μDOGE"
+	case AmountKoinu:
+		return "Koinu"
+	default:
+		return "1e" + strconv.FormatInt(int64(u), 10) + " DOGE"
+	}
+}
+
+// Amount represents the base dogecoin monetary unit (colloquially referred
+// to as a `Koinu').  A single Amount is equal to 1e-8 of a dogecoin.
+type Amount int64
+
+// round converts a floating point number, which may or may not be representable
+// as an integer, to the Amount integer type by rounding to the nearest integer.
+// This is performed by adding or subtracting 0.5 depending on the sign, and
+// relying on integer truncation to round the value to the nearest Amount.
+func round(f float64) Amount {
+	if f < 0 {
+		return Amount(f - 0.5)
+	}
+	return Amount(f + 0.5)
+}
+
+// NewAmount creates an Amount from a floating point value representing
+// some value in dogecoin.  NewAmount errors if f is NaN or +-Infinity, or
+// if the amount is out of the MaxKoinu range.
+//
+// Dogecoin amounts are large enough that a float64 cannot hold every koinu
+// value exactly above about 90 million DOGE.  Use ParseAmount to convert a
+// decimal string without loss.
+func NewAmount(f float64) (Amount, error) {
+	// The amount is only considered invalid if it cannot be represented
+	// as an integer type.  This may happen if f is NaN or +-Infinity.
+	switch {
+	case math.IsNaN(f):
+		fallthrough
+	case math.IsInf(f, 1):
+		fallthrough
+	case math.IsInf(f, -1):
+		return 0, errors.New("invalid dogecoin amount")
+	}
+	if math.Abs(f) > MaxKoinu/KoinuPerDogecoin {
+		return 0, errors.New("dogecoin amount out of range")
+	}
+
+	return round(f * KoinuPerDogecoin), nil
+}
+
+// ParseAmount creates an Amount from a decimal string value in dogecoin such
+// as "1234567890.12345678".  The conversion is exact, there may be at most 8
+// decimal places.
+func ParseAmount(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+	whole, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+	}
+	if whole == "" && frac == "" {
+		return 0, errors.New("invalid dogecoin amount")
+	}
+	if len(frac) > 8 {
+		return 0, errors.New("dogecoin amount has more than 8 decimal places")
+	}
+	frac += strings.Repeat("0", 8-len(frac))
+	if whole == "" {
+		whole = "0"
+	}
+	w, err := strconv.ParseUint(whole, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid dogecoin amount")
+	}
+	f, err := strconv.ParseUint(frac, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid dogecoin amount")
+	}
+	if w > MaxKoinu/KoinuPerDogecoin {
+		return 0, errors.New("dogecoin amount out of range")
+	}
+	a := Amount(w*KoinuPerDogecoin + f)
+	if a > MaxKoinu {
+		return 0, errors.New("dogecoin amount out of range")
+	}
+	if neg {
+		a = -a
+	}
+	return a, nil
+}
+
+// ToUnit converts a monetary amount counted in dogecoin base units to a
+// floating point value representing an amount of dogecoin.
+func (a Amount) ToUnit(u AmountUnit) float64 {
+	return float64(a) / math.Pow10(int(u+8))
+}
+
+// ToDOGE is the equivalent of calling ToUnit with AmountDOGE.
+func (a Amount) ToDOGE() float64 {
+	return a.ToUnit(AmountDOGE)
+}
+
+// Format formats a monetary amount counted in dogecoin base units as a
+// string for a given unit.  The conversion will succeed for any unit,
+// however, known units will be formated with an appended label describing
+// the units with SI notation, or "Koinu" for the base unit.
+func (a Amount) Format(u AmountUnit) string {
+	units := " " + u.String()
+	return strconv.FormatFloat(a.ToUnit(u), 'f', -int(u+8), 64) + units
+}
+
+// String is the equivalent of calling Format with AmountDOGE.  It formats
+// from the integer value so that large amounts are not rounded.
+func (a Amount) String() string {
+	sign := ""
+	v := int64(a)
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	frac := strconv.FormatInt(v%KoinuPerDogecoin, 10)
+	frac = strings.Repeat("0", 8-len(frac)) + frac
+	frac = strings.TrimRight(frac, "0")
+	s := sign + strconv.FormatInt(v/KoinuPerDogecoin, 10)
+	if frac != "" {
+		s += "." + frac
+	}
+	return s + " " + AmountDOGE.String()
+}
+
+// MulF64 multiplies an Amount by a floating point value.  While this is not
+// an operation that must typically be done by a full node or wallet, it is
+// useful for services that build on top of dogecoin (for example, calculating
+// a fee by multiplying by a percentage).
+func (a Amount) MulF64(f float64) Amount {
+	return round(float64(a) * f)
+}
@@ -0,0 +1,60 @@
+package doge
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		s      string
+		amount Amount
+		str    string
+	}{
+		{"0", 0, "0 DOGE"},
+		{"0.00100000", KoinuPerDogecoin / 1000, "0.001 DOGE"},
+		{"-1", -KoinuPerDogecoin, "-1 DOGE"},
+		// above the float64 precision of a koinu
+		{"1234567890.12345678", 123456789012345678, "1234567890.12345678 DOGE"},
+		{"10000000000", MaxKoinu, "10000000000 DOGE"},
+	}
+	for _, test := range tests {
+		a, err := ParseAmount(test.s)
+		if err != nil {
+			t.Errorf("%s: %v", test.s, err)
+			continue
+		}
+		if a != test.amount || a.String() != test.str {
+			t.Errorf("%s: amount %d %s, want %d %s", test.s, a, a, test.amount, test.str)
+		}
+	}
+	for _, s := range []string{"", ".", "1.123456789", "10000000000.00000001", "1e8", "DOGE"} {
+		_, err := ParseAmount(s)
+		if err == nil {
+			t.Errorf("%q parsed", s)
+		}
+	}
+}
+
+// TestParseRPCAmount checks a JSON-RPC balance is decoded without loss
+func TestParseRPCAmount(t *testing.T) {
+	var resp struct {
+		Balance json.Number `json:"balance"`
+		Fee     json.Number `json:"fee"`
+	}
+	err := json.Unmarshal([]byte(`{"balance": 987654321.87654321}`), &resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	balance, err := parseRPCAmount(resp.Balance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance != 98765432187654321 {
+		t.Errorf("balance %d", balance)
+	}
+	fee, err := parseRPCAmount(resp.Fee)
+	if err != nil || fee != 0 {
+		t.Errorf("missing fee %d %v", fee, err)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2017/2019 The Decred developers
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package doge
+
+////////////////////////////////////////////////////////////////////////
+// Public command interface for the Dogecoin atomic swap code library //
+////////////////////////////////////////////////////////////////////////
+
+import (
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+const verify = true
+
+const secretSize = 32
+
+// Dogecoin Core builds version 1 transactions
+const txVersion = 1
+
+// PingRPC tests if wallet node RPC is available and reports the chain, version
+// and capabilities of the node
+func PingRPC(network libs.Network, rpcinfo libs.RPCInfo) (*libs.PingResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return pingrpc(network, rpcinfo)
+}
+
+// GetNewAddress gets a new address from the controlled wallet
+func GetNewAddress(network libs.Network, rpcinfo libs.RPCInfo) (string, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return "", err
+	}
+	return newaddress(network, rpcinfo)
+}
+
+// Initiate command builds a P2SH contract and a transaction to fund it
+func Initiate(network libs.Network, rpcinfo libs.RPCInfo, params libs.InitiateParams) (*libs.InitiateResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return initiate(network, rpcinfo, params)
+}
+
+// Participate command builds a P2SH contract and a transaction to fund it
+func Participate(network libs.Network, rpcinfo libs.RPCInfo, params libs.ParticipateParams) (*libs.ParticipateResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return participate(network, rpcinfo, params)
+}
+
+// Redeem command builds a transaction to redeem a contract
+func Redeem(network libs.Network, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs.RedeemResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return redeem(network, rpcinfo, params)
+}
+
+// Refund command builds a refund transaction for an unredeemed contract
+func Refund(network libs.Network, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs.RefundResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return refund(network, rpcinfo, params)
+}
+
+// AuditContract command
+func AuditContract(network libs.Network, params libs.AuditParams) (*libs.AuditResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return auditContract(network, params)
+}
+
+// Publish command broadcasts a raw hex transaction
+func Publish(network libs.Network, rpcinfo libs.RPCInfo, tx string) (string, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return "", err
+	}
+	txhash, err := publish(network, rpcinfo, tx)
+	if err != nil {
+		return "", err
+	}
+	return txhash, nil
+}
+
+// ExtractSecret returns a secret from the scriptSig of a transaction redeeming a contract
+func ExtractSecret(redemptionTx string, secretHash string) (string, error) {
+	return extractSecret(redemptionTx, secretHash)
+}
+
+// GetTx gets info on a broadcasted transaction
+func GetTx(network libs.Network, rpcinfo libs.RPCInfo, txid string) (*libs.GetTxResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return getTx(network, rpcinfo, txid)
+}
+
+// WatchContract gets the state of a contract on the chain: the contract tx
+// confirmations and the spend, if any, with the secret of a redeem
+func WatchContract(network libs.Network, rpcinfo libs.RPCInfo, params libs.WatchParams) (*libs.WatchResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return watchContract(network, rpcinfo, params)
+}
+
+//...
@@ -0,0 +1,30 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package doge
+
+import (
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// pingrpc tests if wallet node RPC is available and gets the chain, version
+// and capabilities of the node
+func pingrpc(network libs.Network, rpcinfo libs.RPCInfo) (*libs.PingResult, error) {
+	rpcclient, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		rpcclient.Shutdown()
+		rpcclient.WaitForShutdown()
+	}()
+
+	release, err := unlockWallet(rpcclient, rpcinfo.WalletPass)
+	if err != nil {
+		return nil, err
+	}
+	release()
+
+	return getNodeInfo(rpcclient)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package doge
+
+import (
+	"github.com/btcsuite/btcd/wire"
+)
+
+// Dogecoin Core 1.14 relay policy. Dogecoin does not use the bitcoin
+// relay fee based dust rule or per byte fees
+const (
+	// minRelayFeePerKb is the default minimum relay fee of 0.001 DOGE/kB
+	minRelayFeePerKb = Amount(KoinuPerDogecoin / 1000)
+
+	// recommendedFeePerKb is the default wallet fee of 0.01 DOGE/kB. It is
+	// used when neither the wallet nor the node can supply a fee
+	recommendedFeePerKb = Amount(KoinuPerDogecoin / 100)
+
+	// softDustLimit is the default dust limit of 0.01 DOGE. Each output below
+	// it costs an extra relay fee and outputs below 0.001 DOGE are not relayed
+	// at all.  Redeem and refund transactions have a single output so any
+	// output below the soft limit is treated as dust
+	softDustLimit = Amount(KoinuPerDogecoin / 100)
+)
+
+// feeForSerializeSize calculates the fee for a transaction of a given
+// serialized size.  Dogecoin charges the fee rate for each started kB rather
+// than pro rata, so a 1001 byte transaction pays for 2 kB.
+func feeForSerializeSize(feePerKb Amount, txSerializeSize int) Amount {
+	kb := int64(txSerializeSize+999) / 1000
+	if kb == 0 {
+		kb = 1
+	}
+	return feePerKb * Amount(kb)
+}
+
+// isDustOutput determines whether a transaction output is considered dust by
+// Dogecoin relay policy
+func isDustOutput(output *wire.TxOut) bool {
+	return Amount(output.Value) < softDustLimit
+}
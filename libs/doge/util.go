@@ -0,0 +1,101 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package doge
+
+import (
+	"crypto/sha256"
+	"net"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// Get a normalized address from `addr' which can be of form Host[:Port]`
+func getNormalizedAddress(network libs.Network, addr string) (hostport string, err error) {
+	host, port, origErr := net.SplitHostPort(addr)
+	if origErr == nil {
+		return net.JoinHostPort(host, port), nil
+	}
+	defaultPort := getWalletPort(network)
+	addr = net.JoinHostPort(addr, defaultPort)
+	_, _, err = net.SplitHostPort(addr)
+	if err != nil {
+		return "", origErr
+	}
+	return addr, nil
+}
+
+// networks are the networks the library supports
+var networks = []libs.Network{libs.Mainnet, libs.Testnet, libs.Regtest}
+
+// checkNetwork checks the network is one the library supports
+func checkNetwork(network libs.Network) error {
+	return libs.CheckNetwork(network, networks...)
+}
+
+// Get the default wallet port
+func getWalletPort(network libs.Network) string {
+	switch network {
+	case libs.Testnet:
+		return "44555"
+	case libs.Regtest:
+		return "18332"
+	}
+	return "22555"
+}
+
+// Dogecoin chain parameters. Only the fields used to encode and decode
+// addresses and keys differ from bitcoin
+var (
+	mainNetParams = newChainParams(&chaincfg.MainNetParams, "doge-mainnet", 0xc0c0c0c0, 0x1e, 0x16, 0x9e)
+	testNetParams = newChainParams(&chaincfg.TestNet3Params, "doge-testnet", 0xdcb7c1fc, 0x71, 0xc4, 0xf1)
+	regTestParams = newChainParams(&chaincfg.RegressionNetParams, "doge-regtest", 0xdab5bffa, 0x6f, 0xc4, 0xef)
+)
+
+func newChainParams(base *chaincfg.Params, name string, net uint32, pubKeyHashAddrID, scriptHashAddrID, privateKeyID byte) *chaincfg.Params {
+	params := *base
+	params.Name = name
+	params.Net = wire.BitcoinNet(net)
+	params.DefaultPort = ""
+	params.DNSSeeds = nil
+	params.Checkpoints = nil
+	params.PubKeyHashAddrID = pubKeyHashAddrID
+	params.ScriptHashAddrID = scriptHashAddrID
+	params.PrivateKeyID = privateKeyID
+	params.Bech32HRPSegwit = "" // no segwit
+	return &params
+}
+
+// Regtest shares the network magic of bitcoin regtest so is not registered.
+// Addresses are decoded against the params passed and need no registration
+func init() {
+	for _, params := range []*chaincfg.Params{mainNetParams, testNetParams} {
+		err := chaincfg.Register(params)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Get all of the chain parameters for a network
+func getChainParams(network libs.Network) *chaincfg.Params {
+	switch network {
+	case libs.Testnet:
+		return testNetParams
+	case libs.Regtest:
+		return regTestParams
+	}
+	return mainNetParams
+}
+
+func sha256Hash(x []byte) []byte {
+	h := sha256.Sum256(x)
+	return h[:]
+}
+
+func calcFeePerKb(absoluteFee Amount, serializeSize int) float64 {
+	return float64(absoluteFee) / float64(serializeSize) / 1e5
+}
@@ -0,0 +1,36 @@
+package doge
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// TestMainnetAddressPrefix checks mainnet addresses are encoded with the Dogecoin
+// address ids rather than bitcoin's
+func TestMainnetAddressPrefix(t *testing.T) {
+	params := getChainParams(libs.Mainnet)
+	hash := make([]byte, 20)
+	p2pkh, err := btcutil.NewAddressPubKeyHash(hash, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr := p2pkh.EncodeAddress(); addr[0] != 'D' {
+		t.Errorf("P2PKH address %s does not start with D", addr)
+	}
+	p2sh, err := btcutil.NewAddressScriptHashFromHash(hash, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr := p2sh.EncodeAddress(); addr[0] != '9' {
+		t.Errorf("P2SH address %s does not start with 9", addr)
+	}
+	decoded, err := btcutil.DecodeAddress(p2pkh.EncodeAddress(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.IsForNet(params) {
+		t.Errorf("address %s is not for %s", decoded, params.Name)
+	}
+}
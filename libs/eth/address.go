@@ -0,0 +1,20 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package eth
+
+import (
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// newaddress makes a new account key in the keystore directory. Ethereum
+// addresses are the same on every network so the node is not needed
+func newaddress(network libs.Network, rpcinfo libs.RPCInfo) (string, error) {
+	addr, err := newKey(rpcinfo)
+	if err != nil {
+		return "", err
+	}
+
+	return addr.Hex(), nil
+}
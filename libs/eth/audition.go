@@ -0,0 +1,77 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package eth
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// auditContract pulls out information from the counterparty's contract
+// transaction, the swap contract initiate call paying into the contract
+func auditContract(network libs.Network, params libs.AuditParams) (*libs.AuditResult, error) {
+	c, err := decodeContract(params.Contract)
+	if err != nil {
+		return nil, err
+	}
+
+	contractTxBytes, err := hex.DecodeString(params.ContractTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract transaction: %v", err)
+	}
+	contractTx, err := decodeTx(contractTxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract transaction: %v", err)
+	}
+
+	if contractTx.ChainId().Cmp(getChainID(network)) != 0 {
+		return nil, fmt.Errorf("contract transaction is for chain %v not %v", contractTx.ChainId(), getChainID(network))
+	}
+	if contractTx.To() == nil || *contractTx.To() != c.address {
+		return nil, errors.New("contract transaction does not call the swap contract")
+	}
+
+	inits, err := decodeInitiate(contractTx.Data())
+	if err != nil {
+		return nil, err
+	}
+	var init *initiation
+	for i := range inits {
+		if inits[i].SecretHash == c.secretHash {
+			init = &inits[i]
+			break
+		}
+	}
+	if init == nil {
+		return nil, errors.New("contract transaction does not initiate a swap with the secret hash")
+	}
+	if !init.RefundTimestamp.IsInt64() {
+		return nil, errors.New("contract specifies strange locktime")
+	}
+
+	amount, err := weiToGwei(init.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	refundAddr, err := txSender(contractTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract transaction sender: %v", err)
+	}
+
+	result := &libs.AuditResult{}
+
+	result.ContractAddress = c.address.Hex()
+	result.ContractAmount = amount
+	result.ContractRecipientAddress = init.Participant.Hex()
+	result.ContractRefundAddress = refundAddr.Hex()
+	result.ContractRefundLocktime = init.RefundTimestamp.Int64()
+	result.ContractSecretHash = hex.EncodeToString(c.secretHash[:])
+
+	return result, nil
+}
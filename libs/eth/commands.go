@@ -0,0 +1,169 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package eth
+
+////////////////////////////////////////////////////////////////////////
+// Public command interface for the Ethereum atomic swap code library //
+////////////////////////////////////////////////////////////////////////
+
+// Ethereum swaps are held by a swap contract (ethswap.sol) rather than by a
+// P2SH script, so some of the common swap structure fields differ:
+//  - Contract is the swap contract address and the secret hash in hex
+//  - ContractP2SH is the swap contract address
+//  - amounts and fees are in gwei, fee rates are the max fee per gas in gwei
+//  - RPCInfo HostPort is the node RPC url or host[:port], User & Pass are
+//    optional http basic auth, Certs is the keystore file or directory of the
+//    swap account and WalletPass is the keystore passphrase
+// The swap contract address must be set with SetSwapContract before any new
+// swaps are made
+
+import (
+	"time"
+
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+const secretSize = 32
+
+// Refund locktimes of the initiator's and participant's contracts. These are
+// variables so the tests need not move the simulated chain clock a whole day
+var (
+	initiateLocktime    = 48 * time.Hour
+	participateLocktime = 24 * time.Hour
+)
+
+// SetSwapContract sets the address of the deployed swap contract that new
+// swaps on the network are made with
+func SetSwapContract(network libs.Network, addr string) error {
+	err := checkNetwork(network)
+	if err != nil {
+		return err
+	}
+	return setSwapContract(network, addr)
+}
+
+// CheckSwapContract checks a swap contract address for the network without
+// setting it
+func CheckSwapContract(network libs.Network, addr string) error {
+	err := checkNetwork(network)
+	if err != nil {
+		return err
+	}
+	return checkSwapContract(addr)
+}
+
+// DeploySwapContract deploys a new swap contract from the controlled account
+// and returns its address. The deployment is broadcast immediately
+func DeploySwapContract(network libs.Network, rpcinfo libs.RPCInfo) (string, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return "", err
+	}
+	return deploySwapContract(network, rpcinfo)
+}
+
+// PingRPC tests if node RPC is available and the account can be unlocked. It
+// reports the chain id, client version and fee capabilities of the node
+func PingRPC(network libs.Network, rpcinfo libs.RPCInfo) (*libs.PingResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return pingrpc(network, rpcinfo)
+}
+
+// GetNewAddress makes a new account in the keystore directory
+func GetNewAddress(network libs.Network, rpcinfo libs.RPCInfo) (string, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return "", err
+	}
+	return newaddress(network, rpcinfo)
+}
+
+// Initiate command builds a swap contract initiate transaction
+func Initiate(network libs.Network, rpcinfo libs.RPCInfo, params libs.InitiateParams) (*libs.InitiateResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return initiate(network, rpcinfo, params)
+}
+
+// Participate command builds a swap contract initiate transaction
+func Participate(network libs.Network, rpcinfo libs.RPCInfo, params libs.ParticipateParams) (*libs.ParticipateResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return participate(network, rpcinfo, params)
+}
+
+// Redeem command builds a transaction to redeem a contract
+func Redeem(network libs.Network, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs.RedeemResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return redeem(network, rpcinfo, params)
+}
+
+// Refund command builds a refund transaction for an unredeemed contract
+func Refund(network libs.Network, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs.RefundResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return refund(network, rpcinfo, params)
+}
+
+// AuditContract command
+func AuditContract(network libs.Network, params libs.AuditParams) (*libs.AuditResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return auditContract(network, params)
+}
+
+// Publish command broadcasts a raw hex transaction
+func Publish(network libs.Network, rpcinfo libs.RPCInfo, tx string) (string, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return "", err
+	}
+	txhash, err := publish(network, rpcinfo, tx)
+	if err != nil {
+		return "", err
+	}
+	return txhash, nil
+}
+
+// ExtractSecret returns a secret from the input of a transaction redeeming a contract
+func ExtractSecret(redemptionTx string, secretHash string) (string, error) {
+	return extractSecret(redemptionTx, secretHash)
+}
+
+// GetTx gets info on a broadcasted transaction
+func GetTx(network libs.Network, rpcinfo libs.RPCInfo, txid string) (*libs.GetTxResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return getTx(network, rpcinfo, txid)
+}
+
+// WatchContract gets the state of a contract on the chain: the contract tx
+// confirmations and the spend, if any, with the secret of a redeem. The
+// Contract is the swap contract id
+func WatchContract(network libs.Network, rpcinfo libs.RPCInfo, params libs.WatchParams) (*libs.WatchResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return watchContract(network, rpcinfo, params)
+}
+
+//...
@@ -0,0 +1,116 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// contractArgs specifies the common parameters used to create the initiator's
+// and participant's contract.
+type contractArgs struct {
+	them       common.Address
+	amount     int64
+	locktime   int64
+	secretHash [32]byte
+}
+
+// builtContract houses the details regarding a contract and the contract
+// payment transaction
+type builtContract struct {
+	contract    *contract
+	contractTx  *builtTx
+	contractFee int64
+}
+
+// buildContract builds and signs a swap contract initiate call paying the
+// amount into a swap redeemable by them with the secret or refundable by us
+// after the locktime
+func buildContract(network libs.Network, rpcinfo libs.RPCInfo, args *contractArgs) (*builtContract, error) {
+	if args.amount <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+	swapAddr, err := getSwapContract(network)
+	if err != nil {
+		return nil, err
+	}
+
+	key, _, err := loadKey(rpcinfo, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	c := &contract{address: swapAddr, secretHash: args.secretHash}
+	swap, err := getSwap(client, c)
+	if err != nil {
+		return nil, err
+	}
+	if swap.State != stateEmpty {
+		return nil, errors.New("a swap with the secret hash already exists")
+	}
+
+	value := gweiToWei(args.amount)
+	data, err := parsedABI.Pack("initiate", []initiation{{
+		RefundTimestamp: big.NewInt(args.locktime),
+		SecretHash:      args.secretHash,
+		Participant:     args.them,
+		Value:           value,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	contractTx, err := buildTx(client, network, key, &swapAddr, value, data)
+	if err != nil {
+		return nil, err
+	}
+	contractFee, err := weiToGwei(contractTx.fee)
+	if err != nil {
+		return nil, err
+	}
+
+	return &builtContract{
+		contract:    c,
+		contractTx:  contractTx,
+		contractFee: contractFee,
+	}, nil
+}
+
+// deploySwapContract deploys a new swap contract and returns its address
+func deploySwapContract(network libs.Network, rpcinfo libs.RPCInfo) (string, error) {
+	key, from, err := loadKey(rpcinfo, nil)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	deployTx, err := buildTx(client, network, key, nil, new(big.Int), common.FromHex(swapBin))
+	if err != nil {
+		return "", err
+	}
+	_, err = sendRawTransaction(client, deployTx.tx)
+	if err != nil {
+		return "", fmt.Errorf("deploy swap contract: %v", err)
+	}
+
+	return crypto.CreateAddress(from, deployTx.tx.Nonce()).Hex(), nil
+}
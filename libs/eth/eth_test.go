@@ -0,0 +1,365 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const testPass = "123"
+
+// simBackend is the go-ethereum in-process simulated backend as a backend
+type simBackend struct {
+	*backends.SimulatedBackend
+}
+
+func (b simBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	return b.Blockchain().Config().ChainID, nil
+}
+
+// Close leaves the simulated chain running for the next command
+func (b simBackend) Close() {}
+
+// testAccount makes a funded key in a new keystore directory
+func testAccount(t *testing.T, alloc core.GenesisAlloc) (libs.RPCInfo, common.Address) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(key, testPass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alloc[account.Address] = core.GenesisAccount{Balance: gweiToWei(100e9)}
+	return libs.RPCInfo{Certs: dir, WalletPass: testPass}, account.Address
+}
+
+// newTestChain starts a simulated chain with a deployed swap contract, an
+// initiator and a participant account
+func newTestChain(t *testing.T) (sim *backends.SimulatedBackend, initiator, participant libs.RPCInfo, initiatorAddr, participantAddr common.Address) {
+	alloc := core.GenesisAlloc{}
+	initiator, initiatorAddr = testAccount(t, alloc)
+	participant, participantAddr = testAccount(t, alloc)
+
+	sim = backends.NewSimulatedBackend(alloc, 30000000)
+	t.Cleanup(func() { sim.Close() })
+
+	savedDial, savedChainID := dial, chainIDs[libs.Regtest]
+	dial = func(libs.RPCInfo) (backend, error) { return simBackend{sim}, nil }
+	chainIDs[libs.Regtest] = sim.Blockchain().Config().ChainID
+	t.Cleanup(func() {
+		dial, chainIDs[libs.Regtest] = savedDial, savedChainID
+	})
+
+	swapAddr, err := DeploySwapContract(libs.Regtest, initiator)
+	if err != nil {
+		t.Fatalf("DeploySwapContract: %v", err)
+	}
+	sim.Commit()
+	err = SetSwapContract(libs.Regtest, swapAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func publishAndMine(t *testing.T, sim *backends.SimulatedBackend, rpcinfo libs.RPCInfo, tx string) string {
+	txHash, err := Publish(libs.Regtest, rpcinfo, tx)
+	if err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	sim.Commit()
+	receipt, err := sim.TransactionReceipt(context.Background(), common.HexToHash(txHash))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != 1 {
+		t.Fatalf("transaction %s failed", txHash)
+	}
+	return txHash
+}
+
+func TestInitiateRedeem(t *testing.T) {
+	sim, initiator, participant, initiatorAddr, participantAddr := newTestChain(t)
+
+	secret := libs.GetRand32()
+	secretHash, err := libs.Hash256(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initResult, err := Initiate(libs.Regtest, initiator, libs.InitiateParams{
+		SecretHash: secretHash,
+		CP2Addr:    participantAddr.Hex(),
+		CP2Amount:  1e9, // 1 ETH
+	})
+	if err != nil {
+		t.Fatalf("Initiate: %v", err)
+	}
+
+	audit, err := AuditContract(libs.Regtest, libs.AuditParams{
+		Contract:   initResult.Contract,
+		ContractTx: initResult.ContractTx,
+	})
+	if err != nil {
+		t.Fatalf("AuditContract: %v", err)
+	}
+	if audit.ContractAmount != 1e9 {
+		t.Errorf("audit amount %d", audit.ContractAmount)
+	}
+	if audit.ContractSecretHash != secretHash {
+		t.Errorf("audit secret hash %s", audit.ContractSecretHash)
+	}
+	if audit.ContractRecipientAddress != participantAddr.Hex() {
+		t.Errorf("audit recipient %s", audit.ContractRecipientAddress)
+	}
+	if audit.ContractRefundAddress != initiatorAddr.Hex() {
+		t.Errorf("audit refund address %s", audit.ContractRefundAddress)
+	}
+	if audit.ContractRefundLocktime != initResult.ContractRefundLocktime {
+		t.Errorf("audit locktime %d", audit.ContractRefundLocktime)
+	}
+
+	watchParams := libs.WatchParams{
+		Contract:     initResult.Contract,
+		ContractTxid: initResult.ContractTxHash,
+	}
+	watch, err := WatchContract(libs.Regtest, participant, watchParams)
+	if err != nil {
+		t.Fatalf("WatchContract: %v", err)
+	}
+	if watch.Found {
+		t.Error("unpublished contract found")
+	}
+
+	txHash := publishAndMine(t, sim, initiator, initResult.ContractTx)
+	if txHash != initResult.ContractTxHash {
+		t.Errorf("published %s not %s", txHash, initResult.ContractTxHash)
+	}
+
+	watch, err = WatchContract(libs.Regtest, participant, watchParams)
+	if err != nil {
+		t.Fatalf("WatchContract: %v", err)
+	}
+	if !watch.Found || watch.Confirmations != 1 || watch.Spent {
+		t.Errorf("watch after initiate %+v", watch)
+	}
+	if watch.RefundLocktime != initResult.ContractRefundLocktime {
+		t.Errorf("watch locktime %d", watch.RefundLocktime)
+	}
+
+	getTx, err := GetTx(libs.Regtest, initiator, txHash)
+	if err != nil {
+		t.Fatalf("GetTx: %v", err)
+	}
+	if getTx.Confirmations != 1 {
+		t.Errorf("contract tx has %d confirmations", getTx.Confirmations)
+	}
+
+	// only the participant can redeem
+	_, err = Redeem(libs.Regtest, initiator, libs.RedeemParams{
+		Secret:     secret,
+		Contract:   initResult.Contract,
+		ContractTx: initResult.ContractTx,
+	})
+	if err == nil {
+		t.Fatal("initiator redeemed the contract")
+	}
+
+	redeemResult, err := Redeem(libs.Regtest, participant, libs.RedeemParams{
+		Secret:     secret,
+		Contract:   initResult.Contract,
+		ContractTx: initResult.ContractTx,
+	})
+	if err != nil {
+		t.Fatalf("Redeem: %v", err)
+	}
+	publishAndMine(t, sim, participant, redeemResult.RedeemTx)
+
+	watch, err = WatchContract(libs.Regtest, initiator, watchParams)
+	if err != nil {
+		t.Fatalf("WatchContract: %v", err)
+	}
+	if !watch.Spent || !watch.Redeemed || watch.Secret != secret || watch.Confirmations != 2 {
+		t.Errorf("watch after redeem %+v", watch)
+	}
+
+	extracted, err := ExtractSecret(redeemResult.RedeemTx, secretHash)
+	if err != nil {
+		t.Fatalf("ExtractSecret: %v", err)
+	}
+	if extracted != secret {
+		t.Fatalf("extracted secret %s not %s", extracted, secret)
+	}
+
+	_, err = ExtractSecret(initResult.ContractTx, secretHash)
+	if err == nil {
+		t.Fatal("secret extracted from a contract transaction")
+	}
+}
+
+func TestInitiateInsufficientFunds(t *testing.T) {
+	_, initiator, _, _, participantAddr := newTestChain(t)
+
+	secretHash, err := libs.Hash256(libs.GetRand32())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Initiate(libs.Regtest, initiator, libs.InitiateParams{
+		SecretHash: secretHash,
+		CP2Addr:    participantAddr.Hex(),
+		CP2Amount:  200e9,
+	})
+	var fundsErr *libs.FundsError
+	if !errors.As(err, &fundsErr) {
+		t.Fatalf("Initiate: want a funds error, got %v", err)
+	}
+	if fundsErr.Required < 200e9 || fundsErr.Available <= 0 || fundsErr.Available >= 100e9 {
+		t.Fatalf("funds error amounts %+v", fundsErr)
+	}
+}
+
+func TestParticipateRefund(t *testing.T) {
+	sim, _, participant, initiatorAddr, _ := newTestChain(t)
+
+	savedLocktime := participateLocktime
+	// the simulated chain rejects blocks more than 15s ahead of the clock
+	participateLocktime = 0
+	defer func() { participateLocktime = savedLocktime }()
+
+	secretHash, err := libs.Hash256(libs.GetRand32())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	partResult, err := Participate(libs.Regtest, participant, libs.ParticipateParams{
+		SecretHash: secretHash,
+		CP1Addr:    initiatorAddr.Hex(),
+		CP1Amount:  5e8,
+	})
+	if err != nil {
+		t.Fatalf("Participate: %v", err)
+	}
+	publishAndMine(t, sim, participant, partResult.ContractTx)
+
+	refundParams := libs.RefundParams{
+		Contract:   partResult.Contract,
+		ContractTx: partResult.ContractTx,
+	}
+	_, err = Refund(libs.Regtest, participant, refundParams)
+	if err == nil {
+		t.Fatal("contract refunded before the locktime")
+	}
+
+	// move the simulated clock, which starts at 0 and adds 10s a block, to
+	// the locktime
+	head, err := sim.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wait := partResult.ContractRefundLocktime - int64(head.Time) - 10
+	err = sim.AdjustTime(time.Duration(wait) * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	refundResult, err := Refund(libs.Regtest, participant, refundParams)
+	if err != nil {
+		t.Fatalf("Refund: %v", err)
+	}
+	publishAndMine(t, sim, participant, refundResult.RefundTx)
+
+	swap, err := getSwap(simBackend{sim}, mustDecodeContract(t, partResult.Contract))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if swap.State != stateRefunded {
+		t.Fatalf("swap state %d after refund", swap.State)
+	}
+
+	watch, err := WatchContract(libs.Regtest, participant, libs.WatchParams{
+		Contract:     partResult.Contract,
+		ContractTxid: partResult.ContractTxHash,
+	})
+	if err != nil {
+		t.Fatalf("WatchContract: %v", err)
+	}
+	if !watch.Spent || watch.Redeemed || watch.Secret != "" {
+		t.Errorf("watch after refund %+v", watch)
+	}
+}
+
+func mustDecodeContract(t *testing.T, s string) *contract {
+	c, err := decodeContract(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// TestObservedTransport checks the RPCInfo observer is told of each JSON-RPC
+// request and of the error responses
+func TestObservedTransport(t *testing.T) {
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Method == "eth_call" {
+			fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"execution reverted"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":"0x539"}`)
+	}))
+	defer node.Close()
+
+	calls := map[string]error{}
+	observer := func(method string) func(err error) {
+		return func(err error) {
+			calls[method] = err
+		}
+	}
+	client := &http.Client{Transport: &observedTransport{observer: observer}}
+	for _, method := range []string{"eth_chainId", "eth_call"} {
+		body := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":%q,"params":[]}`, method)
+		resp, err := client.Post(node.URL, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var result struct {
+			Result string `json:"result"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("%s: response body not kept: %v", method, err)
+		}
+	}
+	if err, ok := calls["eth_chainId"]; !ok || err != nil {
+		t.Errorf("eth_chainId observed %v, %v", ok, err)
+	}
+	if err := calls["eth_call"]; err == nil || !strings.Contains(err.Error(), "execution reverted") {
+		t.Errorf("eth_call observed error %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package eth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// extractSecret is a convenience for the participant to examine and pull out the secret from
+// the initiator's redemption transaction.  The swap contract emits no events so the secret is
+// read from the redeem call input
+func extractSecret(redemptionTx string, secretHash string) (string, error) {
+	redemptionTxBytes, err := hex.DecodeString(redemptionTx)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode redemption transaction bytes: %v", err)
+	}
+
+	redeemTx, err := decodeTx(redemptionTxBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode redemption transaction: %v", err)
+	}
+
+	secretHashBytes, err := decodeSecretHash(secretHash)
+	if err != nil {
+		return "", err
+	}
+
+	redemptions, err := decodeRedeem(redeemTx.Data())
+	if err != nil {
+		return "", err
+	}
+	for _, r := range redemptions {
+		if r.SecretHash == secretHashBytes && sha256.Sum256(r.Secret[:]) == secretHashBytes {
+			return hex.EncodeToString(r.Secret[:]), nil
+		}
+	}
+	return "", errors.New("transaction does not contain the secret")
+}
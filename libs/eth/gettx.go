@@ -0,0 +1,20 @@
+package eth
+
+import (
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+func getTx(network libs.Network, rpcinfo libs.RPCInfo, txid string) (*libs.GetTxResult, error) {
+	client, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	result, err := getTransaction(client, txid)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package eth
+
+import (
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// initiate builds a contract & a contract transaction depending on the secret hash parameter
+// passed in
+func initiate(network libs.Network, rpcinfo libs.RPCInfo, params libs.InitiateParams) (*libs.InitiateResult, error) {
+	if !common.IsHexAddress(params.CP2Addr) {
+		return nil, errors.New("failed to decode participant address")
+	}
+	cp2Addr := common.HexToAddress(params.CP2Addr)
+
+	secretHash, err := decodeSecretHash(params.SecretHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// the swap contract compares the locktime with the block timestamp
+	locktime := time.Now().Add(initiateLocktime).Unix()
+
+	b, err := buildContract(network, rpcinfo, &contractArgs{
+		them:       cp2Addr,
+		amount:     params.CP2Amount,
+		locktime:   locktime,
+		secretHash: secretHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contractTxBytes, err := b.contractTx.tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var result = &libs.InitiateResult{}
+
+	result.Contract = b.contract.String()
+	result.ContractP2SH = b.contract.address.Hex()
+	result.ContractTx = hex.EncodeToString(contractTxBytes)
+	result.ContractTxHash = b.contractTx.tx.Hash().Hex()
+	result.ContractFee = b.contractFee
+	result.ContractFeePerKb = weiToGweiFloat(b.contractTx.feePerGas)
+	result.ContractRefundLocktime = locktime
+
+	return result, nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package eth
+
+import (
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// participate builds a contract & a contract transaction depending upon the hash of the
+// (shared) secret. The participant will know the secret only when initiator redeems the
+// contract made here
+func participate(network libs.Network, rpcinfo libs.RPCInfo, params libs.ParticipateParams) (*libs.ParticipateResult, error) {
+	if !common.IsHexAddress(params.CP1Addr) {
+		return nil, errors.New("failed to decode initiator address")
+	}
+	cp1Addr := common.HexToAddress(params.CP1Addr)
+
+	secretHash, err := decodeSecretHash(params.SecretHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// the swap contract compares the locktime with the block timestamp
+	locktime := time.Now().Add(participateLocktime).Unix()
+
+	b, err := buildContract(network, rpcinfo, &contractArgs{
+		them:       cp1Addr,
+		amount:     params.CP1Amount,
+		locktime:   locktime,
+		secretHash: secretHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contractTxBytes, err := b.contractTx.tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var result = &libs.ParticipateResult{}
+
+	result.Contract = b.contract.String()
+	result.ContractP2SH = b.contract.address.Hex()
+	result.ContractTx = hex.EncodeToString(contractTxBytes)
+	result.ContractTxHash = b.contractTx.tx.Hash().Hex()
+	result.ContractFee = b.contractFee
+	result.ContractFeePerKb = weiToGweiFloat(b.contractTx.feePerGas)
+	result.ContractRefundLocktime = locktime
+
+	return result, nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package eth
+
+import (
+	"context"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// rpcClienter is implemented by backends that expose the raw node RPC client,
+// like ethclient, and is used to get the client version
+type rpcClienter interface {
+	Client() *rpc.Client
+}
+
+// pingrpc tests if node RPC is available on the network and the account key
+// can be decrypted. It reports the chain id, best block, client version and
+// whether the chain has EIP-1559 fees
+func pingrpc(network libs.Network, rpcinfo libs.RPCInfo) (*libs.PingResult, error) {
+	client, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	_, _, err = loadKey(rpcinfo, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &libs.PingResult{
+		Chain:  getChainID(network).String(),
+		Blocks: header.Number.Int64(),
+	}
+	result.DynamicFees = header.BaseFee != nil
+	if c, ok := client.(rpcClienter); ok {
+		var version string
+		err = c.Client().CallContext(ctx, &version, "web3_clientVersion")
+		if err == nil {
+			result.Version = version
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package eth
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// Publish (broadcast) transaction to the network.
+func publish(network libs.Network, rpcinfo libs.RPCInfo, tx string) (string, error) {
+	txBytes, err := hex.DecodeString(tx)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode broadcast transaction bytes: %v", err)
+	}
+
+	broadcastTx, err := decodeTx(txBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode broadcast transaction: %v", err)
+	}
+
+	client, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	txHash, err := sendRawTransaction(client, broadcastTx)
+	if err != nil {
+		return "", err
+	}
+
+	return txHash, nil
+}
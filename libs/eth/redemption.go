@@ -0,0 +1,84 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package eth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// Build a transaction that can redeem the coins in the passed in contract using
+// the (shared) secret
+func redeem(network libs.Network, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs.RedeemResult, error) {
+	c, err := decodeContract(params.Contract)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := hex.DecodeString(params.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode secret: %v", err)
+	}
+	if len(secret) != secretSize {
+		return nil, errors.New("secret has wrong size")
+	}
+	if sha256.Sum256(secret) != c.secretHash {
+		return nil, errors.New("secret does not hash to the contract secret hash")
+	}
+
+	client, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	swap, err := getSwap(client, c)
+	if err != nil {
+		return nil, err
+	}
+	if swap.State != stateFilled {
+		return nil, errors.New("contract is not redeemable")
+	}
+	key, _, err := loadKey(rpcinfo, &swap.Participant)
+	if err != nil {
+		return nil, err
+	}
+
+	var r redemption
+	copy(r.Secret[:], secret)
+	r.SecretHash = c.secretHash
+	data, err := parsedABI.Pack("redeem", []redemption{r})
+	if err != nil {
+		return nil, err
+	}
+
+	redeemTx, err := buildTx(client, network, key, &c.address, new(big.Int), data)
+	if err != nil {
+		return nil, err
+	}
+	redeemFee, err := weiToGwei(redeemTx.fee)
+	if err != nil {
+		return nil, err
+	}
+
+	redeemTxBytes, err := redeemTx.tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var result = &libs.RedeemResult{}
+
+	result.RedeemTx = hex.EncodeToString(redeemTxBytes)
+	result.RedeemTxHash = redeemTx.tx.Hash().Hex()
+	result.RedeemFee = redeemFee
+	result.RedeemFeePerKb = weiToGweiFloat(redeemTx.feePerGas)
+
+	return result, nil
+}
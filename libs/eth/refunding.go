@@ -0,0 +1,77 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package eth
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// refund builds a refund transaction for a contract that is past its locktime.
+// The swap contract always refunds to the initiator of the swap
+func refund(network libs.Network, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs.RefundResult, error) {
+	c, err := decodeContract(params.Contract)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	swap, err := getSwap(client, c)
+	if err != nil {
+		return nil, err
+	}
+	if swap.State != stateFilled {
+		return nil, errors.New("contract is not refundable")
+	}
+	key, _, err := loadKey(rpcinfo, &swap.Initiator)
+	if err != nil {
+		return nil, err
+	}
+
+	refundable, err := isRefundable(client, c)
+	if err != nil {
+		return nil, err
+	}
+	if !refundable {
+		return nil, fmt.Errorf("contract locktime %v has not been reached", swap.RefundBlockTimestamp)
+	}
+
+	data, err := parsedABI.Pack("refund", c.secretHash)
+	if err != nil {
+		return nil, err
+	}
+
+	refundTx, err := buildTx(client, network, key, &c.address, new(big.Int), data)
+	if err != nil {
+		return nil, err
+	}
+	refundFee, err := weiToGwei(refundTx.fee)
+	if err != nil {
+		return nil, err
+	}
+
+	refundTxBytes, err := refundTx.tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var result = &libs.RefundResult{}
+
+	result.RefundTx = hex.EncodeToString(refundTxBytes)
+	result.RefundTxHash = refundTx.tx.Hash().Hex()
+	result.RefundFee = refundFee
+	result.RefundFeePerKb = weiToGweiFloat(refundTx.feePerGas)
+
+	return result, nil
+}
@@ -0,0 +1,463 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package eth
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// rpcTimeout is the timeout of each node RPC command
+const rpcTimeout = 30 * time.Second
+
+// backend is the node access used by this library.  It is satisfied by the
+// go-ethereum ethclient and, wrapped, by the simulated backend used in tests
+type backend interface {
+	bind.ContractBackend
+	ChainID(ctx context.Context) (*big.Int, error)
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	Close()
+}
+
+// dial connects to the node RPC.  It is a variable so tests can substitute
+// an in-process backend
+var dial = func(rpcinfo libs.RPCInfo) (backend, error) {
+	nodeURL, err := getNodeURL(rpcinfo.HostPort)
+	if err != nil {
+		return nil, fmt.Errorf("node address: %v", err)
+	}
+	var opts []rpc.ClientOption
+	if rpcinfo.User != "" {
+		opts = append(opts, rpc.WithHTTPAuth(func(h http.Header) error {
+			req := &http.Request{Header: h}
+			req.SetBasicAuth(rpcinfo.User, rpcinfo.Pass)
+			return nil
+		}))
+	}
+	if rpcinfo.Observer != nil {
+		opts = append(opts, rpc.WithHTTPClient(&http.Client{
+			Transport: &observedTransport{observer: rpcinfo.Observer},
+		}))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+	client, err := rpc.DialOptions(ctx, nodeURL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("rpc connect: %v", err)
+	}
+	return ethclient.NewClient(client), nil
+}
+
+// observedTransport tells the RPCInfo observer of each JSON-RPC request made
+// over HTTP.  A request fails if the HTTP request fails or the response is an
+// error
+type observedTransport struct {
+	observer libs.RPCObserver
+}
+
+func (t *observedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	var call struct {
+		Method string `json:"method"`
+	}
+	method := "batch"
+	if json.Unmarshal(body, &call) == nil {
+		method = call.Method
+	}
+	done := t.observer.Observe(method)
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		done(err)
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		done(fmt.Errorf("%s: %s", method, resp.Status))
+		return resp, nil
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		done(err)
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+	var result struct {
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(respBody, &result) == nil && result.Error != nil {
+		done(fmt.Errorf("%s: %d: %s", method, result.Error.Code, result.Error.Message))
+	} else {
+		done(nil)
+	}
+	return resp, nil
+}
+
+// startRPC - connects to the node RPC specified in RPCInfo and checks the node
+// is on the network
+func startRPC(network libs.Network, rpcinfo libs.RPCInfo) (backend, error) {
+	client, err := dial(rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("chain id: %v", err)
+	}
+	if chainID.Cmp(getChainID(network)) != 0 {
+		client.Close()
+		return nil, fmt.Errorf("node is on chain %v not %v", chainID, getChainID(network))
+	}
+	return client, nil
+}
+
+/////////////
+// Account //
+/////////////
+
+// The node is used for chain access only and does not hold keys.  The swap
+// account is an encrypted keystore file: RPCInfo.Certs is the keystore file,
+// or a keystore directory in which case new swaps are made from the first key
+// file in name order (the oldest for geth key file names) and redeems and
+// refunds use the key of the swap participant or initiator. RPCInfo.WalletPass
+// is the keystore passphrase
+
+// keyFileAddress is the address field of a keystore file
+type keyFileAddress struct {
+	Address string `json:"address"`
+}
+
+// keyFiles gets the keystore files of a keystore file or directory
+func keyFiles(keystorePath string) ([]string, error) {
+	if keystorePath == "" {
+		return nil, errors.New("no keystore (certs) given for the account")
+	}
+	fi, err := os.Stat(keystorePath)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return []string{keystorePath}, nil
+	}
+	entries, err := os.ReadDir(keystorePath)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || e.Name()[0] == '.' {
+			continue
+		}
+		files = append(files, filepath.Join(keystorePath, e.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// loadKey decrypts the key for an address, or the first key if addr is nil
+func loadKey(rpcinfo libs.RPCInfo, addr *common.Address) (*ecdsa.PrivateKey, common.Address, error) {
+	files, err := keyFiles(rpcinfo.Certs)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	for _, file := range files {
+		keyJSON, err := os.ReadFile(file)
+		if err != nil {
+			return nil, common.Address{}, err
+		}
+		var kfa keyFileAddress
+		if json.Unmarshal(keyJSON, &kfa) != nil || !common.IsHexAddress(kfa.Address) {
+			continue
+		}
+		if addr != nil && common.HexToAddress(kfa.Address) != *addr {
+			continue
+		}
+		key, err := keystore.DecryptKey(keyJSON, rpcinfo.WalletPass)
+		if err != nil {
+			return nil, common.Address{}, fmt.Errorf("keystore %s: %v", filepath.Base(file), err)
+		}
+		return key.PrivateKey, key.Address, nil
+	}
+	if addr != nil {
+		return nil, common.Address{}, fmt.Errorf("no key for %s in the keystore", addr.Hex())
+	}
+	return nil, common.Address{}, errors.New("no key file in the keystore")
+}
+
+// newKey makes a new key in a keystore directory
+func newKey(rpcinfo libs.RPCInfo) (common.Address, error) {
+	fi, err := os.Stat(rpcinfo.Certs)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !fi.IsDir() {
+		return common.Address{}, errors.New("new keys need a keystore directory")
+	}
+	account, err := keystore.StoreKey(rpcinfo.Certs, rpcinfo.WalletPass, keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return account.Address, nil
+}
+
+//////////////////
+// Transactions //
+//////////////////
+
+// builtTx is a signed transaction and its maximum fee
+type builtTx struct {
+	tx        *types.Transaction
+	fee       *big.Int
+	feePerGas *big.Int
+}
+
+// buildTx builds and signs a dynamic fee transaction from the key owner. The
+// gas is estimated, which also checks the call succeeds against the current
+// chain state. The fee cap is twice the latest base fee plus the tip
+func buildTx(client backend, network libs.Network, key *ecdsa.PrivateKey, to *common.Address, value *big.Int, data []byte) (*builtTx, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	nonce, err := client.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("nonce: %v", err)
+	}
+	gas, err := client.EstimateGas(ctx, ethereum.CallMsg{
+		From:  from,
+		To:    to,
+		Value: value,
+		Data:  data,
+	})
+	if err != nil {
+		err = fmt.Errorf("estimate gas: %v", err)
+		if libs.IsInsufficientFunds(err) {
+			return nil, fundsError(ctx, client, from, value, err)
+		}
+		return nil, err
+	}
+	tip, err := client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("gas tip: %v", err)
+	}
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("latest header: %v", err)
+	}
+	if head.BaseFee == nil {
+		return nil, errors.New("node chain has no base fee (pre london)")
+	}
+	feeCap := new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), tip)
+	// round the fee cap up to a whole gwei so the fee can be reported in gwei
+	feeCap.Add(feeCap, new(big.Int).Sub(gwei, big.NewInt(1)))
+	feeCap.Sub(feeCap, new(big.Int).Mod(feeCap, gwei))
+
+	fee := new(big.Int).Mul(feeCap, new(big.Int).SetUint64(gas))
+	required := new(big.Int).Add(value, fee)
+	balance, err := client.BalanceAt(ctx, from, nil)
+	if err != nil {
+		return nil, fmt.Errorf("balance: %v", err)
+	}
+	if balance.Cmp(required) < 0 {
+		return nil, &libs.FundsError{
+			Required:  new(big.Int).Quo(required, gwei).Int64(),
+			Available: new(big.Int).Quo(balance, gwei).Int64(),
+			Err:       errors.New("balance is less than the value plus the maximum fee"),
+		}
+	}
+
+	chainID := getChainID(network)
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: tip,
+		GasFeeCap: feeCap,
+		Gas:       gas,
+		To:        to,
+		Value:     value,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &builtTx{
+		tx:        tx,
+		fee:       fee,
+		feePerGas: feeCap,
+	}, nil
+}
+
+// fundsError makes a libs.FundsError of an insufficient funds error of a
+// transaction from the account sending value
+func fundsError(ctx context.Context, client backend, from common.Address, value *big.Int, err error) error {
+	fundsErr := &libs.FundsError{Required: new(big.Int).Quo(value, gwei).Int64(), Available: -1, Err: err}
+	balance, err := client.BalanceAt(ctx, from, nil)
+	if err == nil {
+		fundsErr.Available = new(big.Int).Quo(balance, gwei).Int64()
+	}
+	return fundsErr
+}
+
+// decodeTx decodes a signed transaction from its binary encoding
+func decodeTx(b []byte) (*types.Transaction, error) {
+	tx := new(types.Transaction)
+	err := tx.UnmarshalBinary(b)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// txSender gets the sender of a signed transaction
+func txSender(tx *types.Transaction) (common.Address, error) {
+	return types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+}
+
+// sendRawTransaction broadcasts a signed transaction
+func sendRawTransaction(client backend, tx *types.Transaction) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+	err := client.SendTransaction(ctx, tx)
+	if err != nil {
+		return "", fmt.Errorf("sendrawtransaction: %v", err)
+	}
+	return tx.Hash().Hex(), nil
+}
+
+// callContract calls a swap contract view method for a swap and gets the
+// single result
+func callContract(client backend, c *contract, method string) (interface{}, error) {
+	data, err := parsedABI.Pack(method, c.secretHash)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+	out, err := client.CallContract(ctx, ethereum.CallMsg{To: &c.address, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("swap contract %s: %v", method, err)
+	}
+	res, err := parsedABI.Unpack(method, out)
+	if err != nil {
+		return nil, fmt.Errorf("swap contract %s: %v", method, err)
+	}
+	if len(res) != 1 {
+		return nil, fmt.Errorf("swap contract %s returned %d results", method, len(res))
+	}
+	return res[0], nil
+}
+
+// getSwap gets the current state of a swap from the swap contract
+func getSwap(client backend, c *contract) (*swapState, error) {
+	res, err := callContract(client, c, "swap")
+	if err != nil {
+		return nil, err
+	}
+	return abi.ConvertType(res, new(swapState)).(*swapState), nil
+}
+
+// isRefundable checks if a swap can be refunded now
+func isRefundable(client backend, c *contract) (bool, error) {
+	res, err := callContract(client, c, "isRefundable")
+	if err != nil {
+		return false, err
+	}
+	refundable, ok := res.(bool)
+	if !ok {
+		return false, errors.New("swap contract isRefundable did not return a bool")
+	}
+	return refundable, nil
+}
+
+// getTransaction gets a transaction and, if mined, its block position
+func getTransaction(client backend, txid string) (*libs.GetTxResult, error) {
+	if !isTxHash(txid) {
+		return nil, fmt.Errorf("invalid transaction hash %q", txid)
+	}
+	hash := common.HexToHash(txid)
+
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	tx, pending, err := client.TransactionByHash(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("gettransaction: %v", err)
+	}
+	txBytes, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &libs.GetTxResult{}
+	result.Hex = hex.EncodeToString(txBytes)
+	if pending {
+		return result, nil
+	}
+
+	receipt, err := client.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("gettransactionreceipt: %v", err)
+	}
+	header, err := client.HeaderByHash(ctx, receipt.BlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getblockheader: %v", err)
+	}
+	tip, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("latest header: %v", err)
+	}
+
+	if tip.Number.Cmp(receipt.BlockNumber) >= 0 {
+		result.Confirmations = new(big.Int).Sub(tip.Number, receipt.BlockNumber).Uint64() + 1
+	}
+	result.Blockhash = receipt.BlockHash.Hex()
+	result.Blockindex = int(receipt.TransactionIndex)
+	result.Blocktime = header.Time
+	result.Time = header.Time
+	result.TimeReceived = header.Time
+
+	return result, nil
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package eth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"sync"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// chainIDs are the EIP-155 chain ids of mainnet, the testnet (sepolia) and
+// regtest - a geth --dev chain
+var chainIDs = map[libs.Network]*big.Int{
+	libs.Mainnet: big.NewInt(1),
+	libs.Testnet: big.NewInt(11155111),
+	libs.Regtest: big.NewInt(1337),
+}
+
+// checkNetwork checks the network is one the library supports
+func checkNetwork(network libs.Network) error {
+	if _, ok := chainIDs[network]; !ok {
+		return fmt.Errorf("%s is not supported", network)
+	}
+	return nil
+}
+
+// getChainID gets the chain id for the network
+func getChainID(network libs.Network) *big.Int {
+	return chainIDs[network]
+}
+
+// defaultRPCPort is the node JSON-RPC port for all networks
+const defaultRPCPort = "8545"
+
+// getNodeURL gets the node RPC URL from a host[:port] or a full http(s)://,
+// ws(s):// or ipc path URL. A bare host gets the default http port
+func getNodeURL(hostport string) (string, error) {
+	if hostport == "" {
+		hostport = "localhost"
+	}
+	u, err := url.Parse(hostport)
+	if err == nil && u.Scheme != "" && u.Host != "" {
+		return hostport, nil
+	}
+	if err == nil && u.Scheme == "" && u.Path != "" && u.Path[0] == '/' {
+		// ipc endpoint
+		return hostport, nil
+	}
+	host, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host, port = hostport, defaultRPCPort
+	}
+	return "http://" + net.JoinHostPort(host, port), nil
+}
+
+// swapContracts are the swap contract addresses keyed by network
+var swapContracts = struct {
+	sync.RWMutex
+	addrs map[libs.Network]common.Address
+}{addrs: map[libs.Network]common.Address{}}
+
+// checkSwapContract checks a swap contract address is a hex address
+func checkSwapContract(addr string) error {
+	if !common.IsHexAddress(addr) {
+		return fmt.Errorf("invalid swap contract address %q", addr)
+	}
+	return nil
+}
+
+// setSwapContract sets the swap contract address used for new swaps
+func setSwapContract(network libs.Network, addr string) error {
+	err := checkSwapContract(addr)
+	if err != nil {
+		return err
+	}
+	swapContracts.Lock()
+	swapContracts.addrs[network] = common.HexToAddress(addr)
+	swapContracts.Unlock()
+	return nil
+}
+
+// getSwapContract gets the swap contract address used for new swaps
+func getSwapContract(network libs.Network) (common.Address, error) {
+	swapContracts.RLock()
+	addr, ok := swapContracts.addrs[network]
+	swapContracts.RUnlock()
+	if !ok {
+		return common.Address{}, errors.New("no swap contract address set for the network")
+	}
+	return addr, nil
+}
+
+// gwei is one gwei in wei.  The int64 amounts and fees of the common swap
+// structures are in gwei as wei amounts overflow an int64
+var gwei = big.NewInt(1e9)
+
+func gweiToWei(v int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(v), gwei)
+}
+
+func weiToGwei(v *big.Int) (int64, error) {
+	q, r := new(big.Int).QuoRem(v, gwei, new(big.Int))
+	if r.Sign() != 0 {
+		return 0, fmt.Errorf("%v wei is not a whole number of gwei", v)
+	}
+	if !q.IsInt64() {
+		return 0, fmt.Errorf("%v wei is out of range", v)
+	}
+	return q.Int64(), nil
+}
+
+// weiToGweiFloat converts a fee rate to gwei
+func weiToGweiFloat(v *big.Int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(v), new(big.Float).SetInt(gwei)).Float64()
+	return f
+}
+
+// A swap contract is identified by the swap contract address and the secret
+// hash.  The hex of the address followed by the secret hash is used as the
+// Contract string of the common swap structures
+type contract struct {
+	address    common.Address
+	secretHash [sha256.Size]byte
+}
+
+func (c *contract) String() string {
+	return hex.EncodeToString(append(c.address.Bytes(), c.secretHash[:]...))
+}
+
+// decodeContract decodes a Contract string
+func decodeContract(s string) (*contract, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract: %v", err)
+	}
+	if len(b) != common.AddressLength+sha256.Size {
+		return nil, errors.New("contract has wrong size")
+	}
+	c := &contract{address: common.BytesToAddress(b[:common.AddressLength])}
+	copy(c.secretHash[:], b[common.AddressLength:])
+	return c, nil
+}
+
+// isTxHash checks a transaction id is 32 bytes of hex with or without 0x
+func isTxHash(txid string) bool {
+	if has0xPrefix(txid) {
+		txid = txid[2:]
+	}
+	b, err := hex.DecodeString(txid)
+	return err == nil && len(b) == common.HashLength
+}
+
+func has0xPrefix(s string) bool {
+	return len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X')
+}
+
+// decodeSecretHash decodes a hex secret hash
+func decodeSecretHash(s string) ([sha256.Size]byte, error) {
+	var secretHash [sha256.Size]byte
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return secretHash, errors.New("secret hash must be hex encoded")
+	}
+	if len(b) != sha256.Size {
+		return secretHash, errors.New("secret hash has wrong size")
+	}
+	copy(secretHash[:], b)
+	return secretHash, nil
+}
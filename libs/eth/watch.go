@@ -0,0 +1,75 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package eth
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// watchContract gets the state of a swap from the swap contract. The swap
+// contract emits no events so the redeem or refund transaction is not found,
+// the swap state holds the secret of a redeem. A spend is reported with one
+// confirmation
+func watchContract(network libs.Network, rpcinfo libs.RPCInfo, params libs.WatchParams) (*libs.WatchResult, error) {
+	c, err := decodeContract(params.Contract)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+	tip, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &libs.WatchResult{
+		Blocks:        tip.Number.Int64(),
+		BestBlockhash: tip.Hash().Hex(),
+		MedianTime:    int64(tip.Time),
+	}
+
+	swap, err := getSwap(client, c)
+	if err != nil {
+		return nil, err
+	}
+	if swap.State == stateEmpty {
+		// the initiate transaction may be pending
+		if isTxHash(params.ContractTxid) {
+			_, pending, err := client.TransactionByHash(ctx, common.HexToHash(params.ContractTxid))
+			result.Found = err == nil && pending
+		}
+		return result, nil
+	}
+	initHeader, err := client.HeaderByNumber(ctx, swap.InitBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	result.Found = true
+	result.Confirmations = tip.Number.Int64() - swap.InitBlockNumber.Int64() + 1
+	result.Blockhash = initHeader.Hash().Hex()
+	result.RefundLocktime = swap.RefundBlockTimestamp.Int64()
+
+	switch swap.State {
+	case stateRedeemed:
+		result.Spent = true
+		result.SpendConfirmations = 1
+		result.Redeemed = true
+		result.Secret = hex.EncodeToString(swap.Secret[:])
+	case stateRefunded:
+		result.Spent = true
+		result.SpendConfirmations = 1
+	}
+	return result, nil
+}
@@ -0,0 +1,247 @@
+package libs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Logging of the swap libs and the server. Records have a level, a message
+// and structured fields. A Log redacts the secret fields of each record then
+// writes it to a Logger, which may be any log output: the text logger by
+// default or, in the server, a JSON logger. Secrets must only be logged as
+// fields, never in the message
+
+// Level is a log level
+type Level int
+
+// Levels
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = map[Level]string{
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+}
+
+func (l Level) String() string {
+	name, ok := levelNames[l]
+	if !ok {
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+	return name
+}
+
+// ParseLevel gets a level from its name
+func ParseLevel(name string) (Level, error) {
+	for l, s := range levelNames {
+		if strings.EqualFold(name, s) {
+			return l, nil
+		}
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %q", name)
+}
+
+// Field is a structured log field
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// F makes a log field
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Logger is a log output. The records it gets are already redacted
+type Logger interface {
+	Log(level Level, msg string, fields []Field)
+}
+
+var (
+	loggerMu sync.RWMutex
+	logger   Logger = NewTextLogger(os.Stderr, LevelInfo)
+)
+
+// SetLogger sets the log output of the Logs without their own. A nil logger
+// discards the records
+func SetLogger(l Logger) {
+	if l == nil {
+		l = discard{}
+	}
+	loggerMu.Lock()
+	logger = l
+	loggerMu.Unlock()
+}
+
+// discard is a Logger dropping the records
+type discard struct{}
+
+func (discard) Log(Level, string, []Field) {}
+
+// Log is a leveled logger with fields added to every record. Secret fields
+// are redacted. A nil Log writes to the logger set with SetLogger
+type Log struct {
+	out    Logger
+	fields []Field
+}
+
+// NewLog makes a Log writing to out. A nil out is the logger set with
+// SetLogger at the time of each record
+func NewLog(out Logger, fields ...Field) *Log {
+	return &Log{out: out, fields: fields}
+}
+
+// With makes a Log adding the fields to every record
+func (l *Log) With(fields ...Field) *Log {
+	if l == nil {
+		return NewLog(nil, fields...)
+	}
+	all := make([]Field, 0, len(l.fields)+len(fields))
+	all = append(all, l.fields...)
+	all = append(all, fields...)
+	return &Log{out: l.out, fields: all}
+}
+
+// Debug logs a debug record
+func (l *Log) Debug(msg string, fields ...Field) {
+	l.log(LevelDebug, msg, fields)
+}
+
+// Info logs an info record
+func (l *Log) Info(msg string, fields ...Field) {
+	l.log(LevelInfo, msg, fields)
+}
+
+// Warn logs a warning record
+func (l *Log) Warn(msg string, fields ...Field) {
+	l.log(LevelWarn, msg, fields)
+}
+
+// Error logs an error record
+func (l *Log) Error(msg string, fields ...Field) {
+	l.log(LevelError, msg, fields)
+}
+
+func (l *Log) log(level Level, msg string, fields []Field) {
+	var out Logger
+	var all []Field
+	if l != nil {
+		out = l.out
+		all = make([]Field, 0, len(l.fields)+len(fields))
+		all = append(all, l.fields...)
+	}
+	all = append(all, fields...)
+	if out == nil {
+		loggerMu.RLock()
+		out = logger
+		loggerMu.RUnlock()
+	}
+	out.Log(level, msg, Redact(all))
+}
+
+///////////////
+// Redaction //
+///////////////
+
+// Redacted replaces the value of a secret field
+const Redacted = "[redacted]"
+
+// secretKeys are parts of the keys of secret fields: wallet passphrases, RPC
+// passwords, swap secrets, auth tokens and private keys
+var secretKeys = []string{"pass", "secret", "token", "privkey", "privatekey", "wif", "seed",
+	"authorization", "credential"}
+
+// IsSecretKey checks if a field key names a secret. Hashes of secrets, such as
+// a secret hash, are not secret
+func IsSecretKey(key string) bool {
+	k := strings.ToLower(strings.NewReplacer("_", "", "-", "", ".", "").Replace(key))
+	if strings.HasSuffix(k, "hash") {
+		return false
+	}
+	for _, s := range secretKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact gets a copy of the fields with the secret values redacted. An RPCInfo
+// value is logged without its passwords
+func Redact(fields []Field) []Field {
+	redacted := make([]Field, len(fields))
+	for i, f := range fields {
+		switch v := f.Value.(type) {
+		case RPCInfo:
+			f.Value = v.String()
+		case *RPCInfo:
+			if v != nil {
+				f.Value = v.String()
+			}
+		}
+		if IsSecretKey(f.Key) && f.Value != nil && f.Value != "" {
+			f.Value = Redacted
+		}
+		redacted[i] = f
+	}
+	return redacted
+}
+
+// String formats the RPC information without the RPC and wallet passwords
+func (r RPCInfo) String() string {
+	if r.Pass != "" {
+		r.Pass = Redacted
+	}
+	if r.WalletPass != "" {
+		r.WalletPass = Redacted
+	}
+	return fmt.Sprintf("{User:%s Pass:%s HostPort:%s WalletPass:%s Certs:%s JSONRPC:%t NodeHostPort:%s NodeCerts:%s}",
+		r.User, r.Pass, r.HostPort, r.WalletPass, r.Certs, r.JSONRPC, r.NodeHostPort, r.NodeCerts)
+}
+
+/////////////////
+// Text Logger //
+/////////////////
+
+// TextLogger writes records as text lines: time, level, message and the
+// fields as key=value
+type TextLogger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level Level
+}
+
+// NewTextLogger makes a text logger writing the records of level and above
+// to w
+func NewTextLogger(w io.Writer, level Level) *TextLogger {
+	return &TextLogger{w: w, level: level}
+}
+
+// Log writes a record
+func (t *TextLogger) Log(level Level, msg string, fields []Field) {
+	if level < t.level {
+		return
+	}
+	var b strings.Builder
+	b.WriteString(time.Now().Format("2006/01/02 15:04:05 "))
+	b.WriteString(strings.ToUpper(level.String()))
+	b.WriteString(" ")
+	b.WriteString(msg)
+	for _, f := range fields {
+		fmt.Fprintf(&b, " %s=%v", f.Key, f.Value)
+	}
+	b.WriteString("\n")
+	t.mu.Lock()
+	io.WriteString(t.w, b.String())
+	t.mu.Unlock()
+}
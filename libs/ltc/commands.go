@@ -19,44 +19,77 @@ const secretSize = 32
 
 const txVersion = 2 // litecoin 0.16 needs tx v2
 
-// PingRPC tests if wallet node RPC is available
-func PingRPC(testnet bool, rpcinfo libs.RPCInfo) error {
-	return pingrpc(testnet, rpcinfo)
+// PingRPC tests if wallet node RPC is available and reports the chain, version
+// and capabilities of the node
+func PingRPC(network libs.Network, rpcinfo libs.RPCInfo) (*libs.PingResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return pingrpc(network, rpcinfo)
 }
 
 // GetNewAddress gets a new address from the controlled wallet
-func GetNewAddress(testnet bool, rpcinfo libs.RPCInfo) (string, error) {
-	return newaddress(testnet, rpcinfo)
+func GetNewAddress(network libs.Network, rpcinfo libs.RPCInfo) (string, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return "", err
+	}
+	return newaddress(network, rpcinfo)
 }
 
 // Initiate command builds a P2SH contract and a transaction to fund it
-func Initiate(testnet bool, rpcinfo libs.RPCInfo, params libs.InitiateParams) (*libs.InitiateResult, error) {
-	return initiate(testnet, rpcinfo, params)
+func Initiate(network libs.Network, rpcinfo libs.RPCInfo, params libs.InitiateParams) (*libs.InitiateResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return initiate(network, rpcinfo, params)
 }
 
 // Participate command builds a P2SH contract and a transaction to fund it
-func Participate(testnet bool, rpcinfo libs.RPCInfo, params libs.ParticipateParams) (*libs.ParticipateResult, error) {
-	return participate(testnet, rpcinfo, params)
+func Participate(network libs.Network, rpcinfo libs.RPCInfo, params libs.ParticipateParams) (*libs.ParticipateResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return participate(network, rpcinfo, params)
 }
 
 // Redeem command builds a transaction to redeem a contract
-func Redeem(testnet bool, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs.RedeemResult, error) {
-	return redeem(testnet, rpcinfo, params)
+func Redeem(network libs.Network, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs.RedeemResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return redeem(network, rpcinfo, params)
 }
 
 // Refund command builds a refund transaction for an unredeemed contract
-func Refund(testnet bool, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs.RefundResult, error) {
-	return refund(testnet, rpcinfo, params)
+func Refund(network libs.Network, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs.RefundResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return refund(network, rpcinfo, params)
 }
 
 // AuditContract command
-func AuditContract(testnet bool, params libs.AuditParams) (*libs.AuditResult, error) {
-	return auditContract(testnet, params)
+func AuditContract(network libs.Network, params libs.AuditParams) (*libs.AuditResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return auditContract(network, params)
 }
 
 // Publish command broadcasts a raw hex transaction
-func Publish(testnet bool, rpcinfo libs.RPCInfo, tx string) (string, error) {
-	txhash, err := publish(testnet, rpcinfo, tx)
+func Publish(network libs.Network, rpcinfo libs.RPCInfo, tx string) (string, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return "", err
+	}
+	txhash, err := publish(network, rpcinfo, tx)
 	if err != nil {
 		return "", err
 	}
@@ -69,8 +102,22 @@ func ExtractSecret(redemptionTx string, secretHash string) (string, error) {
 }
 
 // GetTx gets info on a broadcasted transaction
-func GetTx(testnet bool, rpcinfo libs.RPCInfo, txid string) (*libs.GetTxResult, error) {
-	return getTx(testnet, rpcinfo, txid)
+func GetTx(network libs.Network, rpcinfo libs.RPCInfo, txid string) (*libs.GetTxResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return getTx(network, rpcinfo, txid)
+}
+
+// WatchContract gets the state of a contract on the chain: the contract tx
+// confirmations and the spend, if any, with the secret of a redeem
+func WatchContract(network libs.Network, rpcinfo libs.RPCInfo, params libs.WatchParams) (*libs.WatchResult, error) {
+	err := checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return watchContract(network, rpcinfo, params)
 }
 
 //...
@@ -8,7 +8,7 @@ import (
 	"errors"
 	"fmt"
 
-	rpc "github.com/ltcsuite/ltcd/rpcclient"
+	"github.com/devwarrior777/atomicswap/libs"
 	"github.com/ltcsuite/ltcd/txscript"
 	"github.com/ltcsuite/ltcd/wire"
 	"github.com/ltcsuite/ltcutil"
@@ -36,8 +36,8 @@ type builtContract struct {
 // buildContract creates a contract for the parameters specified in args, using
 // wallet RPC to generate an internal address to redeem the refund and to sign
 // the payment to the contract transaction.
-func buildContract(testnet bool, rpcclient *rpc.Client, args *contractArgs) (*builtContract, error) {
-	refundAddr, err := getRawChangeAddress(testnet, rpcclient)
+func buildContract(network libs.Network, rpcclient *rpcClient, args *contractArgs) (*builtContract, error) {
+	refundAddr, err := getRawChangeAddress(network, rpcclient)
 	if err != nil {
 		return nil, fmt.Errorf("getrawchangeaddress: %v", err)
 	}
@@ -53,7 +53,7 @@ func buildContract(testnet bool, rpcclient *rpc.Client, args *contractArgs) (*bu
 	if err != nil {
 		return nil, err
 	}
-	contractP2SH, err := ltcutil.NewAddressScriptHash(contract, getChainParams(testnet))
+	contractP2SH, err := ltcutil.NewAddressScriptHash(contract, getChainParams(network))
 	if err != nil {
 		return nil, err
 	}
@@ -71,9 +71,11 @@ func buildContract(testnet bool, rpcclient *rpc.Client, args *contractArgs) (*bu
 	unsignedContract.AddTxOut(wire.NewTxOut(int64(args.amount), contractP2SHPkScript))
 	unsignedContract, contractFee, err := fundRawTransaction(rpcclient, unsignedContract, feePerKb)
 	if err != nil {
-		return nil, fmt.Errorf("fundrawtransaction: %v", err)
+		return nil, fundsError(rpcclient, args.amount, fmt.Errorf("fundrawtransaction: %v", err))
 	}
+	done := rpcclient.observe("signrawtransaction")
 	contractTx, complete, err := rpcclient.SignRawTransaction(unsignedContract)
+	done(err)
 	if err != nil {
 		return nil, fmt.Errorf("signrawtransaction: %v", err)
 	}
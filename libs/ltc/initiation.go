@@ -19,8 +19,8 @@ import (
 
 // initiate builds a contract & a contract transaction depending on the secret hash parameter
 // passed in
-func initiate(testnet bool, rpcinfo libs.RPCInfo, params libs.InitiateParams) (*libs.InitiateResult, error) {
-	chainParams := getChainParams(testnet)
+func initiate(network libs.Network, rpcinfo libs.RPCInfo, params libs.InitiateParams) (*libs.InitiateResult, error) {
+	chainParams := getChainParams(network)
 
 	cp2Addr, err := ltcutil.DecodeAddress(params.CP2Addr, chainParams)
 	if err != nil {
@@ -51,7 +51,7 @@ func initiate(testnet bool, rpcinfo libs.RPCInfo, params libs.InitiateParams) (*
 	locktime := time.Now().Add(48 * time.Hour).Unix()
 	// locktime := time.Now().Add(48 * time.Minute).Unix() //Test
 
-	rpcclient, err := startRPC(testnet, rpcinfo)
+	rpcclient, err := startRPC(network, rpcinfo)
 	if err != nil {
 		return nil, err
 	}
@@ -60,13 +60,15 @@ func initiate(testnet bool, rpcinfo libs.RPCInfo, params libs.InitiateParams) (*
 		rpcclient.WaitForShutdown()
 	}()
 
-	err = walletLock(rpcclient, rpcinfo.WalletPass, 1)
+	end := rpcclient.session.Begin()
+	defer end()
+	release, err := unlockWallet(rpcclient, rpcinfo.WalletPass)
 	if err != nil {
 		return nil, err
 	}
-	defer walletUnlock(rpcclient, rpcinfo.WalletPass)
+	defer release()
 
-	b, err := buildContract(testnet, rpcclient, &contractArgs{
+	b, err := buildContract(network, rpcclient, &contractArgs{
 		them:       cp2AddrP2PKH,
 		amount:     cp2Amount,
 		locktime:   locktime,
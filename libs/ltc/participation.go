@@ -20,8 +20,8 @@ import (
 // participate builds a contract & a contract transaction depending upon the hash of the
 // (shared) secret. The participant will know the secret only when initiator redeems the
 // contract made here
-func participate(testnet bool, rpcinfo libs.RPCInfo, params libs.ParticipateParams) (*libs.ParticipateResult, error) {
-	chainParams := getChainParams(testnet)
+func participate(network libs.Network, rpcinfo libs.RPCInfo, params libs.ParticipateParams) (*libs.ParticipateResult, error) {
+	chainParams := getChainParams(network)
 
 	cp1Addr, err := ltcutil.DecodeAddress(params.CP1Addr, chainParams)
 	if err != nil {
@@ -51,7 +51,7 @@ func participate(testnet bool, rpcinfo libs.RPCInfo, params libs.ParticipatePara
 	locktime := time.Now().Add(24 * time.Hour).Unix()
 	// locktime := time.Now().Add(24 * time.Minute).Unix() //Test
 
-	rpcclient, err := startRPC(testnet, rpcinfo)
+	rpcclient, err := startRPC(network, rpcinfo)
 	if err != nil {
 		return nil, err
 	}
@@ -60,13 +60,15 @@ func participate(testnet bool, rpcinfo libs.RPCInfo, params libs.ParticipatePara
 		rpcclient.WaitForShutdown()
 	}()
 
-	err = walletLock(rpcclient, rpcinfo.WalletPass, 1)
+	end := rpcclient.session.Begin()
+	defer end()
+	release, err := unlockWallet(rpcclient, rpcinfo.WalletPass)
 	if err != nil {
 		return nil, err
 	}
-	defer walletUnlock(rpcclient, rpcinfo.WalletPass)
+	defer release()
 
-	b, err := buildContract(testnet, rpcclient, &contractArgs{
+	b, err := buildContract(network, rpcclient, &contractArgs{
 		them:       cp1Address,
 		amount:     cp1Amount,
 		locktime:   locktime,
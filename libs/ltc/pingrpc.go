@@ -8,22 +8,23 @@ import (
 	"github.com/devwarrior777/atomicswap/libs"
 )
 
-// pingrpc tests if wallet node RPC is available
-func pingrpc(testnet bool, rpcinfo libs.RPCInfo) error {
-	rpcclient, err := startRPC(testnet, rpcinfo)
+// pingrpc tests if wallet node RPC is available and gets the chain, version
+// and capabilities of the node
+func pingrpc(network libs.Network, rpcinfo libs.RPCInfo) (*libs.PingResult, error) {
+	rpcclient, err := startRPC(network, rpcinfo)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() {
 		rpcclient.Shutdown()
 		rpcclient.WaitForShutdown()
 	}()
 
-	err = walletLock(rpcclient, rpcinfo.WalletPass, 1)
+	release, err := unlockWallet(rpcclient, rpcinfo.WalletPass)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	walletUnlock(rpcclient, rpcinfo.WalletPass)
+	release()
 
-	return nil
+	return getNodeInfo(rpcclient)
 }
@@ -20,8 +20,8 @@ import (
 
 // Build a transaction that can redeem the coins in the passed in contract using
 // the (shared) secret
-func redeem(testnet bool, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs.RedeemResult, error) {
-	chainParams := getChainParams(testnet)
+func redeem(network libs.Network, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs.RedeemResult, error) {
+	chainParams := getChainParams(network)
 
 	contract, err := hex.DecodeString(params.Contract)
 	if err != nil {
@@ -80,7 +80,7 @@ func redeem(testnet bool, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs
 		Index: uint32(contractOutIdx),
 	}
 
-	rpcclient, err := startRPC(testnet, rpcinfo)
+	rpcclient, err := startRPC(network, rpcinfo)
 	if err != nil {
 		return nil, err
 	}
@@ -89,11 +89,13 @@ func redeem(testnet bool, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs
 		rpcclient.WaitForShutdown()
 	}()
 
-	err = walletLock(rpcclient, rpcinfo.WalletPass, 1)
+	end := rpcclient.session.Begin()
+	defer end()
+	release, err := unlockWallet(rpcclient, rpcinfo.WalletPass)
 	if err != nil {
 		return nil, err
 	}
-	defer walletUnlock(rpcclient, rpcinfo.WalletPass)
+	defer release()
 
 	feePerKb, minFeePerKb, err := getFeePerKb(rpcclient)
 	if err != nil {
@@ -111,7 +113,7 @@ func redeem(testnet bool, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs
 		return nil, fmt.Errorf("redeem output value of %v is dust", ltcutil.Amount(redeemTx.TxOut[0].Value))
 	}
 
-	redeemSig, redeemPubKey, err := createSig(testnet, redeemTx, 0, contract, recipientAddr, rpcclient)
+	redeemSig, redeemPubKey, err := createSig(network, redeemTx, 0, contract, recipientAddr, rpcclient)
 	if err != nil {
 		return nil, err
 	}
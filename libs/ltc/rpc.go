@@ -9,6 +9,8 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/devwarrior777/atomicswap/libs"
 	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
@@ -20,8 +22,8 @@ import (
 
 // startRPC - starts a new RPC client for the network and address specified
 //            along with rpc user & rpc password, in RPCInfo
-func startRPC(testnet bool, rpcinfo libs.RPCInfo) (*rpc.Client, error) {
-	hostport, err := getNormalizedAddress(testnet, rpcinfo.HostPort)
+func startRPC(network libs.Network, rpcinfo libs.RPCInfo) (*rpcClient, error) {
+	hostport, err := getNormalizedAddress(network, rpcinfo.HostPort)
 	if err != nil {
 		return nil, fmt.Errorf("wallet server address: %v", err)
 	}
@@ -32,26 +34,60 @@ func startRPC(testnet bool, rpcinfo libs.RPCInfo) (*rpc.Client, error) {
 		DisableTLS:   true, // bitcoin-like coins abandoned SSL for RPC
 		HTTPPostMode: true,
 	}
-	client, err := rpc.New(connConfig, nil)
+	c, err := rpc.New(connConfig, nil)
 	if err != nil {
-		return client, fmt.Errorf("rpc connect: %v", err)
+		return nil, fmt.Errorf("rpc connect: %v", err)
 	}
-	return client, err
+	client := &rpcClient{
+		Client:   c,
+		observer: rpcinfo.Observer,
+		log:      rpcinfo.Log,
+		session:  libs.Session("ltc", network, hostport),
+	}
+	err = checkChain(network, client)
+	if err != nil {
+		stopRPC(client)
+		return nil, err
+	}
+	return client, nil
 }
 
 // stopRPC - Explicit stop when not using defer()
-func stopRPC(client *rpc.Client) {
+func stopRPC(client *rpcClient) {
 	client.Shutdown()
 	client.WaitForShutdown()
 }
 
+// rpcClient is a wallet RPC client that tells the RPCInfo observer of its calls
+// and logs to the RPCInfo log. Its session serializes the wallet-mutating
+// commands of the wallet
+type rpcClient struct {
+	*rpc.Client
+	observer libs.RPCObserver
+	log      *libs.Log
+	session  *libs.WalletSession
+}
+
+// RawRequest makes a JSON-RPC request
+func (c *rpcClient) RawRequest(method string, params []json.RawMessage) (json.RawMessage, error) {
+	done := c.observer.Observe(method)
+	result, err := c.Client.RawRequest(method, params)
+	done(err)
+	return result, err
+}
+
+// observe tells the observer of a call made with a typed client method
+func (c *rpcClient) observe(method string) func(err error) {
+	return c.observer.Observe(method)
+}
+
 ///////////////
 // RPC funcs //
 ///////////////
 
 // walletLock allows access to an encrypted wallet for 't' seconds
 // If 'p' == "" (empty string) we assume the wallet is not encrypted
-func walletLock(rpcclient *rpc.Client, p string, t int) error {
+func walletLock(rpcclient *rpcClient, p string, t int) error {
 	if len(p) == 0 {
 		return nil
 	}
@@ -73,16 +109,40 @@ func walletLock(rpcclient *rpc.Client, p string, t int) error {
 
 // Re-lock an unlocked (encrypted) wallet
 // If 'p' == "" (empty string) we assume the wallet is not encrypted
-func walletUnlock(rpcclient *rpc.Client, p string) {
+func walletUnlock(rpcclient *rpcClient, p string) {
 	if len(p) == 0 {
 		return
 	}
 	_, _ = rpcclient.RawRequest("walletlock", nil)
 }
 
+// unlockTimeout is the longest (seconds) an encrypted wallet is unlocked for
+// the commands of this process. The wallet is locked again as soon as the last
+// command needing it ends; the timeout only bounds a failed relock
+const unlockTimeout = 60
+
+// unlockWallet unlocks an encrypted wallet for a command and returns the func
+// ending the command's need of it. The wallet stays unlocked while any command
+// of the wallet session needs it and is locked when the last one ends
+// If 'p' == "" (empty string) we assume the wallet is not encrypted
+func unlockWallet(rpcclient *rpcClient, p string) (func(), error) {
+	if len(p) == 0 {
+		return func() {}, nil
+	}
+	err := rpcclient.session.Unlock(func() error {
+		return walletLock(rpcclient, p, unlockTimeout)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		rpcclient.session.Release(func() { walletUnlock(rpcclient, p) })
+	}, nil
+}
+
 // getBlockCount calls the getblockcount JSON-RPC method. It is
 // currently used as a simple 'ping' to discover if node RPC is available
-func getBlockCount(rpcclient *rpc.Client) (int, error) {
+func getBlockCount(rpcclient *rpcClient) (int, error) {
 	rawResp, err := rpcclient.RawRequest("getblockcount", nil)
 	if err != nil {
 		return -1, err
@@ -95,7 +155,93 @@ func getBlockCount(rpcclient *rpc.Client) (int, error) {
 	return blockCount, nil
 }
 
-func getTransaction(rpcclient *rpc.Client, txid string) (*libs.GetTxResult, error) {
+// checkChain checks the node is on the chain of the network with the
+// getblockchaininfo JSON-RPC method
+func checkChain(network libs.Network, rpcclient *rpcClient) error {
+	info, err := getBlockchainInfo(rpcclient)
+	if err != nil {
+		return fmt.Errorf("getblockchaininfo: %v", err)
+	}
+	return libs.CheckChain(network, info.Chain, libs.CoreChainName(network))
+}
+
+// blockchainInfo is the part of the getblockchaininfo result used
+type blockchainInfo struct {
+	Chain         string `json:"chain"`
+	Blocks        int64  `json:"blocks"`
+	BestBlockhash string `json:"bestblockhash"`
+	MedianTime    int64  `json:"mediantime"`
+}
+
+// getBlockchainInfo calls the getblockchaininfo JSON-RPC method
+func getBlockchainInfo(rpcclient *rpcClient) (*blockchainInfo, error) {
+	rawResp, err := rpcclient.RawRequest("getblockchaininfo", nil)
+	if err != nil {
+		return nil, err
+	}
+	var info blockchainInfo
+	err = json.Unmarshal(rawResp, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// getNodeInfo gets the chain, version and RPC capabilities of the node from
+// getblockchaininfo, getnetworkinfo and the help for the methods used
+func getNodeInfo(rpcclient *rpcClient) (*libs.PingResult, error) {
+	info, err := getBlockchainInfo(rpcclient)
+	if err != nil {
+		return nil, fmt.Errorf("getblockchaininfo: %v", err)
+	}
+	rawResp, err := rpcclient.RawRequest("getnetworkinfo", nil)
+	if err != nil {
+		return nil, fmt.Errorf("getnetworkinfo: %v", err)
+	}
+	var netInfo struct {
+		Version         int64  `json:"version"`
+		Subversion      string `json:"subversion"`
+		ProtocolVersion int64  `json:"protocolversion"`
+	}
+	err = json.Unmarshal(rawResp, &netInfo)
+	if err != nil {
+		return nil, fmt.Errorf("getnetworkinfo: %v", err)
+	}
+
+	result := &libs.PingResult{
+		Chain:           info.Chain,
+		Blocks:          info.Blocks,
+		Version:         strconv.FormatInt(netInfo.Version, 10),
+		UserAgent:       netInfo.Subversion,
+		ProtocolVersion: netInfo.ProtocolVersion,
+	}
+	fundHelp := getHelp(rpcclient, "fundrawtransaction")
+	result.EstimateSmartFee = getHelp(rpcclient, "estimatesmartfee") != ""
+	result.FundFeeRate = strings.Contains(fundHelp, "feeRate")
+	result.ChangeType = strings.Contains(fundHelp, "change_type")
+	return result, nil
+}
+
+// getHelp gets the help text of a JSON-RPC method. It is empty if the node
+// does not have the method
+func getHelp(rpcclient *rpcClient, method string) string {
+	param, err := json.Marshal(method)
+	if err != nil {
+		return ""
+	}
+	rawResp, err := rpcclient.RawRequest("help", []json.RawMessage{param})
+	if err != nil {
+		return ""
+	}
+	var help string
+	err = json.Unmarshal(rawResp, &help)
+	if err != nil || strings.HasPrefix(help, "help: unknown command") {
+		return ""
+	}
+	return help
+}
+
+func getTransaction(rpcclient *rpcClient, txid string) (*libs.GetTxResult, error) {
 	txidBytes, err := json.Marshal(txid)
 	if err != nil {
 		return nil, err
@@ -135,8 +281,8 @@ func getTransaction(rpcclient *rpc.Client, txid string) (*libs.GetTxResult, erro
 // getNewAddress calls the getnewaddress JSON-RPC method.  It is
 // implemented manually as the rpcclient implementation always passes the
 // account parameter which was removed in Bitcoin Core 0.15.
-func getNewAddress(testnet bool, rpcclient *rpc.Client) (ltcutil.Address, error) {
-	chainParams := getChainParams(testnet)
+func getNewAddress(network libs.Network, rpcclient *rpcClient) (ltcutil.Address, error) {
+	chainParams := getChainParams(network)
 	account, err := json.Marshal("") // Deprecated but necessary in this position
 	if err != nil {
 		return nil, err
@@ -173,8 +319,8 @@ func getNewAddress(testnet bool, rpcclient *rpc.Client) (ltcutil.Address, error)
 // getRawChangeAddress calls the getrawchangeaddress JSON-RPC method.  It is
 // implemented manually as the rpcclient implementation always passes the
 // account parameter which was removed in Litecoin Core 0.15.
-func getRawChangeAddress(testnet bool, rpcclient *rpc.Client) (ltcutil.Address, error) {
-	chainParams := getChainParams(testnet)
+func getRawChangeAddress(network libs.Network, rpcclient *rpcClient) (ltcutil.Address, error) {
+	chainParams := getChainParams(network)
 	params := []json.RawMessage{[]byte(`"legacy"`)}
 	rawResp, err := rpcclient.RawRequest("getrawchangeaddress", params)
 	if err != nil {
@@ -204,7 +350,7 @@ func getRawChangeAddress(testnet bool, rpcclient *rpc.Client) (ltcutil.Address,
 // the minimum mempool relay fee.  It first tries to get the user-set fee in the
 // wallet.  If unset, it attempts to find an estimate using estimatefee 6.  If
 // both of these fail, it falls back to mempool relay fee policy.
-func getFeePerKb(rpcclient *rpc.Client) (useFee, relayFee ltcutil.Amount, err error) {
+func getFeePerKb(rpcclient *rpcClient) (useFee, relayFee ltcutil.Amount, err error) {
 	var netInfoResp struct {
 		RelayFee float64 `json:"relayfee"`
 	}
@@ -264,14 +410,14 @@ func getFeePerKb(rpcclient *rpc.Client) (useFee, relayFee ltcutil.Amount, err er
 		return useFee, relayFee, err
 	}
 
-	fmt.Println("warning: falling back to mempool relay fee policy")
+	rpcclient.log.Warn("falling back to mempool relay fee policy")
 	return relayFee, relayFee, nil
 }
 
 // fundRawTransaction calls the fundrawtransaction JSON-RPC method.  It is
 // implemented manually as client support is currently missing from the
 // ltcd/rpcclient package.
-func fundRawTransaction(rpcclient *rpc.Client, tx *wire.MsgTx, feePerKb ltcutil.Amount) (fundedTx *wire.MsgTx, fee ltcutil.Amount, err error) {
+func fundRawTransaction(rpcclient *rpcClient, tx *wire.MsgTx, feePerKb ltcutil.Amount) (fundedTx *wire.MsgTx, fee ltcutil.Amount, err error) {
 	var buf bytes.Buffer
 	buf.Grow(tx.SerializeSize())
 	tx.Serialize(&buf)
@@ -319,14 +465,39 @@ func fundRawTransaction(rpcclient *rpc.Client, tx *wire.MsgTx, feePerKb ltcutil.
 	return fundedTx, feeAmount, nil
 }
 
+// fundsError makes a libs.FundsError of an insufficient funds error from
+// fundrawtransaction, with the wallet balance as the available amount
+func fundsError(rpcclient *rpcClient, required ltcutil.Amount, err error) error {
+	if !libs.IsInsufficientFunds(err) {
+		return err
+	}
+	fundsErr := &libs.FundsError{Required: int64(required), Available: -1, Err: err}
+	rawResp, err := rpcclient.RawRequest("getbalance", nil)
+	if err != nil {
+		return fundsErr
+	}
+	var balance float64
+	err = json.Unmarshal(rawResp, &balance)
+	if err != nil {
+		return fundsErr
+	}
+	available, err := ltcutil.NewAmount(balance)
+	if err == nil {
+		fundsErr.Available = int64(available)
+	}
+	return fundsErr
+}
+
 // createSig creates and returns the serialized raw signature and compressed
 // pubkey for a transaction input signature.  Due to limitations of the Litecoin
 // Core RPC API, this requires dumping a private key and signing in the client,
 // rather than letting the wallet sign.
-func createSig(testnet bool, tx *wire.MsgTx, idx int, pkScript []byte, addr ltcutil.Address,
-	rpcclient *rpc.Client) (sig, pubkey []byte, err error) {
+func createSig(network libs.Network, tx *wire.MsgTx, idx int, pkScript []byte, addr ltcutil.Address,
+	rpcclient *rpcClient) (sig, pubkey []byte, err error) {
 
+	done := rpcclient.observe("dumpprivkey")
 	wif, err := rpcclient.DumpPrivKey(addr)
+	done(err)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -337,8 +508,10 @@ func createSig(testnet bool, tx *wire.MsgTx, idx int, pkScript []byte, addr ltcu
 	return sig, wif.PrivKey.PubKey().SerializeCompressed(), nil
 }
 
-func sendRawTransaction(rpcclient *rpc.Client, tx *wire.MsgTx) (*chainhash.Hash, error) {
+func sendRawTransaction(rpcclient *rpcClient, tx *wire.MsgTx) (*chainhash.Hash, error) {
+	done := rpcclient.observe("sendrawtransaction")
 	txHash, err := rpcclient.SendRawTransaction(tx, false)
+	done(err)
 	if err != nil {
 		return nil, fmt.Errorf("sendrawtransaction: %v", err)
 	}
@@ -8,17 +8,18 @@ import (
 	"crypto/sha256"
 	"net"
 
+	"github.com/devwarrior777/atomicswap/libs"
 	"github.com/ltcsuite/ltcd/chaincfg"
 	"github.com/ltcsuite/ltcutil"
 )
 
 // Get a normalized address from `addr' which can be of form Host[:Port]`
-func getNormalizedAddress(testnet bool, addr string) (hostport string, err error) {
+func getNormalizedAddress(network libs.Network, addr string) (hostport string, err error) {
 	host, port, origErr := net.SplitHostPort(addr)
 	if origErr == nil {
 		return net.JoinHostPort(host, port), nil
 	}
-	defaultPort := getWalletPort(testnet)
+	defaultPort := getWalletPort(network)
 	addr = net.JoinHostPort(addr, defaultPort)
 	_, _, err = net.SplitHostPort(addr)
 	if err != nil {
@@ -27,18 +28,32 @@ func getNormalizedAddress(testnet bool, addr string) (hostport string, err error
 	return addr, nil
 }
 
+// networks are the networks the library supports
+var networks = []libs.Network{libs.Mainnet, libs.Testnet, libs.Regtest}
+
+// checkNetwork checks the network is one the library supports
+func checkNetwork(network libs.Network) error {
+	return libs.CheckNetwork(network, networks...)
+}
+
 // Get the default wallet port
-func getWalletPort(testnet bool) string {
-	if testnet {
+func getWalletPort(network libs.Network) string {
+	switch network {
+	case libs.Testnet:
 		return "19332"
+	case libs.Regtest:
+		return "19443"
 	}
 	return "9332"
 }
 
 // Get all of the chain parameters for a network
-func getChainParams(testnet bool) *chaincfg.Params {
-	if testnet {
+func getChainParams(network libs.Network) *chaincfg.Params {
+	switch network {
+	case libs.Testnet:
 		return &chaincfg.TestNet4Params
+	case libs.Regtest:
+		return &chaincfg.RegressionNetParams
 	}
 	return &chaincfg.MainNetParams
 }
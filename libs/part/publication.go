@@ -0,0 +1,44 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package part
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/particl/partsuite_partd/wire"
+)
+
+// Publish (broadcast) transaction to the network.
+func publish(network libs.Network, rpcinfo libs.RPCInfo, tx string) (string, error) {
+	txBytes, err := hex.DecodeString(tx)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode broadcast transaction bytes: %v", err)
+	}
+
+	var broadcastTx wire.MsgTx
+	err = broadcastTx.Deserialize(bytes.NewReader(txBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode broadcast transaction: %v", err)
+	}
+
+	rpcclient, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		rpcclient.Shutdown()
+		rpcclient.WaitForShutdown()
+	}()
+
+	txHash, err := sendRawTransaction(rpcclient, &broadcastTx)
+	if err != nil {
+		return "", err
+	}
+
+	return txHash.String(), nil
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package part
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/particl/partsuite_partd/chaincfg/chainhash"
+	"github.com/particl/partsuite_partd/txscript"
+	"github.com/particl/partsuite_partd/wire"
+	partutil "github.com/particl/partsuite_partutil"
+	"github.com/particl/partsuite_partwallet/wallet/txrules"
+)
+
+// Build a transaction that can refund the coins back to the contract creator
+func refund(network libs.Network, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs.RefundResult, error) {
+	chainParams := getChainParams(network)
+
+	contract, err := hex.DecodeString(params.Contract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract: %v", err)
+	}
+
+	contractTxBytes, err := hex.DecodeString(params.ContractTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract transaction: %v", err)
+	}
+	var contractTx wire.MsgTx
+	err = contractTx.Deserialize(bytes.NewReader(contractTxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract transaction: %v", err)
+	}
+
+	pushes, err := txscript.ExtractAtomicSwapDataPushes(0, contract)
+	if err != nil {
+		return nil, err
+	}
+	if pushes == nil {
+		return nil, errors.New("contract is not an atomic swap script recognized by this tool")
+	}
+
+	rpcclient, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		rpcclient.Shutdown()
+		rpcclient.WaitForShutdown()
+	}()
+
+	end := rpcclient.session.Begin()
+	defer end()
+	release, err := unlockWallet(rpcclient, rpcinfo.WalletPass)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	feePerKb, minFeePerKb, err := getFeePerKb(rpcclient)
+	if err != nil {
+		return nil, err
+	}
+
+	contractP2SH, err := partutil.NewAddressScriptHash(contract, chainParams)
+	if err != nil {
+		return nil, err
+	}
+	contractP2SHPkScript, err := txscript.PayToAddrScript(contractP2SH)
+	if err != nil {
+		return nil, err
+	}
+
+	contractTxHash := contractTx.TxHash()
+	contractOutPoint := wire.OutPoint{Hash: contractTxHash, Index: ^uint32(0)}
+	for i, o := range contractTx.TxOut {
+		if bytes.Equal(o.PkScript, contractP2SHPkScript) {
+			contractOutPoint.Index = uint32(i)
+			break
+		}
+	}
+	if contractOutPoint.Index == ^uint32(0) {
+		return nil, errors.New("contract tx does not contain a P2SH contract payment")
+	}
+
+	refundAddress, err := getRawChangeAddress(network, rpcclient)
+	if err != nil {
+		return nil, fmt.Errorf("getrawchangeaddress: %v", err)
+	}
+	refundOutScript, err := txscript.PayToAddrScript(refundAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	refundAddr, err := partutil.NewAddressPubKeyHash(pushes.RefundHash160[:], chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	refundTx := wire.NewMsgTx(txVersion)
+	refundTx.LockTime = uint32(pushes.LockTime)
+	txIn := wire.NewTxIn(&contractOutPoint, nil, nil)
+	txIn.Sequence = 0
+	refundTx.AddTxIn(txIn)
+	refundTx.AddTxOut(wire.NewTxOut(0, refundOutScript)) // amount set below
+	refundSize := estimateRefundSerializeSize(contract, refundTx)
+	refundFee := txrules.FeeForSerializeSize(feePerKb, refundSize)
+	refundTx.TxOut[0].Value = contractTx.TxOut[contractOutPoint.Index].Value - int64(refundFee)
+	if txrules.IsDustOutput(refundTx.TxOut[0], minFeePerKb) {
+		return nil, fmt.Errorf("refund output value of %v is dust", partutil.Amount(refundTx.TxOut[0].Value))
+	}
+
+	refundSig, refundPubKey, err := createSig(network, refundTx, 0, contractTx.TxOut[contractOutPoint.Index].Value,
+		contract, refundAddr, rpcclient)
+	if err != nil {
+		return nil, err
+	}
+	refundTx.TxIn[0].Witness = refundContractWitness(contract, refundSig, refundPubKey)
+
+	var refundBuf bytes.Buffer
+	refundBuf.Grow(refundTx.SerializeSize())
+	refundTx.Serialize(&refundBuf)
+	strRefundTx := hex.EncodeToString(refundBuf.Bytes())
+
+	var refundTxHash chainhash.Hash
+	refundTxHash = refundTx.TxHash()
+	strRefundTxHash := refundTxHash.String()
+
+	var result = &libs.RefundResult{}
+
+	result.RefundTx = strRefundTx
+	result.RefundTxHash = strRefundTxHash
+	result.RefundFee = int64(refundFee)
+	result.RefundFeePerKb = calcFeePerKb(refundFee, getVirtualSize(refundTx))
+
+	return result, nil
+}
+
+// refundContractWitness returns the witness to refund a contract output using
+// the contract author's signature after the locktime has been reached.  The
+// contract is the final witness item.
+func refundContractWitness(contract, sig, pubkey []byte) wire.TxWitness {
+	b0 := []byte{byte(txscript.OP_0)}
+	return wire.TxWitness{sig, pubkey, b0, contract}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2016 The btcsuite developers
+// Copyright (c) 2017/2019 The Decred developers
+// Copyright (c) 2017 The Particl Core developers
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package part
+
+import (
+	"github.com/particl/partsuite_partd/wire"
+)
+
+// Worst case witness size estimates.
+const (
+	// redeemAtomicSwapWitnessSize is the worst case (largest) serialize size
+	// of the witness to redeem the atomic swap contract.  This does not
+	// include the contract itself.
+	//
+	//   - 6 bytes varint stack height & item lengths
+	//   - 72 bytes DER signature + 1 byte sighash
+	//   - 33 bytes serialized compressed pubkey
+	//   - 32 bytes secret
+	//   - OP_TRUE
+	redeemAtomicSwapWitnessSize = 6 + 73 + 33 + 32 + 1
+
+	// refundAtomicSwapWitnessSize is the worst case (largest) serialize size
+	// of the witness that refunds an atomic swap output.  This does not
+	// include the contract itself.
+	//
+	//   - 5 bytes varint stack height & item lengths
+	//   - 72 bytes DER signature + 1 byte sighash
+	//   - 33 bytes serialized compressed pubkey
+	//   - OP_FALSE
+	refundAtomicSwapWitnessSize = 5 + 73 + 33 + 1
+)
+
+// witnessScaleFactor is the Particl discount applied to witness data when
+// calculating the virtual size of a transaction
+const witnessScaleFactor = 2
+
+// calcVirtualSize returns the virtual size of a transaction given its
+// serialized size without and with the witness data
+func calcVirtualSize(sizeNoWitness int, sizeTotal int) int {
+	return ((sizeNoWitness*(witnessScaleFactor-1) + sizeTotal) + witnessScaleFactor - 1) / witnessScaleFactor
+}
+
+// getVirtualSize returns the virtual size of a transaction. Particl fees are
+// paid on virtual size
+func getVirtualSize(tx *wire.MsgTx) int {
+	return calcVirtualSize(tx.SerializeSizeStripped(), tx.SerializeSize())
+}
+
+// estimateRedeemSerializeSize returns a worst case virtual size estimate for
+// a transaction that redeems an atomic swap output.  The transaction passed
+// in must have its input and outputs set but no witness.
+func estimateRedeemSerializeSize(contract []byte, tx *wire.MsgTx) int {
+	baseSize := tx.SerializeSize()
+	witnessSize := redeemAtomicSwapWitnessSize + len(contract)
+	return calcVirtualSize(baseSize, baseSize+witnessSize)
+}
+
+// estimateRefundSerializeSize returns a worst case virtual size estimate for
+// a transaction that refunds an atomic swap output.  The transaction passed
+// in must have its input and outputs set but no witness.
+func estimateRefundSerializeSize(contract []byte, tx *wire.MsgTx) int {
+	baseSize := tx.SerializeSize()
+	witnessSize := refundAtomicSwapWitnessSize + len(contract)
+	return calcVirtualSize(baseSize, baseSize+witnessSize)
+}
@@ -7,6 +7,8 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
 	math "math"
 )
@@ -28,24 +30,48 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type COIN int32
 
 const (
-	COIN_BTC COIN = 0
-	COIN_LTC COIN = 1
-	COIN_XZC COIN = 2
-	COIN_DCR COIN = 3
+	COIN_BTC  COIN = 0
+	COIN_LTC  COIN = 1
+	COIN_XZC  COIN = 2
+	COIN_DCR  COIN = 3
+	COIN_BCH  COIN = 4
+	COIN_PART COIN = 5
+	COIN_QTUM COIN = 6
+	COIN_UTXO COIN = 7
+	COIN_DOGE COIN = 8
+	COIN_DASH COIN = 9
+	COIN_ZEC  COIN = 10
+	COIN_ETH  COIN = 11
 )
 
 var COIN_name = map[int32]string{
-	0: "BTC",
-	1: "LTC",
-	2: "XZC",
-	3: "DCR",
+	0:  "BTC",
+	1:  "LTC",
+	2:  "XZC",
+	3:  "DCR",
+	4:  "BCH",
+	5:  "PART",
+	6:  "QTUM",
+	7:  "UTXO",
+	8:  "DOGE",
+	9:  "DASH",
+	10: "ZEC",
+	11: "ETH",
 }
 
 var COIN_value = map[string]int32{
-	"BTC": 0,
-	"LTC": 1,
-	"XZC": 2,
-	"DCR": 3,
+	"BTC":  0,
+	"LTC":  1,
+	"XZC":  2,
+	"DCR":  3,
+	"BCH":  4,
+	"PART": 5,
+	"QTUM": 6,
+	"UTXO": 7,
+	"DOGE": 8,
+	"DASH": 9,
+	"ZEC":  10,
+	"ETH":  11,
 }
 
 func (x COIN) String() string {
@@ -84,14 +110,91 @@ func (ERRNO) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_9afe1911bb3b5204, []int{1}
 }
 
+// Not every coin has every network
+type NETWORK int32
+
+const (
+	NETWORK_MAINNET NETWORK = 0
+	NETWORK_TESTNET NETWORK = 1
+	NETWORK_REGTEST NETWORK = 2
+	NETWORK_SIMNET  NETWORK = 3
+	NETWORK_SIGNET  NETWORK = 4
+)
+
+var NETWORK_name = map[int32]string{
+	0: "MAINNET",
+	1: "TESTNET",
+	2: "REGTEST",
+	3: "SIMNET",
+	4: "SIGNET",
+}
+
+var NETWORK_value = map[string]int32{
+	"MAINNET": 0,
+	"TESTNET": 1,
+	"REGTEST": 2,
+	"SIMNET":  3,
+	"SIGNET":  4,
+}
+
+func (x NETWORK) String() string {
+	return proto.EnumName(NETWORK_name, int32(x))
+}
+
+func (NETWORK) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9afe1911bb3b5204, []int{2}
+}
+
+// Swap events streamed by WatchSwap and SubscribeEvents
+type SWAP_EVENT int32
+
+const (
+	SWAP_EVENT_MEMPOOL       SWAP_EVENT = 0
+	SWAP_EVENT_CONFIRMATIONS SWAP_EVENT = 1
+	SWAP_EVENT_REDEEMED      SWAP_EVENT = 2
+	SWAP_EVENT_REFUNDED      SWAP_EVENT = 3
+	SWAP_EVENT_LOCKTIME      SWAP_EVENT = 4
+	SWAP_EVENT_REORG         SWAP_EVENT = 5
+)
+
+var SWAP_EVENT_name = map[int32]string{
+	0: "MEMPOOL",
+	1: "CONFIRMATIONS",
+	2: "REDEEMED",
+	3: "REFUNDED",
+	4: "LOCKTIME",
+	5: "REORG",
+}
+
+var SWAP_EVENT_value = map[string]int32{
+	"MEMPOOL":       0,
+	"CONFIRMATIONS": 1,
+	"REDEEMED":      2,
+	"REFUNDED":      3,
+	"LOCKTIME":      4,
+	"REORG":         5,
+}
+
+func (x SWAP_EVENT) String() string {
+	return proto.EnumName(SWAP_EVENT_name, int32(x))
+}
+
+func (SWAP_EVENT) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_9afe1911bb3b5204, []int{3}
+}
+
 type PingWalletRPCRequest struct {
 	Coin                 COIN     `protobuf:"varint,1,opt,name=coin,proto3,enum=protobind.COIN" json:"coin,omitempty"`
 	Testnet              bool     `protobuf:"varint,2,opt,name=testnet,proto3" json:"testnet,omitempty"`
+	Network              NETWORK  `protobuf:"varint,16,opt,name=network,proto3,enum=protobind.NETWORK" json:"network,omitempty"`
+	CoinDef              string   `protobuf:"bytes,3,opt,name=coin_def,json=coinDef,proto3" json:"coin_def,omitempty"`
+	Jsonrpc              bool     `protobuf:"varint,4,opt,name=jsonrpc,proto3" json:"jsonrpc,omitempty"`
 	Hostport             string   `protobuf:"bytes,5,opt,name=hostport,proto3" json:"hostport,omitempty"`
 	Rpcuser              string   `protobuf:"bytes,6,opt,name=rpcuser,proto3" json:"rpcuser,omitempty"`
 	Rpcpass              string   `protobuf:"bytes,7,opt,name=rpcpass,proto3" json:"rpcpass,omitempty"`
 	Wpass                string   `protobuf:"bytes,8,opt,name=wpass,proto3" json:"wpass,omitempty"`
 	Certs                string   `protobuf:"bytes,9,opt,name=certs,proto3" json:"certs,omitempty"`
+	Profile              string   `protobuf:"bytes,17,opt,name=profile,proto3" json:"profile,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -136,6 +239,27 @@ func (m *PingWalletRPCRequest) GetTestnet() bool {
 	return false
 }
 
+func (m *PingWalletRPCRequest) GetNetwork() NETWORK {
+	if m != nil {
+		return m.Network
+	}
+	return NETWORK_MAINNET
+}
+
+func (m *PingWalletRPCRequest) GetCoinDef() string {
+	if m != nil {
+		return m.CoinDef
+	}
+	return ""
+}
+
+func (m *PingWalletRPCRequest) GetJsonrpc() bool {
+	if m != nil {
+		return m.Jsonrpc
+	}
+	return false
+}
+
 func (m *PingWalletRPCRequest) GetHostport() string {
 	if m != nil {
 		return m.Hostport
@@ -171,12 +295,25 @@ func (m *PingWalletRPCRequest) GetCerts() string {
 	return ""
 }
 
+func (m *PingWalletRPCRequest) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
 type PingWalletRPCResponse struct {
-	Errorno              ERRNO    `protobuf:"varint,14,opt,name=errorno,proto3,enum=protobind.ERRNO" json:"errorno,omitempty"`
-	Errstr               string   `protobuf:"bytes,15,opt,name=errstr,proto3" json:"errstr,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Chain                string        `protobuf:"bytes,5,opt,name=chain,proto3" json:"chain,omitempty"`
+	Blocks               int64         `protobuf:"varint,6,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	Version              string        `protobuf:"bytes,7,opt,name=version,proto3" json:"version,omitempty"`
+	UserAgent            string        `protobuf:"bytes,8,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	ProtocolVersion      int64         `protobuf:"varint,9,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Capabilities         *Capabilities `protobuf:"bytes,10,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	Errorno              ERRNO         `protobuf:"varint,14,opt,name=errorno,proto3,enum=protobind.ERRNO" json:"errorno,omitempty"`
+	Errstr               string        `protobuf:"bytes,15,opt,name=errstr,proto3" json:"errstr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *PingWalletRPCResponse) Reset()         { *m = PingWalletRPCResponse{} }
@@ -204,6 +341,48 @@ func (m *PingWalletRPCResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PingWalletRPCResponse proto.InternalMessageInfo
 
+func (m *PingWalletRPCResponse) GetChain() string {
+	if m != nil {
+		return m.Chain
+	}
+	return ""
+}
+
+func (m *PingWalletRPCResponse) GetBlocks() int64 {
+	if m != nil {
+		return m.Blocks
+	}
+	return 0
+}
+
+func (m *PingWalletRPCResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *PingWalletRPCResponse) GetUserAgent() string {
+	if m != nil {
+		return m.UserAgent
+	}
+	return ""
+}
+
+func (m *PingWalletRPCResponse) GetProtocolVersion() int64 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *PingWalletRPCResponse) GetCapabilities() *Capabilities {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
 func (m *PingWalletRPCResponse) GetErrorno() ERRNO {
 	if m != nil {
 		return m.Errorno
@@ -218,14 +397,82 @@ func (m *PingWalletRPCResponse) GetErrstr() string {
 	return ""
 }
 
+// Node RPC features the swap commands use when available
+type Capabilities struct {
+	Estimatesmartfee     bool     `protobuf:"varint,1,opt,name=estimatesmartfee,proto3" json:"estimatesmartfee,omitempty"`
+	FundFeeRate          bool     `protobuf:"varint,2,opt,name=fund_fee_rate,json=fundFeeRate,proto3" json:"fund_fee_rate,omitempty"`
+	ChangeType           bool     `protobuf:"varint,3,opt,name=change_type,json=changeType,proto3" json:"change_type,omitempty"`
+	DynamicFees          bool     `protobuf:"varint,4,opt,name=dynamic_fees,json=dynamicFees,proto3" json:"dynamic_fees,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Capabilities) Reset()         { *m = Capabilities{} }
+func (m *Capabilities) String() string { return proto.CompactTextString(m) }
+func (*Capabilities) ProtoMessage()    {}
+func (*Capabilities) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9afe1911bb3b5204, []int{2}
+}
+
+func (m *Capabilities) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Capabilities.Unmarshal(m, b)
+}
+func (m *Capabilities) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Capabilities.Marshal(b, m, deterministic)
+}
+func (m *Capabilities) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Capabilities.Merge(m, src)
+}
+func (m *Capabilities) XXX_Size() int {
+	return xxx_messageInfo_Capabilities.Size(m)
+}
+func (m *Capabilities) XXX_DiscardUnknown() {
+	xxx_messageInfo_Capabilities.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Capabilities proto.InternalMessageInfo
+
+func (m *Capabilities) GetEstimatesmartfee() bool {
+	if m != nil {
+		return m.Estimatesmartfee
+	}
+	return false
+}
+
+func (m *Capabilities) GetFundFeeRate() bool {
+	if m != nil {
+		return m.FundFeeRate
+	}
+	return false
+}
+
+func (m *Capabilities) GetChangeType() bool {
+	if m != nil {
+		return m.ChangeType
+	}
+	return false
+}
+
+func (m *Capabilities) GetDynamicFees() bool {
+	if m != nil {
+		return m.DynamicFees
+	}
+	return false
+}
+
 type NewAddressRequest struct {
 	Coin                 COIN     `protobuf:"varint,1,opt,name=coin,proto3,enum=protobind.COIN" json:"coin,omitempty"`
 	Testnet              bool     `protobuf:"varint,2,opt,name=testnet,proto3" json:"testnet,omitempty"`
+	Network              NETWORK  `protobuf:"varint,16,opt,name=network,proto3,enum=protobind.NETWORK" json:"network,omitempty"`
+	CoinDef              string   `protobuf:"bytes,3,opt,name=coin_def,json=coinDef,proto3" json:"coin_def,omitempty"`
+	Jsonrpc              bool     `protobuf:"varint,4,opt,name=jsonrpc,proto3" json:"jsonrpc,omitempty"`
 	Hostport             string   `protobuf:"bytes,5,opt,name=hostport,proto3" json:"hostport,omitempty"`
 	Rpcuser              string   `protobuf:"bytes,6,opt,name=rpcuser,proto3" json:"rpcuser,omitempty"`
 	Rpcpass              string   `protobuf:"bytes,7,opt,name=rpcpass,proto3" json:"rpcpass,omitempty"`
 	Wpass                string   `protobuf:"bytes,8,opt,name=wpass,proto3" json:"wpass,omitempty"`
 	Certs                string   `protobuf:"bytes,9,opt,name=certs,proto3" json:"certs,omitempty"`
+	Profile              string   `protobuf:"bytes,17,opt,name=profile,proto3" json:"profile,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -235,7 +482,7 @@ func (m *NewAddressRequest) Reset()         { *m = NewAddressRequest{} }
 func (m *NewAddressRequest) String() string { return proto.CompactTextString(m) }
 func (*NewAddressRequest) ProtoMessage()    {}
 func (*NewAddressRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{2}
+	return fileDescriptor_9afe1911bb3b5204, []int{3}
 }
 
 func (m *NewAddressRequest) XXX_Unmarshal(b []byte) error {
@@ -270,6 +517,27 @@ func (m *NewAddressRequest) GetTestnet() bool {
 	return false
 }
 
+func (m *NewAddressRequest) GetNetwork() NETWORK {
+	if m != nil {
+		return m.Network
+	}
+	return NETWORK_MAINNET
+}
+
+func (m *NewAddressRequest) GetCoinDef() string {
+	if m != nil {
+		return m.CoinDef
+	}
+	return ""
+}
+
+func (m *NewAddressRequest) GetJsonrpc() bool {
+	if m != nil {
+		return m.Jsonrpc
+	}
+	return false
+}
+
 func (m *NewAddressRequest) GetHostport() string {
 	if m != nil {
 		return m.Hostport
@@ -305,6 +573,13 @@ func (m *NewAddressRequest) GetCerts() string {
 	return ""
 }
 
+func (m *NewAddressRequest) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
 type NewAddressResponse struct {
 	Address              string   `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
 	Errorno              ERRNO    `protobuf:"varint,14,opt,name=errorno,proto3,enum=protobind.ERRNO" json:"errorno,omitempty"`
@@ -318,7 +593,7 @@ func (m *NewAddressResponse) Reset()         { *m = NewAddressResponse{} }
 func (m *NewAddressResponse) String() string { return proto.CompactTextString(m) }
 func (*NewAddressResponse) ProtoMessage()    {}
 func (*NewAddressResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{3}
+	return fileDescriptor_9afe1911bb3b5204, []int{4}
 }
 
 func (m *NewAddressResponse) XXX_Unmarshal(b []byte) error {
@@ -361,16 +636,24 @@ func (m *NewAddressResponse) GetErrstr() string {
 }
 
 type InitiateRequest struct {
-	Coin                 COIN     `protobuf:"varint,1,opt,name=coin,proto3,enum=protobind.COIN" json:"coin,omitempty"`
-	Testnet              bool     `protobuf:"varint,2,opt,name=testnet,proto3" json:"testnet,omitempty"`
-	Hostport             string   `protobuf:"bytes,5,opt,name=hostport,proto3" json:"hostport,omitempty"`
-	Rpcuser              string   `protobuf:"bytes,6,opt,name=rpcuser,proto3" json:"rpcuser,omitempty"`
-	Rpcpass              string   `protobuf:"bytes,7,opt,name=rpcpass,proto3" json:"rpcpass,omitempty"`
-	Wpass                string   `protobuf:"bytes,8,opt,name=wpass,proto3" json:"wpass,omitempty"`
-	Certs                string   `protobuf:"bytes,9,opt,name=certs,proto3" json:"certs,omitempty"`
-	Secrethash           string   `protobuf:"bytes,10,opt,name=secrethash,proto3" json:"secrethash,omitempty"`
-	PartAddress          string   `protobuf:"bytes,11,opt,name=part_address,json=partAddress,proto3" json:"part_address,omitempty"`
-	Amount               int64    `protobuf:"varint,12,opt,name=amount,proto3" json:"amount,omitempty"`
+	Coin        COIN    `protobuf:"varint,1,opt,name=coin,proto3,enum=protobind.COIN" json:"coin,omitempty"`
+	Testnet     bool    `protobuf:"varint,2,opt,name=testnet,proto3" json:"testnet,omitempty"`
+	Network     NETWORK `protobuf:"varint,16,opt,name=network,proto3,enum=protobind.NETWORK" json:"network,omitempty"`
+	CoinDef     string  `protobuf:"bytes,3,opt,name=coin_def,json=coinDef,proto3" json:"coin_def,omitempty"`
+	Jsonrpc     bool    `protobuf:"varint,4,opt,name=jsonrpc,proto3" json:"jsonrpc,omitempty"`
+	Hostport    string  `protobuf:"bytes,5,opt,name=hostport,proto3" json:"hostport,omitempty"`
+	Rpcuser     string  `protobuf:"bytes,6,opt,name=rpcuser,proto3" json:"rpcuser,omitempty"`
+	Rpcpass     string  `protobuf:"bytes,7,opt,name=rpcpass,proto3" json:"rpcpass,omitempty"`
+	Wpass       string  `protobuf:"bytes,8,opt,name=wpass,proto3" json:"wpass,omitempty"`
+	Certs       string  `protobuf:"bytes,9,opt,name=certs,proto3" json:"certs,omitempty"`
+	Profile     string  `protobuf:"bytes,17,opt,name=profile,proto3" json:"profile,omitempty"`
+	Secrethash  string  `protobuf:"bytes,10,opt,name=secrethash,proto3" json:"secrethash,omitempty"`
+	PartAddress string  `protobuf:"bytes,11,opt,name=part_address,json=partAddress,proto3" json:"part_address,omitempty"`
+	Amount      int64   `protobuf:"varint,12,opt,name=amount,proto3" json:"amount,omitempty"`
+	// dcr wallet options
+	Account              string   `protobuf:"bytes,20,opt,name=account,proto3" json:"account,omitempty"`
+	RequiredConfs        int32    `protobuf:"varint,21,opt,name=required_confs,json=requiredConfs,proto3" json:"required_confs,omitempty"`
+	FeePerKb             int64    `protobuf:"varint,22,opt,name=fee_per_kb,json=feePerKb,proto3" json:"fee_per_kb,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -380,7 +663,7 @@ func (m *InitiateRequest) Reset()         { *m = InitiateRequest{} }
 func (m *InitiateRequest) String() string { return proto.CompactTextString(m) }
 func (*InitiateRequest) ProtoMessage()    {}
 func (*InitiateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{4}
+	return fileDescriptor_9afe1911bb3b5204, []int{5}
 }
 
 func (m *InitiateRequest) XXX_Unmarshal(b []byte) error {
@@ -415,6 +698,27 @@ func (m *InitiateRequest) GetTestnet() bool {
 	return false
 }
 
+func (m *InitiateRequest) GetNetwork() NETWORK {
+	if m != nil {
+		return m.Network
+	}
+	return NETWORK_MAINNET
+}
+
+func (m *InitiateRequest) GetCoinDef() string {
+	if m != nil {
+		return m.CoinDef
+	}
+	return ""
+}
+
+func (m *InitiateRequest) GetJsonrpc() bool {
+	if m != nil {
+		return m.Jsonrpc
+	}
+	return false
+}
+
 func (m *InitiateRequest) GetHostport() string {
 	if m != nil {
 		return m.Hostport
@@ -450,6 +754,13 @@ func (m *InitiateRequest) GetCerts() string {
 	return ""
 }
 
+func (m *InitiateRequest) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
 func (m *InitiateRequest) GetSecrethash() string {
 	if m != nil {
 		return m.Secrethash
@@ -471,6 +782,27 @@ func (m *InitiateRequest) GetAmount() int64 {
 	return 0
 }
 
+func (m *InitiateRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *InitiateRequest) GetRequiredConfs() int32 {
+	if m != nil {
+		return m.RequiredConfs
+	}
+	return 0
+}
+
+func (m *InitiateRequest) GetFeePerKb() int64 {
+	if m != nil {
+		return m.FeePerKb
+	}
+	return 0
+}
+
 type InitiateResponse struct {
 	Contract             string   `protobuf:"bytes,5,opt,name=contract,proto3" json:"contract,omitempty"`
 	ContractP2Sh         string   `protobuf:"bytes,6,opt,name=contract_p2sh,json=contractP2sh,proto3" json:"contract_p2sh,omitempty"`
@@ -490,7 +822,7 @@ func (m *InitiateResponse) Reset()         { *m = InitiateResponse{} }
 func (m *InitiateResponse) String() string { return proto.CompactTextString(m) }
 func (*InitiateResponse) ProtoMessage()    {}
 func (*InitiateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{5}
+	return fileDescriptor_9afe1911bb3b5204, []int{6}
 }
 
 func (m *InitiateResponse) XXX_Unmarshal(b []byte) error {
@@ -575,16 +907,24 @@ func (m *InitiateResponse) GetErrstr() string {
 }
 
 type ParticipateRequest struct {
-	Coin                 COIN     `protobuf:"varint,1,opt,name=coin,proto3,enum=protobind.COIN" json:"coin,omitempty"`
-	Testnet              bool     `protobuf:"varint,2,opt,name=testnet,proto3" json:"testnet,omitempty"`
-	Hostport             string   `protobuf:"bytes,5,opt,name=hostport,proto3" json:"hostport,omitempty"`
-	Rpcuser              string   `protobuf:"bytes,6,opt,name=rpcuser,proto3" json:"rpcuser,omitempty"`
-	Rpcpass              string   `protobuf:"bytes,7,opt,name=rpcpass,proto3" json:"rpcpass,omitempty"`
-	Wpass                string   `protobuf:"bytes,8,opt,name=wpass,proto3" json:"wpass,omitempty"`
-	Certs                string   `protobuf:"bytes,9,opt,name=certs,proto3" json:"certs,omitempty"`
-	Secrethash           string   `protobuf:"bytes,10,opt,name=secrethash,proto3" json:"secrethash,omitempty"`
-	InitAddress          string   `protobuf:"bytes,11,opt,name=init_address,json=initAddress,proto3" json:"init_address,omitempty"`
-	Amount               int64    `protobuf:"varint,12,opt,name=amount,proto3" json:"amount,omitempty"`
+	Coin        COIN    `protobuf:"varint,1,opt,name=coin,proto3,enum=protobind.COIN" json:"coin,omitempty"`
+	Testnet     bool    `protobuf:"varint,2,opt,name=testnet,proto3" json:"testnet,omitempty"`
+	Network     NETWORK `protobuf:"varint,16,opt,name=network,proto3,enum=protobind.NETWORK" json:"network,omitempty"`
+	CoinDef     string  `protobuf:"bytes,3,opt,name=coin_def,json=coinDef,proto3" json:"coin_def,omitempty"`
+	Jsonrpc     bool    `protobuf:"varint,4,opt,name=jsonrpc,proto3" json:"jsonrpc,omitempty"`
+	Hostport    string  `protobuf:"bytes,5,opt,name=hostport,proto3" json:"hostport,omitempty"`
+	Rpcuser     string  `protobuf:"bytes,6,opt,name=rpcuser,proto3" json:"rpcuser,omitempty"`
+	Rpcpass     string  `protobuf:"bytes,7,opt,name=rpcpass,proto3" json:"rpcpass,omitempty"`
+	Wpass       string  `protobuf:"bytes,8,opt,name=wpass,proto3" json:"wpass,omitempty"`
+	Certs       string  `protobuf:"bytes,9,opt,name=certs,proto3" json:"certs,omitempty"`
+	Profile     string  `protobuf:"bytes,17,opt,name=profile,proto3" json:"profile,omitempty"`
+	Secrethash  string  `protobuf:"bytes,10,opt,name=secrethash,proto3" json:"secrethash,omitempty"`
+	InitAddress string  `protobuf:"bytes,11,opt,name=init_address,json=initAddress,proto3" json:"init_address,omitempty"`
+	Amount      int64   `protobuf:"varint,12,opt,name=amount,proto3" json:"amount,omitempty"`
+	// dcr wallet options
+	Account              string   `protobuf:"bytes,20,opt,name=account,proto3" json:"account,omitempty"`
+	RequiredConfs        int32    `protobuf:"varint,21,opt,name=required_confs,json=requiredConfs,proto3" json:"required_confs,omitempty"`
+	FeePerKb             int64    `protobuf:"varint,22,opt,name=fee_per_kb,json=feePerKb,proto3" json:"fee_per_kb,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -594,7 +934,7 @@ func (m *ParticipateRequest) Reset()         { *m = ParticipateRequest{} }
 func (m *ParticipateRequest) String() string { return proto.CompactTextString(m) }
 func (*ParticipateRequest) ProtoMessage()    {}
 func (*ParticipateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{6}
+	return fileDescriptor_9afe1911bb3b5204, []int{7}
 }
 
 func (m *ParticipateRequest) XXX_Unmarshal(b []byte) error {
@@ -629,6 +969,27 @@ func (m *ParticipateRequest) GetTestnet() bool {
 	return false
 }
 
+func (m *ParticipateRequest) GetNetwork() NETWORK {
+	if m != nil {
+		return m.Network
+	}
+	return NETWORK_MAINNET
+}
+
+func (m *ParticipateRequest) GetCoinDef() string {
+	if m != nil {
+		return m.CoinDef
+	}
+	return ""
+}
+
+func (m *ParticipateRequest) GetJsonrpc() bool {
+	if m != nil {
+		return m.Jsonrpc
+	}
+	return false
+}
+
 func (m *ParticipateRequest) GetHostport() string {
 	if m != nil {
 		return m.Hostport
@@ -664,6 +1025,13 @@ func (m *ParticipateRequest) GetCerts() string {
 	return ""
 }
 
+func (m *ParticipateRequest) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
 func (m *ParticipateRequest) GetSecrethash() string {
 	if m != nil {
 		return m.Secrethash
@@ -685,6 +1053,27 @@ func (m *ParticipateRequest) GetAmount() int64 {
 	return 0
 }
 
+func (m *ParticipateRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *ParticipateRequest) GetRequiredConfs() int32 {
+	if m != nil {
+		return m.RequiredConfs
+	}
+	return 0
+}
+
+func (m *ParticipateRequest) GetFeePerKb() int64 {
+	if m != nil {
+		return m.FeePerKb
+	}
+	return 0
+}
+
 type ParticipateResponse struct {
 	Contract             string   `protobuf:"bytes,5,opt,name=contract,proto3" json:"contract,omitempty"`
 	ContractP2Sh         string   `protobuf:"bytes,6,opt,name=contract_p2sh,json=contractP2sh,proto3" json:"contract_p2sh,omitempty"`
@@ -704,7 +1093,7 @@ func (m *ParticipateResponse) Reset()         { *m = ParticipateResponse{} }
 func (m *ParticipateResponse) String() string { return proto.CompactTextString(m) }
 func (*ParticipateResponse) ProtoMessage()    {}
 func (*ParticipateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{7}
+	return fileDescriptor_9afe1911bb3b5204, []int{8}
 }
 
 func (m *ParticipateResponse) XXX_Unmarshal(b []byte) error {
@@ -789,16 +1178,23 @@ func (m *ParticipateResponse) GetErrstr() string {
 }
 
 type RedeemRequest struct {
-	Coin                 COIN     `protobuf:"varint,1,opt,name=coin,proto3,enum=protobind.COIN" json:"coin,omitempty"`
-	Testnet              bool     `protobuf:"varint,2,opt,name=testnet,proto3" json:"testnet,omitempty"`
-	Hostport             string   `protobuf:"bytes,5,opt,name=hostport,proto3" json:"hostport,omitempty"`
-	Rpcuser              string   `protobuf:"bytes,6,opt,name=rpcuser,proto3" json:"rpcuser,omitempty"`
-	Rpcpass              string   `protobuf:"bytes,7,opt,name=rpcpass,proto3" json:"rpcpass,omitempty"`
-	Wpass                string   `protobuf:"bytes,8,opt,name=wpass,proto3" json:"wpass,omitempty"`
-	Certs                string   `protobuf:"bytes,9,opt,name=certs,proto3" json:"certs,omitempty"`
-	Secret               string   `protobuf:"bytes,10,opt,name=secret,proto3" json:"secret,omitempty"`
-	Contract             string   `protobuf:"bytes,11,opt,name=contract,proto3" json:"contract,omitempty"`
-	ContractTx           string   `protobuf:"bytes,12,opt,name=contract_tx,json=contractTx,proto3" json:"contract_tx,omitempty"`
+	Coin       COIN    `protobuf:"varint,1,opt,name=coin,proto3,enum=protobind.COIN" json:"coin,omitempty"`
+	Testnet    bool    `protobuf:"varint,2,opt,name=testnet,proto3" json:"testnet,omitempty"`
+	Network    NETWORK `protobuf:"varint,16,opt,name=network,proto3,enum=protobind.NETWORK" json:"network,omitempty"`
+	CoinDef    string  `protobuf:"bytes,3,opt,name=coin_def,json=coinDef,proto3" json:"coin_def,omitempty"`
+	Jsonrpc    bool    `protobuf:"varint,4,opt,name=jsonrpc,proto3" json:"jsonrpc,omitempty"`
+	Hostport   string  `protobuf:"bytes,5,opt,name=hostport,proto3" json:"hostport,omitempty"`
+	Rpcuser    string  `protobuf:"bytes,6,opt,name=rpcuser,proto3" json:"rpcuser,omitempty"`
+	Rpcpass    string  `protobuf:"bytes,7,opt,name=rpcpass,proto3" json:"rpcpass,omitempty"`
+	Wpass      string  `protobuf:"bytes,8,opt,name=wpass,proto3" json:"wpass,omitempty"`
+	Certs      string  `protobuf:"bytes,9,opt,name=certs,proto3" json:"certs,omitempty"`
+	Profile    string  `protobuf:"bytes,17,opt,name=profile,proto3" json:"profile,omitempty"`
+	Secret     string  `protobuf:"bytes,10,opt,name=secret,proto3" json:"secret,omitempty"`
+	Contract   string  `protobuf:"bytes,11,opt,name=contract,proto3" json:"contract,omitempty"`
+	ContractTx string  `protobuf:"bytes,12,opt,name=contract_tx,json=contractTx,proto3" json:"contract_tx,omitempty"`
+	// dcr wallet options
+	Account              string   `protobuf:"bytes,20,opt,name=account,proto3" json:"account,omitempty"`
+	FeePerKb             int64    `protobuf:"varint,22,opt,name=fee_per_kb,json=feePerKb,proto3" json:"fee_per_kb,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -808,7 +1204,7 @@ func (m *RedeemRequest) Reset()         { *m = RedeemRequest{} }
 func (m *RedeemRequest) String() string { return proto.CompactTextString(m) }
 func (*RedeemRequest) ProtoMessage()    {}
 func (*RedeemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{8}
+	return fileDescriptor_9afe1911bb3b5204, []int{9}
 }
 
 func (m *RedeemRequest) XXX_Unmarshal(b []byte) error {
@@ -843,6 +1239,27 @@ func (m *RedeemRequest) GetTestnet() bool {
 	return false
 }
 
+func (m *RedeemRequest) GetNetwork() NETWORK {
+	if m != nil {
+		return m.Network
+	}
+	return NETWORK_MAINNET
+}
+
+func (m *RedeemRequest) GetCoinDef() string {
+	if m != nil {
+		return m.CoinDef
+	}
+	return ""
+}
+
+func (m *RedeemRequest) GetJsonrpc() bool {
+	if m != nil {
+		return m.Jsonrpc
+	}
+	return false
+}
+
 func (m *RedeemRequest) GetHostport() string {
 	if m != nil {
 		return m.Hostport
@@ -878,6 +1295,13 @@ func (m *RedeemRequest) GetCerts() string {
 	return ""
 }
 
+func (m *RedeemRequest) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
 func (m *RedeemRequest) GetSecret() string {
 	if m != nil {
 		return m.Secret
@@ -899,7 +1323,21 @@ func (m *RedeemRequest) GetContractTx() string {
 	return ""
 }
 
-type RedeemResponse struct {
+func (m *RedeemRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *RedeemRequest) GetFeePerKb() int64 {
+	if m != nil {
+		return m.FeePerKb
+	}
+	return 0
+}
+
+type RedeemResponse struct {
 	RedeemTx             string   `protobuf:"bytes,5,opt,name=redeem_tx,json=redeemTx,proto3" json:"redeem_tx,omitempty"`
 	RedeemTxHash         string   `protobuf:"bytes,6,opt,name=redeem_tx_hash,json=redeemTxHash,proto3" json:"redeem_tx_hash,omitempty"`
 	Fee                  int64    `protobuf:"varint,7,opt,name=fee,proto3" json:"fee,omitempty"`
@@ -915,7 +1353,7 @@ func (m *RedeemResponse) Reset()         { *m = RedeemResponse{} }
 func (m *RedeemResponse) String() string { return proto.CompactTextString(m) }
 func (*RedeemResponse) ProtoMessage()    {}
 func (*RedeemResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{9}
+	return fileDescriptor_9afe1911bb3b5204, []int{10}
 }
 
 func (m *RedeemResponse) XXX_Unmarshal(b []byte) error {
@@ -979,15 +1417,22 @@ func (m *RedeemResponse) GetErrstr() string {
 }
 
 type RefundRequest struct {
-	Coin                 COIN     `protobuf:"varint,1,opt,name=coin,proto3,enum=protobind.COIN" json:"coin,omitempty"`
-	Testnet              bool     `protobuf:"varint,2,opt,name=testnet,proto3" json:"testnet,omitempty"`
-	Hostport             string   `protobuf:"bytes,5,opt,name=hostport,proto3" json:"hostport,omitempty"`
-	Rpcuser              string   `protobuf:"bytes,6,opt,name=rpcuser,proto3" json:"rpcuser,omitempty"`
-	Rpcpass              string   `protobuf:"bytes,7,opt,name=rpcpass,proto3" json:"rpcpass,omitempty"`
-	Wpass                string   `protobuf:"bytes,8,opt,name=wpass,proto3" json:"wpass,omitempty"`
-	Certs                string   `protobuf:"bytes,9,opt,name=certs,proto3" json:"certs,omitempty"`
-	Contract             string   `protobuf:"bytes,10,opt,name=contract,proto3" json:"contract,omitempty"`
-	ContractTx           string   `protobuf:"bytes,11,opt,name=contract_tx,json=contractTx,proto3" json:"contract_tx,omitempty"`
+	Coin       COIN    `protobuf:"varint,1,opt,name=coin,proto3,enum=protobind.COIN" json:"coin,omitempty"`
+	Testnet    bool    `protobuf:"varint,2,opt,name=testnet,proto3" json:"testnet,omitempty"`
+	Network    NETWORK `protobuf:"varint,16,opt,name=network,proto3,enum=protobind.NETWORK" json:"network,omitempty"`
+	CoinDef    string  `protobuf:"bytes,3,opt,name=coin_def,json=coinDef,proto3" json:"coin_def,omitempty"`
+	Jsonrpc    bool    `protobuf:"varint,4,opt,name=jsonrpc,proto3" json:"jsonrpc,omitempty"`
+	Hostport   string  `protobuf:"bytes,5,opt,name=hostport,proto3" json:"hostport,omitempty"`
+	Rpcuser    string  `protobuf:"bytes,6,opt,name=rpcuser,proto3" json:"rpcuser,omitempty"`
+	Rpcpass    string  `protobuf:"bytes,7,opt,name=rpcpass,proto3" json:"rpcpass,omitempty"`
+	Wpass      string  `protobuf:"bytes,8,opt,name=wpass,proto3" json:"wpass,omitempty"`
+	Certs      string  `protobuf:"bytes,9,opt,name=certs,proto3" json:"certs,omitempty"`
+	Profile    string  `protobuf:"bytes,17,opt,name=profile,proto3" json:"profile,omitempty"`
+	Contract   string  `protobuf:"bytes,10,opt,name=contract,proto3" json:"contract,omitempty"`
+	ContractTx string  `protobuf:"bytes,11,opt,name=contract_tx,json=contractTx,proto3" json:"contract_tx,omitempty"`
+	// dcr wallet options
+	Account              string   `protobuf:"bytes,20,opt,name=account,proto3" json:"account,omitempty"`
+	FeePerKb             int64    `protobuf:"varint,22,opt,name=fee_per_kb,json=feePerKb,proto3" json:"fee_per_kb,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -997,7 +1442,7 @@ func (m *RefundRequest) Reset()         { *m = RefundRequest{} }
 func (m *RefundRequest) String() string { return proto.CompactTextString(m) }
 func (*RefundRequest) ProtoMessage()    {}
 func (*RefundRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{10}
+	return fileDescriptor_9afe1911bb3b5204, []int{11}
 }
 
 func (m *RefundRequest) XXX_Unmarshal(b []byte) error {
@@ -1032,6 +1477,27 @@ func (m *RefundRequest) GetTestnet() bool {
 	return false
 }
 
+func (m *RefundRequest) GetNetwork() NETWORK {
+	if m != nil {
+		return m.Network
+	}
+	return NETWORK_MAINNET
+}
+
+func (m *RefundRequest) GetCoinDef() string {
+	if m != nil {
+		return m.CoinDef
+	}
+	return ""
+}
+
+func (m *RefundRequest) GetJsonrpc() bool {
+	if m != nil {
+		return m.Jsonrpc
+	}
+	return false
+}
+
 func (m *RefundRequest) GetHostport() string {
 	if m != nil {
 		return m.Hostport
@@ -1067,6 +1533,13 @@ func (m *RefundRequest) GetCerts() string {
 	return ""
 }
 
+func (m *RefundRequest) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
 func (m *RefundRequest) GetContract() string {
 	if m != nil {
 		return m.Contract
@@ -1081,6 +1554,20 @@ func (m *RefundRequest) GetContractTx() string {
 	return ""
 }
 
+func (m *RefundRequest) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
+}
+
+func (m *RefundRequest) GetFeePerKb() int64 {
+	if m != nil {
+		return m.FeePerKb
+	}
+	return 0
+}
+
 type RefundResponse struct {
 	RefundTx             string   `protobuf:"bytes,5,opt,name=refund_tx,json=refundTx,proto3" json:"refund_tx,omitempty"`
 	RefundTxHash         string   `protobuf:"bytes,6,opt,name=refund_tx_hash,json=refundTxHash,proto3" json:"refund_tx_hash,omitempty"`
@@ -1097,7 +1584,7 @@ func (m *RefundResponse) Reset()         { *m = RefundResponse{} }
 func (m *RefundResponse) String() string { return proto.CompactTextString(m) }
 func (*RefundResponse) ProtoMessage()    {}
 func (*RefundResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{11}
+	return fileDescriptor_9afe1911bb3b5204, []int{12}
 }
 
 func (m *RefundResponse) XXX_Unmarshal(b []byte) error {
@@ -1163,11 +1650,15 @@ func (m *RefundResponse) GetErrstr() string {
 type PublishRequest struct {
 	Coin                 COIN     `protobuf:"varint,1,opt,name=coin,proto3,enum=protobind.COIN" json:"coin,omitempty"`
 	Testnet              bool     `protobuf:"varint,2,opt,name=testnet,proto3" json:"testnet,omitempty"`
+	Network              NETWORK  `protobuf:"varint,16,opt,name=network,proto3,enum=protobind.NETWORK" json:"network,omitempty"`
+	CoinDef              string   `protobuf:"bytes,3,opt,name=coin_def,json=coinDef,proto3" json:"coin_def,omitempty"`
+	Jsonrpc              bool     `protobuf:"varint,4,opt,name=jsonrpc,proto3" json:"jsonrpc,omitempty"`
 	Hostport             string   `protobuf:"bytes,5,opt,name=hostport,proto3" json:"hostport,omitempty"`
 	Rpcuser              string   `protobuf:"bytes,6,opt,name=rpcuser,proto3" json:"rpcuser,omitempty"`
 	Rpcpass              string   `protobuf:"bytes,7,opt,name=rpcpass,proto3" json:"rpcpass,omitempty"`
 	Wpass                string   `protobuf:"bytes,8,opt,name=wpass,proto3" json:"wpass,omitempty"`
 	Certs                string   `protobuf:"bytes,9,opt,name=certs,proto3" json:"certs,omitempty"`
+	Profile              string   `protobuf:"bytes,17,opt,name=profile,proto3" json:"profile,omitempty"`
 	Tx                   string   `protobuf:"bytes,10,opt,name=tx,proto3" json:"tx,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -1178,7 +1669,7 @@ func (m *PublishRequest) Reset()         { *m = PublishRequest{} }
 func (m *PublishRequest) String() string { return proto.CompactTextString(m) }
 func (*PublishRequest) ProtoMessage()    {}
 func (*PublishRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{12}
+	return fileDescriptor_9afe1911bb3b5204, []int{13}
 }
 
 func (m *PublishRequest) XXX_Unmarshal(b []byte) error {
@@ -1213,6 +1704,27 @@ func (m *PublishRequest) GetTestnet() bool {
 	return false
 }
 
+func (m *PublishRequest) GetNetwork() NETWORK {
+	if m != nil {
+		return m.Network
+	}
+	return NETWORK_MAINNET
+}
+
+func (m *PublishRequest) GetCoinDef() string {
+	if m != nil {
+		return m.CoinDef
+	}
+	return ""
+}
+
+func (m *PublishRequest) GetJsonrpc() bool {
+	if m != nil {
+		return m.Jsonrpc
+	}
+	return false
+}
+
 func (m *PublishRequest) GetHostport() string {
 	if m != nil {
 		return m.Hostport
@@ -1248,6 +1760,13 @@ func (m *PublishRequest) GetCerts() string {
 	return ""
 }
 
+func (m *PublishRequest) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
 func (m *PublishRequest) GetTx() string {
 	if m != nil {
 		return m.Tx
@@ -1268,7 +1787,7 @@ func (m *PublishResponse) Reset()         { *m = PublishResponse{} }
 func (m *PublishResponse) String() string { return proto.CompactTextString(m) }
 func (*PublishResponse) ProtoMessage()    {}
 func (*PublishResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{13}
+	return fileDescriptor_9afe1911bb3b5204, []int{14}
 }
 
 func (m *PublishResponse) XXX_Unmarshal(b []byte) error {
@@ -1313,6 +1832,8 @@ func (m *PublishResponse) GetErrstr() string {
 type ExtractSecretRequest struct {
 	Coin                 COIN     `protobuf:"varint,1,opt,name=coin,proto3,enum=protobind.COIN" json:"coin,omitempty"`
 	Testnet              bool     `protobuf:"varint,2,opt,name=testnet,proto3" json:"testnet,omitempty"`
+	Network              NETWORK  `protobuf:"varint,16,opt,name=network,proto3,enum=protobind.NETWORK" json:"network,omitempty"`
+	CoinDef              string   `protobuf:"bytes,3,opt,name=coin_def,json=coinDef,proto3" json:"coin_def,omitempty"`
 	CpRedemptionTx       string   `protobuf:"bytes,5,opt,name=cp_redemption_tx,json=cpRedemptionTx,proto3" json:"cp_redemption_tx,omitempty"`
 	Secrethash           string   `protobuf:"bytes,6,opt,name=secrethash,proto3" json:"secrethash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1324,7 +1845,7 @@ func (m *ExtractSecretRequest) Reset()         { *m = ExtractSecretRequest{} }
 func (m *ExtractSecretRequest) String() string { return proto.CompactTextString(m) }
 func (*ExtractSecretRequest) ProtoMessage()    {}
 func (*ExtractSecretRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{14}
+	return fileDescriptor_9afe1911bb3b5204, []int{15}
 }
 
 func (m *ExtractSecretRequest) XXX_Unmarshal(b []byte) error {
@@ -1359,6 +1880,20 @@ func (m *ExtractSecretRequest) GetTestnet() bool {
 	return false
 }
 
+func (m *ExtractSecretRequest) GetNetwork() NETWORK {
+	if m != nil {
+		return m.Network
+	}
+	return NETWORK_MAINNET
+}
+
+func (m *ExtractSecretRequest) GetCoinDef() string {
+	if m != nil {
+		return m.CoinDef
+	}
+	return ""
+}
+
 func (m *ExtractSecretRequest) GetCpRedemptionTx() string {
 	if m != nil {
 		return m.CpRedemptionTx
@@ -1386,7 +1921,7 @@ func (m *ExtractSecretResponse) Reset()         { *m = ExtractSecretResponse{} }
 func (m *ExtractSecretResponse) String() string { return proto.CompactTextString(m) }
 func (*ExtractSecretResponse) ProtoMessage()    {}
 func (*ExtractSecretResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{15}
+	return fileDescriptor_9afe1911bb3b5204, []int{16}
 }
 
 func (m *ExtractSecretResponse) XXX_Unmarshal(b []byte) error {
@@ -1431,6 +1966,8 @@ func (m *ExtractSecretResponse) GetErrstr() string {
 type AuditRequest struct {
 	Coin                 COIN     `protobuf:"varint,1,opt,name=coin,proto3,enum=protobind.COIN" json:"coin,omitempty"`
 	Testnet              bool     `protobuf:"varint,2,opt,name=testnet,proto3" json:"testnet,omitempty"`
+	Network              NETWORK  `protobuf:"varint,16,opt,name=network,proto3,enum=protobind.NETWORK" json:"network,omitempty"`
+	CoinDef              string   `protobuf:"bytes,3,opt,name=coin_def,json=coinDef,proto3" json:"coin_def,omitempty"`
 	Contract             string   `protobuf:"bytes,5,opt,name=contract,proto3" json:"contract,omitempty"`
 	ContractTx           string   `protobuf:"bytes,6,opt,name=contract_tx,json=contractTx,proto3" json:"contract_tx,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1442,7 +1979,7 @@ func (m *AuditRequest) Reset()         { *m = AuditRequest{} }
 func (m *AuditRequest) String() string { return proto.CompactTextString(m) }
 func (*AuditRequest) ProtoMessage()    {}
 func (*AuditRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{16}
+	return fileDescriptor_9afe1911bb3b5204, []int{17}
 }
 
 func (m *AuditRequest) XXX_Unmarshal(b []byte) error {
@@ -1477,6 +2014,20 @@ func (m *AuditRequest) GetTestnet() bool {
 	return false
 }
 
+func (m *AuditRequest) GetNetwork() NETWORK {
+	if m != nil {
+		return m.Network
+	}
+	return NETWORK_MAINNET
+}
+
+func (m *AuditRequest) GetCoinDef() string {
+	if m != nil {
+		return m.CoinDef
+	}
+	return ""
+}
+
 func (m *AuditRequest) GetContract() string {
 	if m != nil {
 		return m.Contract
@@ -1509,7 +2060,7 @@ func (m *AuditResponse) Reset()         { *m = AuditResponse{} }
 func (m *AuditResponse) String() string { return proto.CompactTextString(m) }
 func (*AuditResponse) ProtoMessage()    {}
 func (*AuditResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{17}
+	return fileDescriptor_9afe1911bb3b5204, []int{18}
 }
 
 func (m *AuditResponse) XXX_Unmarshal(b []byte) error {
@@ -1587,14 +2138,21 @@ func (m *AuditResponse) GetErrstr() string {
 }
 
 type GetTxRequest struct {
-	Coin                 COIN     `protobuf:"varint,1,opt,name=coin,proto3,enum=protobind.COIN" json:"coin,omitempty"`
-	Testnet              bool     `protobuf:"varint,2,opt,name=testnet,proto3" json:"testnet,omitempty"`
-	Hostport             string   `protobuf:"bytes,5,opt,name=hostport,proto3" json:"hostport,omitempty"`
-	Rpcuser              string   `protobuf:"bytes,6,opt,name=rpcuser,proto3" json:"rpcuser,omitempty"`
-	Rpcpass              string   `protobuf:"bytes,7,opt,name=rpcpass,proto3" json:"rpcpass,omitempty"`
-	Wpass                string   `protobuf:"bytes,8,opt,name=wpass,proto3" json:"wpass,omitempty"`
-	Certs                string   `protobuf:"bytes,9,opt,name=certs,proto3" json:"certs,omitempty"`
-	Txid                 string   `protobuf:"bytes,10,opt,name=txid,proto3" json:"txid,omitempty"`
+	Coin     COIN    `protobuf:"varint,1,opt,name=coin,proto3,enum=protobind.COIN" json:"coin,omitempty"`
+	Testnet  bool    `protobuf:"varint,2,opt,name=testnet,proto3" json:"testnet,omitempty"`
+	Network  NETWORK `protobuf:"varint,16,opt,name=network,proto3,enum=protobind.NETWORK" json:"network,omitempty"`
+	CoinDef  string  `protobuf:"bytes,3,opt,name=coin_def,json=coinDef,proto3" json:"coin_def,omitempty"`
+	Jsonrpc  bool    `protobuf:"varint,4,opt,name=jsonrpc,proto3" json:"jsonrpc,omitempty"`
+	Hostport string  `protobuf:"bytes,5,opt,name=hostport,proto3" json:"hostport,omitempty"`
+	Rpcuser  string  `protobuf:"bytes,6,opt,name=rpcuser,proto3" json:"rpcuser,omitempty"`
+	Rpcpass  string  `protobuf:"bytes,7,opt,name=rpcpass,proto3" json:"rpcpass,omitempty"`
+	Wpass    string  `protobuf:"bytes,8,opt,name=wpass,proto3" json:"wpass,omitempty"`
+	Certs    string  `protobuf:"bytes,9,opt,name=certs,proto3" json:"certs,omitempty"`
+	Profile  string  `protobuf:"bytes,17,opt,name=profile,proto3" json:"profile,omitempty"`
+	Txid     string  `protobuf:"bytes,10,opt,name=txid,proto3" json:"txid,omitempty"`
+	// dcr node for transactions not in the wallet (uses rpcuser & rpcpass)
+	NodeHostport         string   `protobuf:"bytes,11,opt,name=node_hostport,json=nodeHostport,proto3" json:"node_hostport,omitempty"`
+	NodeCerts            string   `protobuf:"bytes,12,opt,name=node_certs,json=nodeCerts,proto3" json:"node_certs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1604,7 +2162,7 @@ func (m *GetTxRequest) Reset()         { *m = GetTxRequest{} }
 func (m *GetTxRequest) String() string { return proto.CompactTextString(m) }
 func (*GetTxRequest) ProtoMessage()    {}
 func (*GetTxRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{18}
+	return fileDescriptor_9afe1911bb3b5204, []int{19}
 }
 
 func (m *GetTxRequest) XXX_Unmarshal(b []byte) error {
@@ -1639,6 +2197,27 @@ func (m *GetTxRequest) GetTestnet() bool {
 	return false
 }
 
+func (m *GetTxRequest) GetNetwork() NETWORK {
+	if m != nil {
+		return m.Network
+	}
+	return NETWORK_MAINNET
+}
+
+func (m *GetTxRequest) GetCoinDef() string {
+	if m != nil {
+		return m.CoinDef
+	}
+	return ""
+}
+
+func (m *GetTxRequest) GetJsonrpc() bool {
+	if m != nil {
+		return m.Jsonrpc
+	}
+	return false
+}
+
 func (m *GetTxRequest) GetHostport() string {
 	if m != nil {
 		return m.Hostport
@@ -1674,6 +2253,13 @@ func (m *GetTxRequest) GetCerts() string {
 	return ""
 }
 
+func (m *GetTxRequest) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
 func (m *GetTxRequest) GetTxid() string {
 	if m != nil {
 		return m.Txid
@@ -1681,6 +2267,20 @@ func (m *GetTxRequest) GetTxid() string {
 	return ""
 }
 
+func (m *GetTxRequest) GetNodeHostport() string {
+	if m != nil {
+		return m.NodeHostport
+	}
+	return ""
+}
+
+func (m *GetTxRequest) GetNodeCerts() string {
+	if m != nil {
+		return m.NodeCerts
+	}
+	return ""
+}
+
 type GetTxResponse struct {
 	Confirmations        uint64   `protobuf:"varint,5,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
 	Blockhash            string   `protobuf:"bytes,6,opt,name=blockhash,proto3" json:"blockhash,omitempty"`
@@ -1689,6 +2289,7 @@ type GetTxResponse struct {
 	Time                 uint64   `protobuf:"varint,9,opt,name=time,proto3" json:"time,omitempty"`
 	TimeReceived         uint64   `protobuf:"varint,10,opt,name=time_received,json=timeReceived,proto3" json:"time_received,omitempty"`
 	Hex                  string   `protobuf:"bytes,11,opt,name=hex,proto3" json:"hex,omitempty"`
+	Locked               bool     `protobuf:"varint,12,opt,name=locked,proto3" json:"locked,omitempty"`
 	Errorno              ERRNO    `protobuf:"varint,14,opt,name=errorno,proto3,enum=protobind.ERRNO" json:"errorno,omitempty"`
 	Errstr               string   `protobuf:"bytes,15,opt,name=errstr,proto3" json:"errstr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1700,7 +2301,7 @@ func (m *GetTxResponse) Reset()         { *m = GetTxResponse{} }
 func (m *GetTxResponse) String() string { return proto.CompactTextString(m) }
 func (*GetTxResponse) ProtoMessage()    {}
 func (*GetTxResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_9afe1911bb3b5204, []int{19}
+	return fileDescriptor_9afe1911bb3b5204, []int{20}
 }
 
 func (m *GetTxResponse) XXX_Unmarshal(b []byte) error {
@@ -1770,6 +2371,13 @@ func (m *GetTxResponse) GetHex() string {
 	return ""
 }
 
+func (m *GetTxResponse) GetLocked() bool {
+	if m != nil {
+		return m.Locked
+	}
+	return false
+}
+
 func (m *GetTxResponse) GetErrorno() ERRNO {
 	if m != nil {
 		return m.Errorno
@@ -1784,11 +2392,356 @@ func (m *GetTxResponse) GetErrstr() string {
 	return ""
 }
 
+type WatchSwapRequest struct {
+	Coin               COIN    `protobuf:"varint,1,opt,name=coin,proto3,enum=protobind.COIN" json:"coin,omitempty"`
+	Testnet            bool    `protobuf:"varint,2,opt,name=testnet,proto3" json:"testnet,omitempty"`
+	Network            NETWORK `protobuf:"varint,16,opt,name=network,proto3,enum=protobind.NETWORK" json:"network,omitempty"`
+	CoinDef            string  `protobuf:"bytes,3,opt,name=coin_def,json=coinDef,proto3" json:"coin_def,omitempty"`
+	Jsonrpc            bool    `protobuf:"varint,4,opt,name=jsonrpc,proto3" json:"jsonrpc,omitempty"`
+	Hostport           string  `protobuf:"bytes,5,opt,name=hostport,proto3" json:"hostport,omitempty"`
+	Rpcuser            string  `protobuf:"bytes,6,opt,name=rpcuser,proto3" json:"rpcuser,omitempty"`
+	Rpcpass            string  `protobuf:"bytes,7,opt,name=rpcpass,proto3" json:"rpcpass,omitempty"`
+	Wpass              string  `protobuf:"bytes,8,opt,name=wpass,proto3" json:"wpass,omitempty"`
+	Certs              string  `protobuf:"bytes,9,opt,name=certs,proto3" json:"certs,omitempty"`
+	Profile            string  `protobuf:"bytes,17,opt,name=profile,proto3" json:"profile,omitempty"`
+	Contract           string  `protobuf:"bytes,10,opt,name=contract,proto3" json:"contract,omitempty"`
+	ContractTxid       string  `protobuf:"bytes,13,opt,name=contract_txid,json=contractTxid,proto3" json:"contract_txid,omitempty"`
+	Rescan             bool    `protobuf:"varint,18,opt,name=rescan,proto3" json:"rescan,omitempty"`
+	FinalConfirmations int64   `protobuf:"varint,19,opt,name=final_confirmations,json=finalConfirmations,proto3" json:"final_confirmations,omitempty"`
+	// dcr node watched for the contract and its spend (uses rpcuser & rpcpass)
+	NodeHostport         string   `protobuf:"bytes,11,opt,name=node_hostport,json=nodeHostport,proto3" json:"node_hostport,omitempty"`
+	NodeCerts            string   `protobuf:"bytes,12,opt,name=node_certs,json=nodeCerts,proto3" json:"node_certs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchSwapRequest) Reset()         { *m = WatchSwapRequest{} }
+func (m *WatchSwapRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchSwapRequest) ProtoMessage()    {}
+func (*WatchSwapRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9afe1911bb3b5204, []int{21}
+}
+
+func (m *WatchSwapRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchSwapRequest.Unmarshal(m, b)
+}
+func (m *WatchSwapRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchSwapRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchSwapRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchSwapRequest.Merge(m, src)
+}
+func (m *WatchSwapRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchSwapRequest.Size(m)
+}
+func (m *WatchSwapRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchSwapRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchSwapRequest proto.InternalMessageInfo
+
+func (m *WatchSwapRequest) GetCoin() COIN {
+	if m != nil {
+		return m.Coin
+	}
+	return COIN_BTC
+}
+
+func (m *WatchSwapRequest) GetTestnet() bool {
+	if m != nil {
+		return m.Testnet
+	}
+	return false
+}
+
+func (m *WatchSwapRequest) GetNetwork() NETWORK {
+	if m != nil {
+		return m.Network
+	}
+	return NETWORK_MAINNET
+}
+
+func (m *WatchSwapRequest) GetCoinDef() string {
+	if m != nil {
+		return m.CoinDef
+	}
+	return ""
+}
+
+func (m *WatchSwapRequest) GetJsonrpc() bool {
+	if m != nil {
+		return m.Jsonrpc
+	}
+	return false
+}
+
+func (m *WatchSwapRequest) GetHostport() string {
+	if m != nil {
+		return m.Hostport
+	}
+	return ""
+}
+
+func (m *WatchSwapRequest) GetRpcuser() string {
+	if m != nil {
+		return m.Rpcuser
+	}
+	return ""
+}
+
+func (m *WatchSwapRequest) GetRpcpass() string {
+	if m != nil {
+		return m.Rpcpass
+	}
+	return ""
+}
+
+func (m *WatchSwapRequest) GetWpass() string {
+	if m != nil {
+		return m.Wpass
+	}
+	return ""
+}
+
+func (m *WatchSwapRequest) GetCerts() string {
+	if m != nil {
+		return m.Certs
+	}
+	return ""
+}
+
+func (m *WatchSwapRequest) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
+func (m *WatchSwapRequest) GetContract() string {
+	if m != nil {
+		return m.Contract
+	}
+	return ""
+}
+
+func (m *WatchSwapRequest) GetContractTxid() string {
+	if m != nil {
+		return m.ContractTxid
+	}
+	return ""
+}
+
+func (m *WatchSwapRequest) GetRescan() bool {
+	if m != nil {
+		return m.Rescan
+	}
+	return false
+}
+
+func (m *WatchSwapRequest) GetFinalConfirmations() int64 {
+	if m != nil {
+		return m.FinalConfirmations
+	}
+	return 0
+}
+
+func (m *WatchSwapRequest) GetNodeHostport() string {
+	if m != nil {
+		return m.NodeHostport
+	}
+	return ""
+}
+
+func (m *WatchSwapRequest) GetNodeCerts() string {
+	if m != nil {
+		return m.NodeCerts
+	}
+	return ""
+}
+
+type SubscribeEventsRequest struct {
+	Swaps                []*WatchSwapRequest `protobuf:"bytes,1,rep,name=swaps,proto3" json:"swaps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *SubscribeEventsRequest) Reset()         { *m = SubscribeEventsRequest{} }
+func (m *SubscribeEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeEventsRequest) ProtoMessage()    {}
+func (*SubscribeEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9afe1911bb3b5204, []int{22}
+}
+
+func (m *SubscribeEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeEventsRequest.Unmarshal(m, b)
+}
+func (m *SubscribeEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeEventsRequest.Merge(m, src)
+}
+func (m *SubscribeEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeEventsRequest.Size(m)
+}
+func (m *SubscribeEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeEventsRequest proto.InternalMessageInfo
+
+func (m *SubscribeEventsRequest) GetSwaps() []*WatchSwapRequest {
+	if m != nil {
+		return m.Swaps
+	}
+	return nil
+}
+
+type SwapEvent struct {
+	Event                SWAP_EVENT `protobuf:"varint,5,opt,name=event,proto3,enum=protobind.SWAP_EVENT" json:"event,omitempty"`
+	Contract             string     `protobuf:"bytes,6,opt,name=contract,proto3" json:"contract,omitempty"`
+	ContractTxid         string     `protobuf:"bytes,7,opt,name=contract_txid,json=contractTxid,proto3" json:"contract_txid,omitempty"`
+	Confirmations        int64      `protobuf:"varint,8,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	Blockhash            string     `protobuf:"bytes,9,opt,name=blockhash,proto3" json:"blockhash,omitempty"`
+	Blocks               int64      `protobuf:"varint,10,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	RefundLocktime       int64      `protobuf:"varint,11,opt,name=refund_locktime,json=refundLocktime,proto3" json:"refund_locktime,omitempty"`
+	SpendTxid            string     `protobuf:"bytes,12,opt,name=spend_txid,json=spendTxid,proto3" json:"spend_txid,omitempty"`
+	SpendTx              string     `protobuf:"bytes,13,opt,name=spend_tx,json=spendTx,proto3" json:"spend_tx,omitempty"`
+	SpendConfirmations   int64      `protobuf:"varint,16,opt,name=spend_confirmations,json=spendConfirmations,proto3" json:"spend_confirmations,omitempty"`
+	Secret               string     `protobuf:"bytes,17,opt,name=secret,proto3" json:"secret,omitempty"`
+	Errorno              ERRNO      `protobuf:"varint,14,opt,name=errorno,proto3,enum=protobind.ERRNO" json:"errorno,omitempty"`
+	Errstr               string     `protobuf:"bytes,15,opt,name=errstr,proto3" json:"errstr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SwapEvent) Reset()         { *m = SwapEvent{} }
+func (m *SwapEvent) String() string { return proto.CompactTextString(m) }
+func (*SwapEvent) ProtoMessage()    {}
+func (*SwapEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9afe1911bb3b5204, []int{23}
+}
+
+func (m *SwapEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SwapEvent.Unmarshal(m, b)
+}
+func (m *SwapEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SwapEvent.Marshal(b, m, deterministic)
+}
+func (m *SwapEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SwapEvent.Merge(m, src)
+}
+func (m *SwapEvent) XXX_Size() int {
+	return xxx_messageInfo_SwapEvent.Size(m)
+}
+func (m *SwapEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SwapEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SwapEvent proto.InternalMessageInfo
+
+func (m *SwapEvent) GetEvent() SWAP_EVENT {
+	if m != nil {
+		return m.Event
+	}
+	return SWAP_EVENT_MEMPOOL
+}
+
+func (m *SwapEvent) GetContract() string {
+	if m != nil {
+		return m.Contract
+	}
+	return ""
+}
+
+func (m *SwapEvent) GetContractTxid() string {
+	if m != nil {
+		return m.ContractTxid
+	}
+	return ""
+}
+
+func (m *SwapEvent) GetConfirmations() int64 {
+	if m != nil {
+		return m.Confirmations
+	}
+	return 0
+}
+
+func (m *SwapEvent) GetBlockhash() string {
+	if m != nil {
+		return m.Blockhash
+	}
+	return ""
+}
+
+func (m *SwapEvent) GetBlocks() int64 {
+	if m != nil {
+		return m.Blocks
+	}
+	return 0
+}
+
+func (m *SwapEvent) GetRefundLocktime() int64 {
+	if m != nil {
+		return m.RefundLocktime
+	}
+	return 0
+}
+
+func (m *SwapEvent) GetSpendTxid() string {
+	if m != nil {
+		return m.SpendTxid
+	}
+	return ""
+}
+
+func (m *SwapEvent) GetSpendTx() string {
+	if m != nil {
+		return m.SpendTx
+	}
+	return ""
+}
+
+func (m *SwapEvent) GetSpendConfirmations() int64 {
+	if m != nil {
+		return m.SpendConfirmations
+	}
+	return 0
+}
+
+func (m *SwapEvent) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *SwapEvent) GetErrorno() ERRNO {
+	if m != nil {
+		return m.Errorno
+	}
+	return ERRNO_OK
+}
+
+func (m *SwapEvent) GetErrstr() string {
+	if m != nil {
+		return m.Errstr
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterEnum("protobind.COIN", COIN_name, COIN_value)
 	proto.RegisterEnum("protobind.ERRNO", ERRNO_name, ERRNO_value)
+	proto.RegisterEnum("protobind.NETWORK", NETWORK_name, NETWORK_value)
+	proto.RegisterEnum("protobind.SWAP_EVENT", SWAP_EVENT_name, SWAP_EVENT_value)
 	proto.RegisterType((*PingWalletRPCRequest)(nil), "protobind.PingWalletRPCRequest")
 	proto.RegisterType((*PingWalletRPCResponse)(nil), "protobind.PingWalletRPCResponse")
+	proto.RegisterType((*Capabilities)(nil), "protobind.Capabilities")
 	proto.RegisterType((*NewAddressRequest)(nil), "protobind.NewAddressRequest")
 	proto.RegisterType((*NewAddressResponse)(nil), "protobind.NewAddressResponse")
 	proto.RegisterType((*InitiateRequest)(nil), "protobind.InitiateRequest")
@@ -1807,86 +2760,157 @@ func init() {
 	proto.RegisterType((*AuditResponse)(nil), "protobind.AuditResponse")
 	proto.RegisterType((*GetTxRequest)(nil), "protobind.GetTxRequest")
 	proto.RegisterType((*GetTxResponse)(nil), "protobind.GetTxResponse")
+	proto.RegisterType((*WatchSwapRequest)(nil), "protobind.WatchSwapRequest")
+	proto.RegisterType((*SubscribeEventsRequest)(nil), "protobind.SubscribeEventsRequest")
+	proto.RegisterType((*SwapEvent)(nil), "protobind.SwapEvent")
 }
 
 func init() { proto.RegisterFile("atomicswap.proto", fileDescriptor_9afe1911bb3b5204) }
 
 var fileDescriptor_9afe1911bb3b5204 = []byte{
-	// 1169 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0xcd, 0x6e, 0x1c, 0xc5,
-	0x13, 0xcf, 0xcc, 0x7e, 0xd7, 0x7e, 0x4d, 0x3a, 0x4e, 0x32, 0xff, 0x49, 0xfe, 0x89, 0xd9, 0x04,
-	0xc5, 0x18, 0xc9, 0x48, 0xe6, 0x86, 0x84, 0x84, 0xb3, 0xb1, 0xc0, 0xc2, 0xb2, 0x57, 0xed, 0x8d,
-	0x40, 0x70, 0xb0, 0xc6, 0xb3, 0x6d, 0xb6, 0x85, 0x77, 0x66, 0xe8, 0xe9, 0xc5, 0xfb, 0x06, 0x48,
-	0xbc, 0x04, 0xdc, 0x90, 0x22, 0xf1, 0x14, 0x1c, 0xb8, 0x21, 0x71, 0xe0, 0x84, 0x78, 0x17, 0xd4,
-	0x5f, 0xb3, 0x3d, 0xe3, 0x75, 0xb8, 0xac, 0x84, 0x30, 0x9c, 0xb6, 0xeb, 0x57, 0xd5, 0xbd, 0x5d,
-	0xf5, 0xab, 0xee, 0xaa, 0x1e, 0xf0, 0x42, 0x9e, 0xcc, 0x68, 0x94, 0x5d, 0x86, 0xe9, 0x4e, 0xca,
-	0x12, 0x9e, 0xa0, 0x96, 0xfc, 0x39, 0xa3, 0xf1, 0x64, 0xf0, 0xab, 0x03, 0x1b, 0x23, 0x1a, 0x7f,
-	0xf1, 0x49, 0x78, 0x71, 0x41, 0x38, 0x1e, 0x0d, 0x31, 0xf9, 0x6a, 0x4e, 0x32, 0x8e, 0x9e, 0x40,
-	0x35, 0x4a, 0x68, 0xec, 0x3b, 0x9b, 0xce, 0x56, 0x6f, 0xb7, 0xbf, 0x93, 0x4f, 0xd9, 0x19, 0x1e,
-	0x1f, 0x1c, 0x61, 0xa9, 0x44, 0x3e, 0x34, 0x38, 0xc9, 0x78, 0x4c, 0xb8, 0xef, 0x6e, 0x3a, 0x5b,
-	0x4d, 0x6c, 0x44, 0x14, 0x40, 0x73, 0x9a, 0x64, 0x3c, 0x4d, 0x18, 0xf7, 0x6b, 0x9b, 0xce, 0x56,
-	0x0b, 0xe7, 0xb2, 0x98, 0xc5, 0xd2, 0x68, 0x9e, 0x11, 0xe6, 0xd7, 0xa5, 0xca, 0x88, 0x5a, 0x93,
-	0x86, 0x59, 0xe6, 0x37, 0x72, 0x8d, 0x10, 0xd1, 0x06, 0xd4, 0x2e, 0x25, 0xde, 0x94, 0xb8, 0x12,
-	0x04, 0x1a, 0x11, 0xc6, 0x33, 0xbf, 0xa5, 0x50, 0x29, 0x0c, 0x3e, 0x87, 0xbb, 0x25, 0x97, 0xb2,
-	0x34, 0x89, 0x33, 0x82, 0xb6, 0xa1, 0x41, 0x18, 0x4b, 0x58, 0x9c, 0xf8, 0x3d, 0xe9, 0x96, 0x67,
-	0xb9, 0xb5, 0x8f, 0xf1, 0xd1, 0x31, 0x36, 0x06, 0xe8, 0x1e, 0xd4, 0x09, 0x63, 0x19, 0x67, 0x7e,
-	0x5f, 0xae, 0xad, 0xa5, 0xc1, 0x2f, 0x0e, 0xdc, 0x3e, 0x22, 0x97, 0x7b, 0x93, 0x09, 0x23, 0x59,
-	0x76, 0x03, 0xa2, 0xc5, 0x00, 0xd9, 0xfe, 0xe8, 0x50, 0xf9, 0xd0, 0x08, 0x15, 0xa4, 0x37, 0x64,
-	0xc4, 0xb5, 0x04, 0xf1, 0x07, 0x17, 0xfa, 0x07, 0x31, 0xe5, 0x34, 0xe4, 0xe4, 0x9f, 0x1f, 0x42,
-	0xf4, 0x08, 0x20, 0x23, 0x11, 0x23, 0x7c, 0x1a, 0x66, 0x53, 0x1f, 0xa4, 0xca, 0x42, 0xd0, 0x1b,
-	0xd0, 0x49, 0x43, 0xc6, 0x4f, 0x4d, 0x44, 0xdb, 0xd2, 0xa2, 0x2d, 0x30, 0x1d, 0x77, 0x11, 0xa9,
-	0x70, 0x96, 0xcc, 0x63, 0xee, 0x77, 0x36, 0x9d, 0xad, 0x0a, 0xd6, 0xd2, 0xe0, 0x95, 0x0b, 0xde,
-	0x32, 0x52, 0x9a, 0x9c, 0x00, 0x9a, 0x51, 0x12, 0x73, 0x16, 0x46, 0xb9, 0xaf, 0x46, 0x46, 0x4f,
-	0xa0, 0x6b, 0xc6, 0xa7, 0xe9, 0x6e, 0x36, 0xd5, 0x1e, 0x77, 0x0c, 0x38, 0xda, 0xcd, 0xa6, 0xe8,
-	0x31, 0xb4, 0x73, 0x23, 0xbe, 0xd0, 0xae, 0x83, 0x81, 0xc6, 0x0b, 0xb4, 0x05, 0x9e, 0x65, 0x70,
-	0x2a, 0xfd, 0x52, 0x81, 0xe8, 0x2d, 0xad, 0x3e, 0x12, 0xbe, 0x79, 0x50, 0x39, 0x27, 0x44, 0xc6,
-	0xa3, 0x82, 0xc5, 0x50, 0xc4, 0xf4, 0x9c, 0x10, 0x16, 0x72, 0x22, 0x43, 0xe1, 0x62, 0x23, 0x8a,
-	0x7d, 0x5f, 0x24, 0xd1, 0x97, 0x9c, 0xce, 0x88, 0x8c, 0x41, 0x05, 0xe7, 0xf2, 0x5a, 0xd2, 0xea,
-	0x95, 0x0b, 0x68, 0x14, 0x32, 0x4e, 0x23, 0x9a, 0xfe, 0x6b, 0x32, 0x8b, 0xc6, 0xf4, 0x4a, 0x66,
-	0x09, 0xec, 0xaf, 0x32, 0xeb, 0x47, 0x17, 0xee, 0x14, 0x82, 0xf5, 0x5f, 0x72, 0xbd, 0x36, 0xb9,
-	0xbe, 0x73, 0xa1, 0x8b, 0xc9, 0x84, 0x90, 0xd9, 0x0d, 0xc8, 0xab, 0x7b, 0x50, 0x57, 0x59, 0xa4,
-	0x73, 0x4a, 0x4b, 0x05, 0xf2, 0xdb, 0x25, 0xf2, 0x4b, 0xbc, 0x76, 0xca, 0xbc, 0x0e, 0x7e, 0x72,
-	0xa0, 0x67, 0x22, 0xa4, 0x93, 0xe9, 0x01, 0xb4, 0x98, 0x44, 0xc4, 0x0c, 0xed, 0xa4, 0x02, 0xc6,
-	0x0b, 0xf4, 0x14, 0x7a, 0xb9, 0x52, 0x65, 0x81, 0x4e, 0x27, 0x63, 0x61, 0xe7, 0x40, 0x63, 0x65,
-	0x0e, 0x34, 0x8b, 0x39, 0xb0, 0x0e, 0x9e, 0xbf, 0x91, 0x3c, 0x9f, 0xcf, 0xe3, 0xc9, 0x0d, 0xe0,
-	0xd9, 0xe6, 0x13, 0x5e, 0xcf, 0x67, 0xfb, 0x1a, 0x3e, 0x55, 0x24, 0x6c, 0x3e, 0x05, 0x52, 0xe0,
-	0x53, 0x00, 0x86, 0x4f, 0xad, 0x2c, 0xf1, 0xa9, 0x2c, 0xfe, 0x16, 0x3e, 0x7f, 0x77, 0xa0, 0x37,
-	0x9a, 0x9f, 0x5d, 0xd0, 0x6c, 0x7a, 0x03, 0x08, 0xed, 0x81, 0xcb, 0x17, 0x9a, 0x4a, 0x97, 0x2f,
-	0x06, 0x31, 0xf4, 0x73, 0xe7, 0x34, 0x47, 0xf7, 0xa1, 0x61, 0xe2, 0xaf, 0x76, 0x57, 0xe7, 0x2a,
-	0xf2, 0x6b, 0xb9, 0x05, 0x1d, 0xd8, 0xd8, 0x5f, 0xc8, 0x0c, 0x39, 0x91, 0x57, 0xc6, 0x9a, 0x62,
-	0x2a, 0x6a, 0x42, 0x7a, 0x2a, 0x0e, 0xfe, 0x2c, 0xe5, 0x34, 0x89, 0x97, 0xf9, 0xd5, 0x8b, 0x52,
-	0x9c, 0xc3, 0xe3, 0x45, 0xa9, 0x24, 0xd6, 0xcb, 0x25, 0x71, 0x90, 0xc1, 0xdd, 0xd2, 0x06, 0x75,
-	0x5c, 0x96, 0x77, 0x5e, 0xad, 0x70, 0xe7, 0xad, 0x23, 0x2c, 0xdf, 0x3a, 0xd0, 0xd9, 0x9b, 0x4f,
-	0x28, 0x5f, 0x5f, 0x8a, 0x5d, 0x5b, 0x84, 0x4b, 0xe7, 0xb6, 0x7e, 0xe5, 0xdc, 0xfe, 0xe6, 0x42,
-	0x57, 0x6f, 0x46, 0xbb, 0xfe, 0x0c, 0xfa, 0xf9, 0x14, 0xdd, 0x0c, 0xd4, 0xe4, 0xf9, 0xcb, 0x0b,
-	0xee, 0x9e, 0x44, 0xd1, 0x5b, 0x56, 0x69, 0x36, 0x3d, 0x85, 0xfa, 0x83, 0x7c, 0x01, 0xd3, 0x57,
-	0xbc, 0x03, 0x77, 0x72, 0x53, 0x8b, 0x10, 0x95, 0xdb, 0xc8, 0xa8, 0x4e, 0x96, 0xbd, 0xca, 0xdb,
-	0x70, 0x9b, 0x91, 0x88, 0xa6, 0x94, 0xc4, 0xcb, 0xc5, 0x55, 0xca, 0x7b, 0xb9, 0xc2, 0xac, 0xfe,
-	0x66, 0x7e, 0x97, 0x18, 0x4b, 0x75, 0x0c, 0xba, 0x0a, 0x35, 0x66, 0xcf, 0xa0, 0xaf, 0xcd, 0xf2,
-	0xda, 0x0f, 0xca, 0x31, 0x05, 0x1f, 0xae, 0xb3, 0x03, 0xf8, 0xc3, 0x81, 0xce, 0x87, 0x84, 0x8f,
-	0x17, 0x37, 0xe0, 0x1e, 0x41, 0x50, 0xe5, 0x0b, 0x3a, 0xd1, 0x37, 0x89, 0x1c, 0x0f, 0xbe, 0x77,
-	0xa1, 0xab, 0xfd, 0xd3, 0x79, 0xf3, 0x54, 0xf6, 0x7b, 0xe7, 0x94, 0xcd, 0x42, 0x71, 0xfa, 0xd4,
-	0x5b, 0xb0, 0x8a, 0x8b, 0x20, 0x7a, 0x08, 0xad, 0x33, 0x11, 0x7e, 0xeb, 0x40, 0x2e, 0x01, 0x71,
-	0x5e, 0xa5, 0x40, 0xe3, 0x09, 0x51, 0xdd, 0x60, 0x0d, 0x5b, 0x48, 0x3e, 0x5b, 0x92, 0xd7, 0x94,
-	0xeb, 0x2f, 0x01, 0xb9, 0x4f, 0xa1, 0x68, 0x49, 0x85, 0x1c, 0x8b, 0x2e, 0x54, 0xfc, 0x9e, 0x32,
-	0x12, 0x11, 0xfa, 0x35, 0x51, 0x4e, 0x54, 0x71, 0x47, 0x80, 0x58, 0x63, 0xa2, 0xcc, 0x4c, 0x89,
-	0xa9, 0x6a, 0x62, 0xb8, 0x8e, 0x14, 0xd8, 0xde, 0x86, 0xaa, 0xa0, 0x16, 0x35, 0xa0, 0xf2, 0x7c,
-	0x3c, 0xf4, 0x6e, 0x89, 0xc1, 0xe1, 0x78, 0xe8, 0x39, 0x62, 0xf0, 0xe9, 0x67, 0x43, 0xcf, 0x15,
-	0x83, 0x17, 0x43, 0xec, 0x55, 0xb6, 0xb7, 0xa1, 0x26, 0x57, 0x45, 0x75, 0x70, 0x8f, 0x3f, 0xf6,
-	0x6e, 0xa1, 0x26, 0x54, 0x0f, 0x0f, 0x9e, 0x9f, 0x78, 0x0e, 0xea, 0x43, 0xfb, 0xe5, 0xd1, 0xc9,
-	0xcb, 0xd1, 0xe8, 0x18, 0x8f, 0xf7, 0x5f, 0x78, 0xee, 0xee, 0xcf, 0x35, 0x68, 0x9c, 0x5c, 0x86,
-	0xe9, 0x21, 0x3d, 0x43, 0x18, 0xba, 0x85, 0xcf, 0x17, 0xe8, 0xb1, 0xb5, 0xcf, 0x55, 0xdf, 0x6a,
-	0x82, 0xcd, 0xeb, 0x0d, 0x34, 0x91, 0x07, 0x00, 0xcb, 0x47, 0x3e, 0x7a, 0x68, 0xd9, 0x5f, 0xf9,
-	0x96, 0x11, 0xfc, 0xff, 0x1a, 0xad, 0x5e, 0x6a, 0x08, 0x4d, 0xf3, 0x20, 0x45, 0x81, 0x65, 0x5a,
-	0x7a, 0xcf, 0x07, 0x0f, 0x56, 0xea, 0xf4, 0x22, 0x87, 0xd0, 0xb6, 0xde, 0x1e, 0xc8, 0xfe, 0xcb,
-	0xab, 0x0f, 0xb8, 0xe0, 0xd1, 0x75, 0x6a, 0xbd, 0xda, 0xfb, 0x50, 0x57, 0x7d, 0x27, 0xf2, 0x2d,
-	0xcb, 0x42, 0xb3, 0x1e, 0xfc, 0x6f, 0x85, 0xc6, 0x9e, 0x2e, 0x6e, 0x8b, 0xd2, 0x74, 0xab, 0x07,
-	0x2c, 0x4d, 0x2f, 0xf4, 0x44, 0x1f, 0x40, 0x43, 0x97, 0x60, 0x64, 0x5b, 0x15, 0x7b, 0x8e, 0x20,
-	0x58, 0xa5, 0xd2, 0x2b, 0x60, 0xe8, 0x16, 0x4a, 0x56, 0x81, 0xf1, 0x55, 0xd5, 0xb6, 0xc0, 0xf8,
-	0xea, 0x6a, 0xf7, 0x1e, 0xd4, 0x64, 0x0d, 0x40, 0xf7, 0x2d, 0x53, 0xbb, 0x44, 0x05, 0xfe, 0x55,
-	0xc5, 0x72, 0xae, 0xbc, 0x07, 0x0a, 0x73, 0xed, 0x9b, 0xaf, 0x30, 0xb7, 0x70, 0x65, 0x9c, 0xd5,
-	0xa5, 0xe2, 0xdd, 0x3f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xb0, 0x8b, 0x1d, 0x9a, 0x76, 0x14, 0x00,
-	0x00,
+	// 2260 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0x4f, 0x6f, 0xe3, 0xc6,
+	0x15, 0x5f, 0xfd, 0x97, 0x9e, 0xfe, 0x98, 0x1e, 0x7b, 0x1d, 0xae, 0xb2, 0x9b, 0x30, 0x4c, 0xd2,
+	0xb8, 0x4e, 0xb2, 0xee, 0xba, 0xb7, 0xa4, 0x28, 0xa0, 0x95, 0xb5, 0xb6, 0xb1, 0xb6, 0xa5, 0xd2,
+	0xda, 0xec, 0x62, 0x51, 0x40, 0xa0, 0xa8, 0x91, 0xc4, 0xae, 0x45, 0x32, 0xc3, 0xf1, 0xca, 0xdb,
+	0x63, 0x0b, 0xe4, 0x03, 0xb4, 0x97, 0x9e, 0x7a, 0xe8, 0xb1, 0x40, 0xef, 0x3d, 0xf4, 0x56, 0xf4,
+	0x50, 0x14, 0xed, 0x25, 0x2d, 0xd0, 0x0f, 0xd0, 0x43, 0x3f, 0x46, 0xf1, 0x66, 0x86, 0x14, 0x25,
+	0xcb, 0xee, 0xc1, 0x6e, 0x93, 0x83, 0x4f, 0x9a, 0xf7, 0x87, 0xbf, 0x19, 0xbe, 0xf7, 0x7b, 0x6f,
+	0x86, 0xa4, 0x40, 0xb3, 0xb9, 0x3f, 0x71, 0x9d, 0x70, 0x6a, 0x07, 0x0f, 0x03, 0xe6, 0x73, 0x9f,
+	0x94, 0xc4, 0x4f, 0xdf, 0xf5, 0x06, 0xf5, 0xfb, 0x23, 0xdf, 0x1f, 0x9d, 0xd2, 0x6d, 0x3b, 0x70,
+	0xb7, 0x6d, 0xcf, 0xf3, 0xb9, 0xcd, 0x5d, 0xdf, 0x0b, 0xa5, 0x63, 0xfd, 0x13, 0xf1, 0xe3, 0x7c,
+	0x3a, 0xa2, 0xde, 0xa7, 0xe1, 0xd4, 0x1e, 0x8d, 0x28, 0xdb, 0xf6, 0x03, 0xe1, 0x71, 0xd1, 0xdb,
+	0xfc, 0x5b, 0x1a, 0xd6, 0x3b, 0xae, 0x37, 0x7a, 0x6e, 0x9f, 0x9e, 0x52, 0x6e, 0x75, 0x9a, 0x16,
+	0xfd, 0xf2, 0x8c, 0x86, 0x9c, 0xbc, 0x0f, 0x59, 0xc7, 0x77, 0x3d, 0x3d, 0x65, 0xa4, 0x36, 0x6b,
+	0x3b, 0x2b, 0x0f, 0xe3, 0xe9, 0x1f, 0x36, 0xdb, 0x07, 0xc7, 0x96, 0x30, 0x12, 0x1d, 0x0a, 0x9c,
+	0x86, 0xdc, 0xa3, 0x5c, 0x4f, 0x1b, 0xa9, 0xcd, 0xa2, 0x15, 0x89, 0xe4, 0x13, 0x28, 0x78, 0x94,
+	0x4f, 0x7d, 0xf6, 0x4a, 0xd7, 0x04, 0x02, 0x49, 0x20, 0x1c, 0xb7, 0xba, 0xcf, 0xdb, 0xd6, 0x53,
+	0x2b, 0x72, 0x21, 0xf7, 0xa0, 0x88, 0x78, 0xbd, 0x01, 0x1d, 0xea, 0x19, 0x23, 0xb5, 0x59, 0xb2,
+	0x0a, 0x28, 0xef, 0xd2, 0x21, 0x4e, 0xf1, 0x93, 0xd0, 0xf7, 0x58, 0xe0, 0xe8, 0x59, 0x39, 0x85,
+	0x12, 0x49, 0x1d, 0x8a, 0x63, 0x3f, 0xe4, 0x81, 0xcf, 0xb8, 0x9e, 0x13, 0x17, 0xc5, 0x32, 0x5e,
+	0xc5, 0x02, 0xe7, 0x2c, 0xa4, 0x4c, 0xcf, 0x4b, 0x3c, 0x25, 0x2a, 0x4b, 0x60, 0x87, 0xa1, 0x5e,
+	0x88, 0x2d, 0x28, 0x92, 0x75, 0xc8, 0x4d, 0x85, 0xbe, 0x28, 0xf4, 0xb9, 0x69, 0xa4, 0x75, 0x28,
+	0xe3, 0xa1, 0x5e, 0x92, 0x5a, 0x21, 0x20, 0x4a, 0xc0, 0xfc, 0xa1, 0x7b, 0x4a, 0xf5, 0x55, 0x89,
+	0xa2, 0x44, 0xf3, 0xb7, 0x69, 0xb8, 0xbb, 0x10, 0xd0, 0x30, 0xf0, 0xbd, 0x90, 0x0a, 0xa4, 0xb1,
+	0xed, 0x7a, 0x6a, 0xb1, 0x52, 0x20, 0x1b, 0x90, 0xef, 0x9f, 0xfa, 0xce, 0xab, 0x50, 0x2c, 0x34,
+	0x63, 0x29, 0x09, 0x67, 0x78, 0x4d, 0x59, 0xe8, 0xfa, 0x5e, 0xb4, 0x4e, 0x25, 0x92, 0x07, 0x00,
+	0x78, 0x27, 0x3d, 0x7b, 0x44, 0x3d, 0xae, 0x16, 0x5b, 0x42, 0x4d, 0x03, 0x15, 0xe4, 0xbb, 0xa0,
+	0x49, 0x06, 0xf8, 0xa7, 0xbd, 0x08, 0xa1, 0x24, 0xa0, 0x57, 0x22, 0xfd, 0x17, 0x0a, 0xe9, 0x73,
+	0xa8, 0x38, 0x76, 0x60, 0xf7, 0xdd, 0x53, 0x97, 0xbb, 0x34, 0xd4, 0xc1, 0x48, 0x6d, 0x96, 0x77,
+	0xde, 0x4a, 0xe6, 0x3a, 0x61, 0xb6, 0xe6, 0x9c, 0xc9, 0x16, 0x14, 0x28, 0x63, 0x3e, 0xf3, 0x7c,
+	0xbd, 0x26, 0x32, 0xac, 0x25, 0xae, 0x6b, 0x59, 0xd6, 0x71, 0xdb, 0x8a, 0x1c, 0xf0, 0x26, 0x29,
+	0x63, 0x21, 0x67, 0xfa, 0x8a, 0x58, 0xae, 0x92, 0xcc, 0xdf, 0xa4, 0xa0, 0xd2, 0x9c, 0x07, 0xd5,
+	0x68, 0xc8, 0xdd, 0x89, 0xcd, 0x69, 0x38, 0xb1, 0x19, 0x1f, 0x52, 0x2a, 0x18, 0x58, 0xb4, 0x2e,
+	0xe8, 0x89, 0x09, 0xd5, 0xe1, 0x99, 0x37, 0xe8, 0x0d, 0x29, 0xed, 0x31, 0x9b, 0x53, 0x45, 0xc1,
+	0x32, 0x2a, 0x9f, 0x50, 0x6a, 0xd9, 0x9c, 0x92, 0x77, 0xa1, 0xec, 0x8c, 0x6d, 0x6f, 0x44, 0x7b,
+	0xfc, 0x4d, 0x40, 0x05, 0xb7, 0x8a, 0x16, 0x48, 0x55, 0xf7, 0x4d, 0x40, 0xc9, 0x7b, 0x50, 0x19,
+	0xbc, 0xf1, 0xec, 0x89, 0xeb, 0x20, 0x4e, 0xa8, 0x38, 0x56, 0x56, 0xba, 0x27, 0x94, 0x86, 0xe6,
+	0x5f, 0xd2, 0xb0, 0x7a, 0x4c, 0xa7, 0x8d, 0xc1, 0x80, 0xd1, 0x30, 0xbc, 0xad, 0x8f, 0xeb, 0xd5,
+	0x07, 0x03, 0x92, 0x0c, 0xa6, 0xaa, 0x0d, 0x1d, 0x0a, 0xb6, 0x54, 0xa9, 0xa5, 0x46, 0xe2, 0x8d,
+	0xd0, 0xec, 0xe7, 0x59, 0x58, 0x39, 0xf0, 0x5c, 0xee, 0xda, 0x9c, 0xde, 0xe6, 0xef, 0x5a, 0xf9,
+	0x23, 0xef, 0x00, 0x84, 0xd4, 0x61, 0x94, 0x8f, 0xed, 0x70, 0x2c, 0x3a, 0x46, 0xc9, 0x4a, 0x68,
+	0xb0, 0xa0, 0x02, 0x9b, 0xf1, 0x5e, 0x94, 0xce, 0xb2, 0xf0, 0x28, 0xa3, 0x4e, 0x25, 0x1d, 0xd3,
+	0x64, 0x4f, 0xfc, 0x33, 0x8f, 0xeb, 0x15, 0xd9, 0xf2, 0xa4, 0x24, 0x48, 0xe0, 0x38, 0xc2, 0xb0,
+	0xae, 0x48, 0x20, 0x45, 0xf2, 0x21, 0xd4, 0x18, 0xfd, 0xf2, 0xcc, 0x65, 0x74, 0xd0, 0x73, 0x7c,
+	0x6f, 0x18, 0xea, 0x77, 0x8d, 0xd4, 0x66, 0xce, 0xaa, 0x46, 0xda, 0x26, 0x2a, 0xc9, 0x7d, 0x00,
+	0x6c, 0x06, 0x01, 0x65, 0xbd, 0x57, 0x7d, 0x7d, 0x43, 0x80, 0x17, 0x87, 0x94, 0x76, 0x28, 0x7b,
+	0xda, 0xc7, 0xce, 0xac, 0xcd, 0x58, 0xa0, 0x88, 0x57, 0xc7, 0xcc, 0x78, 0x9c, 0xd9, 0x4e, 0x1c,
+	0xe4, 0x48, 0x26, 0xef, 0x43, 0x35, 0x1a, 0xf7, 0x82, 0x9d, 0x70, 0xac, 0x42, 0x5d, 0x89, 0x94,
+	0x9d, 0x9d, 0x70, 0x2c, 0x3a, 0x4c, 0xe4, 0xc4, 0xcf, 0x55, 0xcc, 0x21, 0x52, 0x75, 0xcf, 0xc9,
+	0x26, 0x68, 0x09, 0x87, 0x9e, 0x08, 0x9b, 0xcc, 0x40, 0x6d, 0xe6, 0xb5, 0x8f, 0xa1, 0xd3, 0x20,
+	0x83, 0xfd, 0x4e, 0x36, 0x6b, 0x1c, 0x62, 0x44, 0x86, 0x94, 0x8a, 0xe6, 0x86, 0x91, 0x4e, 0x5b,
+	0x91, 0x88, 0xeb, 0xc6, 0x7d, 0x82, 0xbb, 0x13, 0x2a, 0x42, 0x9c, 0xb1, 0x62, 0xf9, 0x46, 0x4a,
+	0xe6, 0xab, 0x2c, 0x90, 0x8e, 0xcd, 0xb8, 0xeb, 0xb8, 0xc1, 0x6d, 0xd5, 0xfc, 0x3f, 0xaa, 0xc6,
+	0xf5, 0xdc, 0x0b, 0x55, 0x83, 0xba, 0x6f, 0xb8, 0x6a, 0x7e, 0x97, 0x86, 0xb5, 0x39, 0x22, 0xdc,
+	0x16, 0xce, 0x95, 0x85, 0xf3, 0xf7, 0x0c, 0x54, 0x2d, 0x3a, 0xa0, 0x74, 0x72, 0x5b, 0x33, 0xd7,
+	0xab, 0x99, 0x0d, 0xc8, 0xcb, 0x0a, 0x51, 0xf5, 0xa2, 0xa4, 0x39, 0xe6, 0x95, 0x17, 0x98, 0xb7,
+	0x40, 0xaa, 0xca, 0x05, 0x52, 0x5d, 0x5e, 0x2d, 0x57, 0x97, 0xc1, 0x1f, 0x53, 0x50, 0x8b, 0xd2,
+	0xaa, 0x2a, 0xe0, 0x6d, 0x28, 0x31, 0xa1, 0xc1, 0x99, 0x54, 0xd8, 0xa4, 0xa2, 0x7b, 0x4e, 0x3e,
+	0x80, 0x9a, 0x1c, 0xc7, 0xd4, 0x55, 0x35, 0x10, 0x79, 0x24, 0x89, 0x5b, 0x58, 0x4a, 0xdc, 0xe2,
+	0x3c, 0x71, 0x6f, 0x82, 0x9c, 0x7f, 0x10, 0xe4, 0xc4, 0x03, 0xf2, 0x2d, 0x39, 0xaf, 0x47, 0xce,
+	0x24, 0x09, 0xe1, 0x6a, 0x12, 0x96, 0x6f, 0x98, 0x84, 0x32, 0x7d, 0x49, 0x12, 0xa2, 0x66, 0x8e,
+	0x84, 0xa8, 0x88, 0x48, 0xa8, 0x8c, 0x0b, 0x24, 0x94, 0x1e, 0xdf, 0x08, 0x09, 0xff, 0x99, 0x86,
+	0x5a, 0xe7, 0xac, 0x7f, 0xea, 0x86, 0xe3, 0x5b, 0x16, 0x5e, 0x8f, 0x85, 0x35, 0x48, 0xf3, 0x73,
+	0xc5, 0xbf, 0x34, 0x3f, 0x37, 0x3d, 0x58, 0x89, 0x23, 0xab, 0x08, 0xf2, 0x16, 0x14, 0xa2, 0xe4,
+	0xcb, 0x75, 0xe7, 0xb9, 0x4c, 0xfb, 0x4d, 0xa4, 0xf2, 0xdf, 0x29, 0x58, 0x6f, 0x9d, 0x0b, 0x5a,
+	0x9f, 0x88, 0xe6, 0xfc, 0x6d, 0x4b, 0x28, 0x9e, 0x21, 0x82, 0x1e, 0xf6, 0xdc, 0x89, 0x78, 0x05,
+	0x36, 0xab, 0x92, 0x9a, 0x13, 0x58, 0xb1, 0xba, 0x7b, 0xbe, 0x70, 0x42, 0xcb, 0x2f, 0x9e, 0xd0,
+	0xcc, 0x10, 0xee, 0x2e, 0xdc, 0xa9, 0x0a, 0xf0, 0x6c, 0x9b, 0xca, 0xcd, 0x6d, 0x53, 0x37, 0x11,
+	0xdf, 0xaf, 0x53, 0x50, 0x69, 0x9c, 0x0d, 0xdc, 0x6f, 0x5d, 0x5c, 0xaf, 0x3a, 0xfd, 0x2d, 0xb4,
+	0xbf, 0xfc, 0x62, 0xfb, 0x33, 0xff, 0x91, 0x86, 0xaa, 0xba, 0x2b, 0x15, 0xc3, 0x8f, 0x60, 0x25,
+	0xbe, 0x44, 0x1d, 0x72, 0x73, 0xa2, 0x1d, 0xc5, 0x27, 0xbd, 0x86, 0xd0, 0xe2, 0xcb, 0xad, 0x99,
+	0xa3, 0x3a, 0x2b, 0xcb, 0x09, 0x62, 0x80, 0xe8, 0xbc, 0xbc, 0x0d, 0x6b, 0xb1, 0x6b, 0x22, 0xb3,
+	0xb2, 0x0e, 0x49, 0x64, 0x3a, 0x89, 0x2d, 0xe4, 0x63, 0x58, 0x65, 0xd4, 0x71, 0x03, 0x97, 0x7a,
+	0x33, 0x70, 0x59, 0x9e, 0x5a, 0x6c, 0x88, 0xd0, 0x3f, 0x8c, 0x5b, 0x6b, 0xe4, 0x29, 0x4b, 0xb6,
+	0x2a, 0xb5, 0x91, 0xdb, 0x47, 0xb0, 0xa2, 0xdc, 0xe2, 0x43, 0x27, 0xc8, 0x1b, 0x93, 0xea, 0xc3,
+	0x9b, 0x3c, 0x7a, 0xfe, 0x3a, 0x03, 0x95, 0x3d, 0xca, 0xbb, 0xe7, 0xb7, 0x6d, 0xf5, 0x7a, 0x6d,
+	0x95, 0x40, 0x96, 0x9f, 0xbb, 0x03, 0xd5, 0x58, 0xc5, 0x18, 0x9f, 0x69, 0x3c, 0x7f, 0x40, 0x7b,
+	0xf1, 0x72, 0xe5, 0xb6, 0x5e, 0x41, 0xe5, 0x7e, 0xb4, 0xe4, 0x07, 0x00, 0xc2, 0x49, 0xce, 0x26,
+	0x4f, 0x9f, 0x25, 0xd4, 0x34, 0x51, 0x61, 0xfe, 0x3e, 0x0d, 0x55, 0x95, 0x20, 0x45, 0xfc, 0x0f,
+	0xc4, 0x93, 0xd2, 0xd0, 0x65, 0x13, 0xf9, 0x56, 0x5e, 0x04, 0x21, 0x6b, 0xcd, 0x2b, 0xc9, 0x7d,
+	0x28, 0x89, 0xb7, 0xc2, 0x89, 0xd6, 0x34, 0x53, 0x60, 0xe7, 0x12, 0x82, 0xeb, 0x0d, 0xa8, 0x7c,
+	0x8e, 0xca, 0x59, 0x09, 0x4d, 0x7c, 0xb5, 0x60, 0x5f, 0x51, 0xe0, 0xcf, 0x14, 0xe2, 0x5e, 0xd1,
+	0x50, 0x12, 0x06, 0x31, 0xc6, 0x7b, 0xc5, 0xdf, 0x1e, 0xa3, 0x0e, 0x75, 0x5f, 0x53, 0x19, 0x88,
+	0xac, 0x55, 0x41, 0xa5, 0xa5, 0x74, 0x78, 0x6c, 0x18, 0xd3, 0xe8, 0x74, 0x83, 0x43, 0xe4, 0x25,
+	0xc2, 0xd2, 0x81, 0xb8, 0xf3, 0xa2, 0xa5, 0xa4, 0x1b, 0xe1, 0xf6, 0xaf, 0xb2, 0xa0, 0x3d, 0xb7,
+	0xb9, 0x33, 0x3e, 0x99, 0xda, 0xc1, 0x2d, 0xbf, 0xff, 0x77, 0x87, 0xd7, 0xe4, 0xb3, 0xbb, 0x28,
+	0x82, 0xea, 0xfc, 0xb3, 0x7b, 0x17, 0x8b, 0x61, 0x03, 0xf2, 0x8c, 0x86, 0x8e, 0xed, 0xe9, 0x44,
+	0x66, 0x5a, 0x4a, 0xd8, 0x73, 0x87, 0xae, 0x67, 0x9f, 0xf6, 0xe6, 0x49, 0xbd, 0x26, 0x5a, 0x1e,
+	0x11, 0xa6, 0x66, 0xd2, 0x72, 0x23, 0x55, 0xf5, 0x14, 0x36, 0x4e, 0xce, 0xfa, 0xa1, 0xc3, 0xdc,
+	0x3e, 0x6d, 0xbd, 0xa6, 0x1e, 0x8f, 0xdf, 0xd1, 0x3f, 0x82, 0x1c, 0x7e, 0x41, 0x0b, 0xf5, 0x94,
+	0x91, 0xd9, 0x2c, 0xef, 0xbc, 0x9d, 0x48, 0xef, 0x22, 0x97, 0x2c, 0xe9, 0x69, 0xfe, 0x29, 0x03,
+	0x25, 0x54, 0x0b, 0x20, 0xf2, 0x31, 0xe4, 0x28, 0x0e, 0x44, 0xee, 0x6a, 0x3b, 0x77, 0x13, 0x00,
+	0x27, 0xcf, 0x1b, 0x9d, 0x5e, 0xeb, 0x8b, 0xd6, 0x71, 0xd7, 0x92, 0x3e, 0x73, 0x51, 0xcd, 0xff,
+	0xb7, 0xa8, 0x16, 0x96, 0x44, 0xf5, 0x42, 0x33, 0x28, 0x8a, 0xb8, 0x5d, 0xd5, 0x0c, 0x4a, 0x8b,
+	0xcd, 0x60, 0xf6, 0x39, 0x09, 0xe6, 0x3e, 0x27, 0x2d, 0xd9, 0x88, 0xca, 0x4b, 0x37, 0xa2, 0x07,
+	0x00, 0x61, 0x40, 0xc5, 0x23, 0x83, 0x3b, 0x88, 0x82, 0x2d, 0x34, 0x62, 0x8d, 0xf7, 0xa0, 0x18,
+	0x99, 0x15, 0x33, 0x0a, 0xca, 0x88, 0xc9, 0x97, 0xa6, 0xf9, 0x9b, 0xd0, 0x64, 0xf2, 0x85, 0x69,
+	0x3e, 0xf9, 0xb3, 0x93, 0xd3, 0xea, 0x4d, 0x9f, 0x9c, 0xb6, 0xa6, 0x90, 0xc5, 0x1e, 0x40, 0x0a,
+	0x90, 0x79, 0xdc, 0x6d, 0x6a, 0x77, 0x70, 0x70, 0xd8, 0x6d, 0x6a, 0x29, 0x1c, 0xbc, 0x78, 0xd9,
+	0xd4, 0xd2, 0x38, 0xd8, 0x6d, 0x5a, 0x5a, 0x46, 0xf8, 0x34, 0xf7, 0xb5, 0x2c, 0x29, 0x42, 0xb6,
+	0xd3, 0xb0, 0xba, 0x5a, 0x0e, 0x47, 0x3f, 0xea, 0x3e, 0x3b, 0xd2, 0xf2, 0x38, 0x7a, 0xd6, 0x7d,
+	0xd1, 0xd6, 0x0a, 0x38, 0xda, 0x6d, 0xef, 0xb5, 0xb4, 0xa2, 0x18, 0x35, 0x4e, 0xf6, 0xb5, 0x12,
+	0x5e, 0xfa, 0xb2, 0xd5, 0xd4, 0x00, 0x07, 0xad, 0xee, 0xbe, 0x56, 0xde, 0xda, 0x82, 0x9c, 0x58,
+	0x22, 0xc9, 0x43, 0xba, 0xfd, 0x54, 0xbb, 0x83, 0xce, 0x87, 0x07, 0x8f, 0x4f, 0xb4, 0x14, 0x59,
+	0x81, 0xf2, 0xb3, 0xe3, 0x93, 0x67, 0x9d, 0x4e, 0xdb, 0xea, 0xb6, 0x76, 0xb5, 0xf4, 0xd6, 0x3e,
+	0x14, 0x54, 0x9b, 0x21, 0x65, 0x28, 0x1c, 0x35, 0x0e, 0x8e, 0x8f, 0x5b, 0x5d, 0xed, 0x0e, 0x0a,
+	0xdd, 0xd6, 0x49, 0x17, 0x85, 0x14, 0x0a, 0x56, 0x6b, 0x0f, 0x65, 0x2d, 0x4d, 0x00, 0xf2, 0x27,
+	0x07, 0x47, 0x68, 0xc8, 0xc8, 0xf1, 0x1e, 0x8e, 0xb3, 0x5b, 0x36, 0xc0, 0x8c, 0x90, 0x02, 0xac,
+	0x75, 0xd4, 0x69, 0xb7, 0x0f, 0xb5, 0x3b, 0x64, 0x15, 0xaa, 0xcd, 0xf6, 0xf1, 0x93, 0x03, 0xeb,
+	0xa8, 0xd1, 0x3d, 0x68, 0x1f, 0xe3, 0x42, 0x2a, 0x50, 0xb4, 0x5a, 0xbb, 0xad, 0xd6, 0x11, 0xae,
+	0x42, 0x4a, 0x4f, 0x9e, 0x1d, 0xef, 0xb6, 0x76, 0xb5, 0x0c, 0x4a, 0x87, 0xed, 0xe6, 0xd3, 0xee,
+	0xc1, 0x51, 0x4b, 0xcb, 0x92, 0x12, 0xe4, 0xac, 0x56, 0xdb, 0xda, 0xd3, 0x72, 0x3b, 0x5f, 0x95,
+	0xa0, 0x80, 0x95, 0x71, 0xe8, 0xf6, 0xc9, 0x08, 0xaa, 0x73, 0xdf, 0x38, 0xc9, 0xbb, 0x89, 0x0c,
+	0x2d, 0xfb, 0x9c, 0x5c, 0x37, 0x2e, 0x77, 0x90, 0x5b, 0xa1, 0xb9, 0xf6, 0xb3, 0xaf, 0xff, 0xf5,
+	0xcb, 0x74, 0xd5, 0x2c, 0x6e, 0xbf, 0x7e, 0xb4, 0x1d, 0xb8, 0xde, 0xe8, 0xb3, 0xd4, 0x16, 0x19,
+	0x00, 0xcc, 0xbe, 0x16, 0x91, 0xfb, 0xc9, 0xfe, 0xbc, 0xf8, 0x45, 0xae, 0xfe, 0xe0, 0x12, 0xab,
+	0xc2, 0xbf, 0x27, 0xf0, 0xd7, 0xcc, 0x1a, 0xe2, 0x7b, 0x74, 0xaa, 0xce, 0x6d, 0x38, 0xcb, 0x8f,
+	0xa1, 0x18, 0x7d, 0x18, 0x20, 0xf5, 0x04, 0xca, 0xc2, 0x37, 0xa3, 0xfa, 0xdb, 0x4b, 0x6d, 0x0a,
+	0xff, 0x2d, 0x81, 0xbf, 0x6a, 0x56, 0x10, 0xdf, 0x55, 0x56, 0x44, 0x1f, 0x43, 0x39, 0xf1, 0x02,
+	0x95, 0x24, 0x97, 0x79, 0xf1, 0x0d, 0x7b, 0xfd, 0x9d, 0xcb, 0xcc, 0x6a, 0x9a, 0xba, 0x98, 0x66,
+	0xdd, 0x5c, 0x11, 0x61, 0x9a, 0x39, 0xe0, 0x4c, 0x5d, 0xc8, 0xcb, 0x77, 0x54, 0x44, 0x4f, 0xa0,
+	0xcc, 0xbd, 0x8d, 0xac, 0xdf, 0x5b, 0x62, 0x51, 0xd0, 0x77, 0x05, 0xf4, 0x8a, 0x09, 0x08, 0x2d,
+	0xdf, 0x53, 0xc5, 0xa8, 0xd8, 0x23, 0x16, 0x50, 0x13, 0xaf, 0x91, 0xea, 0xf7, 0x96, 0x58, 0x96,
+	0xa3, 0xa2, 0x0d, 0x51, 0x5f, 0x40, 0x41, 0x3d, 0xaa, 0x92, 0xe4, 0xc5, 0xf3, 0x2f, 0x06, 0xea,
+	0xf5, 0x65, 0x26, 0x05, 0xbc, 0x21, 0x80, 0x35, 0xb3, 0x2c, 0x22, 0x21, 0x8d, 0x88, 0x1c, 0x40,
+	0x75, 0xee, 0x49, 0x6d, 0x8e, 0x9c, 0xcb, 0x9e, 0x56, 0xeb, 0xc6, 0xe5, 0x0e, 0x6a, 0xae, 0xfb,
+	0x62, 0xae, 0x0d, 0x73, 0x15, 0xe7, 0xa2, 0xd2, 0x45, 0x76, 0x2b, 0x9c, 0xb1, 0x03, 0x39, 0xf1,
+	0x3c, 0x43, 0x92, 0x9f, 0xce, 0x93, 0xcf, 0x6d, 0x75, 0xfd, 0xa2, 0x41, 0x21, 0xaf, 0x0b, 0xe4,
+	0x9a, 0x59, 0x42, 0x64, 0x1b, 0x4d, 0x0a, 0x51, 0x1c, 0x14, 0xe7, 0x10, 0x93, 0x67, 0xfb, 0xba,
+	0x7e, 0xd1, 0xb0, 0x0c, 0x71, 0x44, 0x39, 0x3f, 0x47, 0xc4, 0x97, 0x50, 0x8a, 0xf7, 0x3c, 0x72,
+	0xd5, 0x4e, 0x58, 0x5f, 0x4f, 0xee, 0x72, 0xd1, 0x56, 0x68, 0xea, 0x02, 0x95, 0x98, 0x55, 0x44,
+	0x9d, 0xe2, 0x35, 0xb8, 0x61, 0x7e, 0x96, 0xda, 0xfa, 0x5e, 0x8a, 0x9c, 0xc2, 0xca, 0xc2, 0x0e,
+	0x4c, 0xde, 0x4b, 0x82, 0x2c, 0xdd, 0x9d, 0x2f, 0x99, 0xe7, 0x1d, 0x31, 0x8f, 0x6e, 0xae, 0xe1,
+	0x3c, 0x61, 0x74, 0xa5, 0xd8, 0x61, 0x43, 0x31, 0xdb, 0xe3, 0xbf, 0xa6, 0x7e, 0xd1, 0xf8, 0x73,
+	0x8a, 0x04, 0x40, 0x66, 0x7f, 0x92, 0x31, 0xa2, 0xce, 0xf4, 0xbc, 0x21, 0x74, 0x86, 0x50, 0x3a,
+	0xfe, 0x64, 0x62, 0x7b, 0x83, 0xf0, 0xa1, 0xa1, 0xe6, 0x0c, 0x0d, 0xc7, 0x66, 0xec, 0x8d, 0xc1,
+	0xc7, 0xd4, 0x98, 0x8a, 0x0e, 0x64, 0x58, 0x9d, 0xa6, 0x11, 0x52, 0xce, 0x5d, 0x6f, 0x14, 0x1a,
+	0x3e, 0x33, 0x6c, 0x23, 0xa4, 0xec, 0x35, 0x65, 0x91, 0x5d, 0x9d, 0x99, 0x76, 0x52, 0x8f, 0x5e,
+	0xee, 0x41, 0x0b, 0xf2, 0x7d, 0x6a, 0x33, 0xca, 0xc8, 0xe7, 0xc5, 0x34, 0xf9, 0xce, 0x63, 0x31,
+	0x36, 0x7e, 0xc0, 0xfd, 0x57, 0xd4, 0xfb, 0xa1, 0x31, 0x64, 0xfe, 0x44, 0x40, 0x0b, 0x39, 0x8c,
+	0xa6, 0xaf, 0x57, 0x1b, 0x67, 0x7c, 0xec, 0x33, 0xf7, 0xa7, 0x62, 0xdf, 0x33, 0xd2, 0xfd, 0x0a,
+	0x40, 0x0c, 0x74, 0xa7, 0x9f, 0x17, 0x61, 0xf8, 0xfe, 0x7f, 0x06, 0x00, 0x2a, 0xb4, 0x2b, 0x26,
+	0xf4, 0x23, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1911,6 +2935,8 @@ type SwapLibClient interface {
 	ExtractSecret(ctx context.Context, in *ExtractSecretRequest, opts ...grpc.CallOption) (*ExtractSecretResponse, error)
 	Audit(ctx context.Context, in *AuditRequest, opts ...grpc.CallOption) (*AuditResponse, error)
 	GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error)
+	WatchSwap(ctx context.Context, in *WatchSwapRequest, opts ...grpc.CallOption) (SwapLib_WatchSwapClient, error)
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (SwapLib_SubscribeEventsClient, error)
 }
 
 type swapLibClient struct {
@@ -2011,6 +3037,70 @@ func (c *swapLibClient) GetTx(ctx context.Context, in *GetTxRequest, opts ...grp
 	return out, nil
 }
 
+func (c *swapLibClient) WatchSwap(ctx context.Context, in *WatchSwapRequest, opts ...grpc.CallOption) (SwapLib_WatchSwapClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SwapLib_serviceDesc.Streams[0], "/protobind.SwapLib/WatchSwap", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &swapLibWatchSwapClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SwapLib_WatchSwapClient interface {
+	Recv() (*SwapEvent, error)
+	grpc.ClientStream
+}
+
+type swapLibWatchSwapClient struct {
+	grpc.ClientStream
+}
+
+func (x *swapLibWatchSwapClient) Recv() (*SwapEvent, error) {
+	m := new(SwapEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *swapLibClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (SwapLib_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SwapLib_serviceDesc.Streams[1], "/protobind.SwapLib/SubscribeEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &swapLibSubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SwapLib_SubscribeEventsClient interface {
+	Recv() (*SwapEvent, error)
+	grpc.ClientStream
+}
+
+type swapLibSubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *swapLibSubscribeEventsClient) Recv() (*SwapEvent, error) {
+	m := new(SwapEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // SwapLibServer is the server API for SwapLib service.
 type SwapLibServer interface {
 	PingWalletRPC(context.Context, *PingWalletRPCRequest) (*PingWalletRPCResponse, error)
@@ -2023,6 +3113,8 @@ type SwapLibServer interface {
 	ExtractSecret(context.Context, *ExtractSecretRequest) (*ExtractSecretResponse, error)
 	Audit(context.Context, *AuditRequest) (*AuditResponse, error)
 	GetTx(context.Context, *GetTxRequest) (*GetTxResponse, error)
+	WatchSwap(*WatchSwapRequest, SwapLib_WatchSwapServer) error
+	SubscribeEvents(*SubscribeEventsRequest, SwapLib_SubscribeEventsServer) error
 }
 
 func RegisterSwapLibServer(s *grpc.Server, srv SwapLibServer) {
@@ -2209,6 +3301,48 @@ func _SwapLib_GetTx_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SwapLib_WatchSwap_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSwapRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SwapLibServer).WatchSwap(m, &swapLibWatchSwapServer{stream})
+}
+
+type SwapLib_WatchSwapServer interface {
+	Send(*SwapEvent) error
+	grpc.ServerStream
+}
+
+type swapLibWatchSwapServer struct {
+	grpc.ServerStream
+}
+
+func (x *swapLibWatchSwapServer) Send(m *SwapEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SwapLib_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SwapLibServer).SubscribeEvents(m, &swapLibSubscribeEventsServer{stream})
+}
+
+type SwapLib_SubscribeEventsServer interface {
+	Send(*SwapEvent) error
+	grpc.ServerStream
+}
+
+type swapLibSubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *swapLibSubscribeEventsServer) Send(m *SwapEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _SwapLib_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protobind.SwapLib",
 	HandlerType: (*SwapLibServer)(nil),
@@ -2254,6 +3388,17 @@ var _SwapLib_serviceDesc = grpc.ServiceDesc{
 			Handler:    _SwapLib_GetTx_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSwap",
+			Handler:       _SwapLib_WatchSwap_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _SwapLib_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "atomicswap.proto",
 }
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataKey is the gRPC metadata key the bearer token is sent in
+const MetadataKey = "authorization"
+
+const bearerPrefix = "Bearer "
+
+// MethodScopes are the scopes the SwapLib methods need. Methods not listed
+// are refused
+var MethodScopes = map[string]Scope{
+	"/protobind.SwapLib/PingWalletRPC":   ScopeRead,
+	"/protobind.SwapLib/Audit":           ScopeRead,
+	"/protobind.SwapLib/GetTx":           ScopeRead,
+	"/protobind.SwapLib/ExtractSecret":   ScopeRead,
+	"/protobind.SwapLib/WatchSwap":       ScopeRead,
+	"/protobind.SwapLib/SubscribeEvents": ScopeRead,
+	"/protobind.SwapLib/NewAddress":      ScopeSpend,
+	"/protobind.SwapLib/Initiate":        ScopeSpend,
+	"/protobind.SwapLib/Participate":     ScopeSpend,
+	"/protobind.SwapLib/Redeem":          ScopeSpend,
+	"/protobind.SwapLib/Refund":          ScopeSpend,
+	"/protobind.SwapLib/Publish":         ScopeSpend,
+}
+
+// UnaryServerInterceptor checks the bearer token of unary calls
+func UnaryServerInterceptor(store *Store) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		err := authorize(ctx, store, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor checks the bearer token of streaming calls
+func StreamServerInterceptor(store *Store) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := authorize(ss.Context(), store, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// authorize checks the call carries a token with the scope of the method
+func authorize(ctx context.Context, store *Store, method string) error {
+	scope, ok := MethodScopes[method]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "no scope for %s", method)
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md[MetadataKey]
+	if len(values) == 0 || !strings.HasPrefix(values[0], bearerPrefix) {
+		return status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	err := store.Check(strings.TrimPrefix(values[0], bearerPrefix), scope)
+	switch err {
+	case nil:
+		return nil
+	case ErrBadCredential:
+		return status.Error(codes.Unauthenticated, err.Error())
+	case ErrScope:
+		return status.Errorf(codes.PermissionDenied, "%s needs a %s token", method, scope)
+	}
+	logging.FromContext(ctx).Error("token store", libs.F("error", err))
+	return status.Error(codes.Internal, "token store unavailable")
+}
+
+// Credentials sends a bearer token with every call. It implements
+// grpc.PerRPCCredentials
+type Credentials struct {
+	Token string
+	// AllowInsecure allows the token to be sent without TLS
+	AllowInsecure bool
+}
+
+// GetRequestMetadata gets the token metadata
+func (c Credentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{MetadataKey: bearerPrefix + c.Token}, nil
+}
+
+// RequireTransportSecurity is true unless the token may be sent without TLS
+func (c Credentials) RequireTransportSecurity() bool {
+	return !c.AllowInsecure
+}
@@ -0,0 +1,252 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scope is a permission a token grants
+type Scope string
+
+// Token scopes
+const (
+	// ScopeRead allows the methods that only read: audit, get tx..
+	ScopeRead Scope = "read"
+	// ScopeSpend allows the methods that use the wallet to build, sign and
+	// publish transactions
+	ScopeSpend Scope = "spend"
+)
+
+// ParseScopes parses a comma separated list of scopes
+func ParseScopes(s string) ([]Scope, error) {
+	var scopes []Scope
+	for _, name := range strings.Split(s, ",") {
+		scope := Scope(strings.ToLower(strings.TrimSpace(name)))
+		switch scope {
+		case ScopeRead, ScopeSpend:
+			scopes = append(scopes, scope)
+		default:
+			return nil, fmt.Errorf("unknown scope %q", name)
+		}
+	}
+	return scopes, nil
+}
+
+// Token is a stored API token. Only the hash of the token secret is stored
+type Token struct {
+	ID      string    `json:"id"`
+	Label   string    `json:"label,omitempty"`
+	Scopes  []Scope   `json:"scopes"`
+	Hash    string    `json:"hash"` // hex sha256 of the secret
+	Created time.Time `json:"created"`
+	Revoked bool      `json:"revoked,omitempty"`
+}
+
+func (t *Token) has(scope Scope) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Errors checking a credential
+var (
+	ErrBadCredential = errors.New("unknown or revoked token")
+	ErrScope         = errors.New("token does not have the scope")
+)
+
+// Store is a file of API tokens. The file is reloaded when it changes so
+// tokens minted or revoked with the tokens command apply to a running server
+type Store struct {
+	path    string
+	mu      sync.Mutex
+	modTime time.Time
+	tokens  map[string]*Token
+}
+
+// OpenStore opens a token file. A missing file is an empty store
+func OpenStore(path string) (*Store, error) {
+	s := &Store{path: path, tokens: map[string]*Token{}}
+	err := s.reload()
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// reload reads the token file if it changed since it was last read
+func (s *Store) reload() error {
+	fi, err := os.Stat(s.path)
+	if os.IsNotExist(err) {
+		s.tokens = map[string]*Token{}
+		s.modTime = time.Time{}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.ModTime().Equal(s.modTime) {
+		return nil
+	}
+	b, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return err
+	}
+	var tokens []*Token
+	err = json.Unmarshal(b, &tokens)
+	if err != nil {
+		return fmt.Errorf("token file %s: %v", s.path, err)
+	}
+	s.tokens = make(map[string]*Token, len(tokens))
+	for _, t := range tokens {
+		s.tokens[t.ID] = t
+	}
+	s.modTime = fi.ModTime()
+	return nil
+}
+
+// save writes the token file
+func (s *Store) save() error {
+	tokens := s.list()
+	b, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), ".tokens")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(append(b, '\n'))
+	if err == nil {
+		err = tmp.Chmod(0600)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmp.Name(), s.path)
+	if err != nil {
+		return err
+	}
+	s.modTime = time.Time{} // read back on next use
+	return nil
+}
+
+func (s *Store) list() []*Token {
+	tokens := make([]*Token, 0, len(s.tokens))
+	for _, t := range s.tokens {
+		tokens = append(tokens, t)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Created.Before(tokens[j].Created)
+	})
+	return tokens
+}
+
+// List gets the tokens oldest first
+func (s *Store) List() ([]*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.reload()
+	if err != nil {
+		return nil, err
+	}
+	return s.list(), nil
+}
+
+// Mint makes a new token with the scopes and returns the credential clients
+// send. The credential is not stored and cannot be shown again
+func (s *Store) Mint(label string, scopes []Scope) (credential string, token *Token, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err = s.reload()
+	if err != nil {
+		return "", nil, err
+	}
+	id := make([]byte, 8)
+	secret := make([]byte, 32)
+	_, err = rand.Read(id)
+	if err == nil {
+		_, err = rand.Read(secret)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	hash := sha256.Sum256(secret)
+	token = &Token{
+		ID:      hex.EncodeToString(id),
+		Label:   label,
+		Scopes:  scopes,
+		Hash:    hex.EncodeToString(hash[:]),
+		Created: time.Now().UTC(),
+	}
+	s.tokens[token.ID] = token
+	err = s.save()
+	if err != nil {
+		return "", nil, err
+	}
+	return token.ID + "." + hex.EncodeToString(secret), token, nil
+}
+
+// Revoke revokes a token
+func (s *Store) Revoke(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.reload()
+	if err != nil {
+		return err
+	}
+	t, ok := s.tokens[id]
+	if !ok {
+		return fmt.Errorf("no token %s", id)
+	}
+	t.Revoked = true
+	return s.save()
+}
+
+// Check checks a credential is a live token with the scope
+func (s *Store) Check(credential string, scope Scope) error {
+	parts := strings.SplitN(credential, ".", 2)
+	if len(parts) != 2 {
+		return ErrBadCredential
+	}
+	secret, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return ErrBadCredential
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err = s.reload()
+	if err != nil {
+		return err
+	}
+	t, ok := s.tokens[parts[0]]
+	if !ok || t.Revoked {
+		return ErrBadCredential
+	}
+	hash := sha256.Sum256(secret)
+	if subtle.ConstantTimeCompare([]byte(hex.EncodeToString(hash[:])), []byte(t.Hash)) != 1 {
+		return ErrBadCredential
+	}
+	if !t.has(scope) {
+		return ErrScope
+	}
+	return nil
+}
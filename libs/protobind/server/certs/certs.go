@@ -0,0 +1,240 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Files in the certificate directory
+const (
+	CACertFile     = "ca.crt"
+	CAKeyFile      = "ca.key"
+	ServerCertFile = "server.crt"
+	ServerKeyFile  = "server.key"
+)
+
+const (
+	caValidity   = 10 * 365 * 24 * time.Hour
+	certValidity = 2 * 365 * 24 * time.Hour
+	organization = "atomicswap server"
+)
+
+// EnsureServerCert makes a self-signed CA and a server certificate for the
+// hosts, signed by the CA, in dir unless they exist. It returns the server
+// certificate and key paths
+func EnsureServerCert(dir string, hosts []string) (certPath, keyPath string, err error) {
+	certPath = filepath.Join(dir, ServerCertFile)
+	keyPath = filepath.Join(dir, ServerKeyFile)
+	if exists(certPath) && exists(keyPath) {
+		return certPath, keyPath, nil
+	}
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return "", "", err
+	}
+	if !exists(filepath.Join(dir, CACertFile)) {
+		err = newCA(dir)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	template := newTemplate("atomicswap server", certValidity)
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else if h != "" {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+	err = issue(dir, template, certPath, keyPath)
+	if err != nil {
+		return "", "", err
+	}
+	return certPath, keyPath, nil
+}
+
+// IssueClientCert issues a client certificate for name, signed by the CA in
+// dir, for servers requiring client certificates. It returns the certificate
+// and key paths
+func IssueClientCert(dir, name string) (certPath, keyPath string, err error) {
+	certPath = filepath.Join(dir, name+".crt")
+	keyPath = filepath.Join(dir, name+".key")
+	if exists(certPath) || exists(keyPath) {
+		return "", "", fmt.Errorf("client certificate %s exists", certPath)
+	}
+	template := newTemplate(name, certValidity)
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	err = issue(dir, template, certPath, keyPath)
+	if err != nil {
+		return "", "", err
+	}
+	return certPath, keyPath, nil
+}
+
+// CertPool reads a pool of PEM certificates from path
+func CertPool(path string) (*x509.CertPool, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, fmt.Errorf("no certificates in %s", path)
+	}
+	return pool, nil
+}
+
+// ServerTLSConfig makes the server TLS configuration. If clientCAPath is set
+// client certificates signed by its CAs are verified and, with
+// requireClientCert, required
+func ServerTLSConfig(certPath, keyPath, clientCAPath string, requireClientCert bool) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if requireClientCert && clientCAPath == "" {
+		return nil, errors.New("client certificates required without a client CA")
+	}
+	if clientCAPath != "" {
+		config.ClientCAs, err = CertPool(clientCAPath)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+		if requireClientCert {
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+	return config, nil
+}
+
+// ClientTLSConfig makes a client TLS configuration trusting the CAs in caPath
+// and, if certPath is set, presenting a client certificate
+func ClientTLSConfig(caPath, certPath, keyPath, serverName string) (*tls.Config, error) {
+	pool, err := CertPool(caPath)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		RootCAs:    pool,
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if certPath != "" {
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// newCA makes the self-signed CA in dir
+func newCA(dir string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	template := newTemplate("atomicswap CA", caValidity)
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return err
+	}
+	err = writeKey(filepath.Join(dir, CAKeyFile), key)
+	if err != nil {
+		return err
+	}
+	return writePEM(filepath.Join(dir, CACertFile), "CERTIFICATE", der, 0644)
+}
+
+// issue makes a key and a certificate from template signed by the CA in dir
+func issue(dir string, template *x509.Certificate, certPath, keyPath string) error {
+	ca, err := tls.LoadX509KeyPair(filepath.Join(dir, CACertFile), filepath.Join(dir, CAKeyFile))
+	if err != nil {
+		return fmt.Errorf("certificate authority: %v", err)
+	}
+	caCert, err := x509.ParseCertificate(ca.Certificate[0])
+	if err != nil {
+		return err
+	}
+	signer, ok := ca.PrivateKey.(crypto.Signer)
+	if !ok {
+		return errors.New("certificate authority: key cannot sign")
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), signer)
+	if err != nil {
+		return err
+	}
+	err = writeKey(keyPath, key)
+	if err != nil {
+		return err
+	}
+	return writePEM(certPath, "CERTIFICATE", der, 0644)
+}
+
+func newTemplate(commonName string, validity time.Duration) *x509.Certificate {
+	serial, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization: []string{organization},
+			CommonName:   commonName,
+		},
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(validity),
+	}
+}
+
+func writeKey(path string, key *ecdsa.PrivateKey) error {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	return writePEM(path, "EC PRIVATE KEY", der, 0600)
+}
+
+func writePEM(path, blockType string, der []byte, perm os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	err = pem.Encode(f, &pem.Block{Type: blockType, Bytes: der})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
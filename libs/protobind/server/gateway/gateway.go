@@ -0,0 +1,113 @@
+// Package gateway serves the SwapLib gRPC service as JSON over HTTP. The
+// routes are generated from the google.api.http annotations in
+// atomicswap.proto (atomicswap.pb.gw.go) and described in
+// atomicswap.swagger.json.
+//
+// HTTP requests are proxied to a gRPC server on an in-memory connection so
+// they pass the same interceptors as gRPC clients. The Authorization header
+// is forwarded as the bearer token and the traceparent header continues a
+// client's trace. The HTTP listener uses the gRPC server's TLS certificates
+// and client certificate checks
+package gateway
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/certs"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// bufSize is the buffer of the in-memory gRPC connection
+const bufSize = 1 << 20
+
+// Gateway is the JSON over HTTP server
+type Gateway struct {
+	grpcServer *grpc.Server
+	grpcLis    *bufconn.Listener
+	conn       *grpc.ClientConn
+	httpServer *http.Server
+}
+
+// New makes a gateway for the service. opts are the gRPC server options other
+// than the transport credentials, such as the auth and telemetry interceptors
+func New(service bnd.SwapLibServer, opts ...grpc.ServerOption) (*Gateway, error) {
+	g := &Gateway{
+		grpcServer: grpc.NewServer(opts...),
+		grpcLis:    bufconn.Listen(bufSize),
+	}
+	bnd.RegisterSwapLibServer(g.grpcServer, service)
+	go g.grpcServer.Serve(g.grpcLis)
+
+	dial := func(context.Context, string) (net.Conn, error) {
+		return g.grpcLis.Dial()
+	}
+	conn, err := grpc.DialContext(context.Background(), "gateway",
+		grpc.WithContextDialer(dial), grpc.WithInsecure())
+	if err != nil {
+		g.grpcServer.Stop()
+		return nil, err
+	}
+	g.conn = conn
+
+	mux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard,
+		&runtime.JSONPb{OrigName: true, EmitDefaults: true}),
+		runtime.WithIncomingHeaderMatcher(headerMatcher))
+	err = bnd.RegisterSwapLibHandler(context.Background(), mux, conn)
+	if err != nil {
+		g.Stop()
+		return nil, err
+	}
+	g.httpServer = &http.Server{Handler: mux}
+	return g, nil
+}
+
+// headerMatcher forwards the W3C trace context and request ID headers to the
+// gRPC server as well as the default headers, so HTTP requests may continue a
+// trace and name their request ID
+func headerMatcher(key string) (string, bool) {
+	switch strings.ToLower(key) {
+	case "traceparent", "tracestate", "x-request-id":
+		return strings.ToLower(key), true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// Serve serves HTTP on lis until Stop
+func (g *Gateway) Serve(lis net.Listener) error {
+	err := g.httpServer.Serve(lis)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// ServeTLS serves HTTPS on lis until Stop. The TLS settings are those of the
+// gRPC server
+func (g *Gateway) ServeTLS(lis net.Listener, certPath, keyPath, clientCAPath string, requireClientCert bool) error {
+	config, err := certs.ServerTLSConfig(certPath, keyPath, clientCAPath, requireClientCert)
+	if err != nil {
+		return err
+	}
+	g.httpServer.TLSConfig = config
+	err = g.httpServer.ServeTLS(lis, "", "")
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// Stop stops the HTTP server, waiting for the requests in flight, and the
+// gRPC server behind it
+func (g *Gateway) Stop() {
+	if g.httpServer != nil {
+		g.httpServer.Shutdown(context.Background())
+	}
+	g.conn.Close()
+	g.grpcServer.GracefulStop()
+}
@@ -0,0 +1,36 @@
+package main
+
+// issuecert issues a client certificate signed by the server CA for servers
+// with require_client_cert:
+//
+//	issuecert -dir tls -name svrtest
+//
+// writes tls/svrtest.crt and tls/svrtest.key. The CA is made by the server
+// on first start in [server] tls_dir
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/certs"
+)
+
+var (
+	dirFlag  = flag.String("dir", "tls", "certificate directory - [server] tls_dir of the server config.ini")
+	nameFlag = flag.String("name", "", "client name - the certificate common name and file name")
+)
+
+func main() {
+	flag.Parse()
+	if *nameFlag == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	certPath, keyPath, err := certs.IssueClientCert(*dirFlag, *nameFlag)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	fmt.Printf("client certificate %s\nclient key %s\n", certPath, keyPath)
+}
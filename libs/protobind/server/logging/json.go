@@ -0,0 +1,75 @@
+// Package logging has the server's JSON log output and the request logs. Each
+// SwapLib request gets a request ID, taken from the client's x-request-id
+// metadata (or HTTP header) or made here, and returned in the x-request-id
+// response header. The request's log has the request ID, RPC method, coin and
+// network fields and is passed to the swap libs in the RPCInfo, so the lib
+// records of a request can be found by its ID
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// JSONLogger writes records as JSON objects, one a line, with the time, level
+// and message and the fields as keys. It implements libs.Logger
+type JSONLogger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level libs.Level
+}
+
+// NewJSONLogger makes a JSON logger writing the records of level and above to w
+func NewJSONLogger(w io.Writer, level libs.Level) *JSONLogger {
+	return &JSONLogger{w: w, level: level}
+}
+
+// Log writes a record
+func (j *JSONLogger) Log(level libs.Level, msg string, fields []libs.Field) {
+	if level < j.level {
+		return
+	}
+	record := make(map[string]interface{}, len(fields)+3)
+	for _, f := range fields {
+		record[f.Key] = jsonValue(f.Value)
+	}
+	record["time"] = time.Now().UTC().Format(time.RFC3339Nano)
+	record["level"] = level.String()
+	record["msg"] = msg
+	line, err := json.Marshal(record)
+	if err != nil {
+		line, _ = json.Marshal(map[string]string{
+			"time":  record["time"].(string),
+			"level": level.String(),
+			"msg":   msg,
+			"error": fmt.Sprintf("log fields: %v", err),
+		})
+	}
+	j.mu.Lock()
+	j.w.Write(append(line, '\n'))
+	j.mu.Unlock()
+}
+
+// jsonValue gets the JSON value of a field. Errors and Stringers are logged
+// as their text
+func jsonValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil, string, bool, int, int32, int64, uint, uint32, uint64, float32, float64:
+		return v
+	case time.Duration:
+		return v.String()
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	}
+	if _, err := json.Marshal(v); err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return v
+}
@@ -0,0 +1,150 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/rpcerr"
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/wallets"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDKey is the metadata key of the request ID
+const RequestIDKey = "x-request-id"
+
+// maxRequestIDLen is the longest client request ID used. Longer or unprintable
+// ones are replaced
+const maxRequestIDLen = 64
+
+// coinRequest is a request for a coin's wallet on a network
+type coinRequest interface {
+	GetCoin() bnd.COIN
+	GetNetwork() bnd.NETWORK
+	GetTestnet() bool
+}
+
+type logKey struct{}
+
+// FromContext gets the log of a request. Outside a request it is the server log
+func FromContext(ctx context.Context) *libs.Log {
+	log, ok := ctx.Value(logKey{}).(*libs.Log)
+	if !ok {
+		return libs.NewLog(nil)
+	}
+	return log
+}
+
+// UnaryServerInterceptor gives each unary SwapLib request an ID and a log and
+// logs its result
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	id := requestID(ctx)
+	grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+	log := requestLog(id, info.FullMethod, req)
+	start := time.Now()
+	resp, err := handler(context.WithValue(ctx, logKey{}, log), req)
+	logResult(log, start, resp, err)
+	return resp, err
+}
+
+// StreamServerInterceptor gives each SwapLib stream an ID and a log and logs
+// its result. The stream request is not read here so the coin and network
+// are logged by the handler
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	id := requestID(ss.Context())
+	ss.SetHeader(metadata.Pairs(RequestIDKey, id))
+	log := requestLog(id, info.FullMethod, nil)
+	log.Info("stream started")
+	start := time.Now()
+	ctx := context.WithValue(ss.Context(), logKey{}, log)
+	err := handler(srv, &loggedStream{ServerStream: ss, ctx: ctx})
+	logResult(log, start, nil, err)
+	return err
+}
+
+// loggedStream is a server stream with the context of its log
+type loggedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggedStream) Context() context.Context {
+	return s.ctx
+}
+
+// RequestFields gets the coin and network log fields of a request
+func RequestFields(req interface{}) []libs.Field {
+	r, ok := req.(coinRequest)
+	if !ok {
+		return nil
+	}
+	network := wallets.RequestNetwork(r.GetNetwork(), r.GetTestnet())
+	return []libs.Field{libs.F("coin", r.GetCoin().String()), libs.F("network", network.String())}
+}
+
+// requestLog makes the log of a request
+func requestLog(id, fullMethod string, req interface{}) *libs.Log {
+	fields := []libs.Field{
+		libs.F("request_id", id),
+		libs.F("method", fullMethod[strings.LastIndex(fullMethod, "/")+1:]),
+	}
+	fields = append(fields, RequestFields(req)...)
+	return libs.NewLog(nil, fields...)
+}
+
+// errstrResponse is a response with the errno_payload error string
+type errstrResponse interface {
+	GetErrstr() string
+}
+
+// logResult logs the result of a request: info for success and warn for a
+// failure
+func logResult(log *libs.Log, start time.Time, resp interface{}, err error) {
+	code := rpcerr.ResultCode(resp, err)
+	fields := []libs.Field{
+		libs.F("code", code),
+		libs.F("duration_ms", time.Since(start).Milliseconds()),
+	}
+	if err != nil {
+		fields = append(fields, libs.F("error", err))
+	} else if r, ok := resp.(errstrResponse); ok && r.GetErrstr() != "" {
+		fields = append(fields, libs.F("error", r.GetErrstr()))
+	}
+	if code == codes.OK.String() {
+		log.Info("request", fields...)
+		return
+	}
+	log.Warn("request failed", fields...)
+}
+
+// requestID gets the client's request ID or makes one
+func requestID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(RequestIDKey); len(ids) > 0 && validRequestID(ids[0]) {
+		return ids[0]
+	}
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// validRequestID checks a client request ID is short and printable
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for _, c := range id {
+		if c < 0x21 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
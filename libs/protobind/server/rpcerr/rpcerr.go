@@ -0,0 +1,195 @@
+// Package rpcerr makes gRPC status errors of the wallet and swap lib errors.
+// The lib errors are mostly wrapped node RPC errors so they are classified
+// by their message. Every status has an ErrorInfo detail with the reason and
+// the coin and network of the request. An invalid request field is named in
+// a BadRequest detail and a wallet without the funds for a contract reports
+// the required and available amounts in the ErrorInfo metadata
+package rpcerr
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain is the ErrorInfo domain of the swap server errors
+const Domain = "atomicswap"
+
+// ErrorInfo reasons
+const (
+	ReasonUnsupportedCoin   = "UNSUPPORTED_COIN"
+	ReasonUnsupportedNet    = "UNSUPPORTED_NETWORK"
+	ReasonProfile           = "PROFILE"
+	ReasonInvalidArgument   = "INVALID_ARGUMENT"
+	ReasonNodeUnavailable   = "NODE_UNAVAILABLE"
+	ReasonWrongChain        = "WRONG_CHAIN"
+	ReasonInsufficientFunds = "INSUFFICIENT_FUNDS"
+	ReasonWalletLocked      = "WALLET_LOCKED"
+	ReasonDust              = "DUST"
+	ReasonTxRejected        = "TX_REJECTED"
+	ReasonNotFound          = "NOT_FOUND"
+	ReasonServerConfig      = "SERVER_CONFIG"
+	ReasonLibs              = "LIBS"
+)
+
+// Request is the coin and network of the failed request
+type Request struct {
+	Coin    string
+	Network string
+}
+
+// rule classifies the errors with any of the match strings in their lower
+// case message
+type rule struct {
+	match  []string
+	code   codes.Code
+	reason string
+	field  string
+}
+
+// rules are tried in order so the more specific come first
+var rules = []rule{
+	// request fields
+	{[]string{"unsupported coin"}, codes.InvalidArgument, ReasonUnsupportedCoin, "coin"},
+	{[]string{"no coin definition"}, codes.NotFound, ReasonUnsupportedCoin, "coin_def"},
+	{[]string{"eth keystore"}, codes.InvalidArgument, ReasonInvalidArgument, "certs"},
+	{[]string{"unknown wallet profile"}, codes.NotFound, ReasonProfile, "profile"},
+	{[]string{"wallet profile"}, codes.InvalidArgument, ReasonProfile, "profile"},
+	{[]string{"sealed secret", "profile key"}, codes.Internal, ReasonServerConfig, ""},
+	{[]string{"failed to decode contract transaction", "contract transaction is for chain",
+		"does not contain the contract output", "does not contain a contract output",
+		"does not contain a p2sh contract payment"}, codes.InvalidArgument, ReasonInvalidArgument, "contract_tx"},
+	{[]string{"failed to decode contract", "not an atomic swap script", "strange secret size",
+		"contract has wrong size"}, codes.InvalidArgument, ReasonInvalidArgument, "contract"},
+	{[]string{"failed to decode secret", "secret has wrong size",
+		"secret does not hash to the contract secret hash"}, codes.InvalidArgument, ReasonInvalidArgument, "secret"},
+	{[]string{"secret hash"}, codes.InvalidArgument, ReasonInvalidArgument, "secrethash"},
+	{[]string{"participant address"}, codes.InvalidArgument, ReasonInvalidArgument, "part_address"},
+	{[]string{"initiator address"}, codes.InvalidArgument, ReasonInvalidArgument, "init_address"},
+	{[]string{"redemption transaction"}, codes.InvalidArgument, ReasonInvalidArgument, "cp_redemption_tx"},
+	{[]string{"broadcast transaction", "-22:"}, codes.InvalidArgument, ReasonInvalidArgument, "tx"},
+	{[]string{"invalid transaction hash", "txid: bad length"}, codes.InvalidArgument, ReasonInvalidArgument, "txid"},
+	{[]string{" amount", "amount out of range"}, codes.InvalidArgument, ReasonInvalidArgument, "amount"},
+	{[]string{"invalid fee rate"}, codes.InvalidArgument, ReasonInvalidArgument, "fee_per_kb"},
+	{[]string{"required confirmations"}, codes.InvalidArgument, ReasonInvalidArgument, "required_confs"},
+	{[]string{"needs an account name"}, codes.InvalidArgument, ReasonInvalidArgument, "account"},
+	{[]string{"is not supported"}, codes.InvalidArgument, ReasonUnsupportedNet, "network"},
+	{[]string{"-8:", "invalid address"}, codes.InvalidArgument, ReasonInvalidArgument, ""},
+
+	// wallet and chain state
+	{[]string{"insufficient funds", "insufficient balance"}, codes.FailedPrecondition, ReasonInsufficientFunds, ""},
+	{[]string{"node is on chain", "wallet is on", "not intended for use on", "ping of a testnet wallet"},
+		codes.FailedPrecondition, ReasonWrongChain, ""},
+	{[]string{"-13:", "-14:", "passphrase", "wallet is locked", "unlock"}, codes.FailedPrecondition, ReasonWalletLocked, ""},
+	{[]string{"is dust"}, codes.FailedPrecondition, ReasonDust, ""},
+	{[]string{"-25:", "-26:", "-27:", "non-final", "missing inputs", "already spent", "already known"},
+		codes.FailedPrecondition, ReasonTxRejected, ""},
+	{[]string{"no information available about transaction", "invalid or non-wallet transaction id",
+		"transaction not found", "does not contain the secret", "not found"}, codes.NotFound, ReasonNotFound, ""},
+
+	// node connection
+	{[]string{"connection refused", "no such host", "i/o timeout", "connection reset", "rpc connect",
+		"grpc dial", "dial tcp", "eof", "deadline exceeded", "code = unavailable", "status code: 5",
+		"-28:", "loading block index", "client has been shutdown", "transport is closing"},
+		codes.Unavailable, ReasonNodeUnavailable, ""},
+}
+
+// Error makes a status error of a wallet or lib error
+func Error(err error, request Request) error {
+	return Status(err, request).Err()
+}
+
+// InvalidArgument makes a status error for an invalid request field
+func InvalidArgument(field, description string) error {
+	st := status.New(codes.InvalidArgument, description)
+	withDetails, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: description},
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// Status makes a status of a wallet or lib error. A status error with details,
+// made here, is kept as it is. Others, such as dcrwallet gRPC errors, are
+// classified like any error
+func Status(err error, request Request) *status.Status {
+	if st, ok := status.FromError(err); ok && len(st.Details()) > 0 {
+		return st
+	}
+	code, reason, field := classify(err)
+	info := &errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: Domain,
+		Metadata: map[string]string{
+			"coin":    request.Coin,
+			"network": request.Network,
+		},
+	}
+	var fundsErr *libs.FundsError
+	if errors.As(err, &fundsErr) {
+		info.Metadata["required"] = strconv.FormatInt(fundsErr.Required, 10)
+		if fundsErr.Available >= 0 {
+			info.Metadata["available"] = strconv.FormatInt(fundsErr.Available, 10)
+		}
+	}
+	st := status.New(code, err.Error())
+	details := []proto.Message{info}
+	if field != "" {
+		details = append(details, &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: field, Description: err.Error()},
+			},
+		})
+	}
+	withDetails, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		return st
+	}
+	return withDetails
+}
+
+// errnoResponse is a response with the errno_payload error fields
+type errnoResponse interface {
+	GetErrorno() bnd.ERRNO
+}
+
+// ResultCode gets the result of a request for logs and metrics: the gRPC
+// status code or, for a failed errno_payload response, its ERRNO
+func ResultCode(resp interface{}, err error) string {
+	if err != nil {
+		return status.Code(err).String()
+	}
+	if r, ok := resp.(errnoResponse); ok && r.GetErrorno() != bnd.ERRNO_OK {
+		return r.GetErrorno().String()
+	}
+	return codes.OK.String()
+}
+
+// classify gets the status code, ErrorInfo reason and any request field of
+// an error
+func classify(err error) (codes.Code, string, string) {
+	var fundsErr *libs.FundsError
+	if errors.As(err, &fundsErr) {
+		return codes.FailedPrecondition, ReasonInsufficientFunds, "amount"
+	}
+	msg := strings.ToLower(err.Error())
+	for _, r := range rules {
+		for _, m := range r.match {
+			if strings.Contains(msg, m) {
+				return r.code, r.reason, r.field
+			}
+		}
+	}
+	return codes.Unknown, ReasonLibs, ""
+}
@@ -0,0 +1,61 @@
+package main
+
+// sealsecret makes the profile key of the server and seals wallet profile
+// secrets with it for config.ini:
+//
+//	sealsecret -newkey /path/profile.key
+//	echo -n "rpcpassword" | sealsecret -key /path/profile.key
+//
+// The sealed value (enc:...) goes in a profile's rpcpass or wpass and the key
+// path in [server] profile_key_file
+
+import (
+	"bufio"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/wallets"
+)
+
+var (
+	newKeyFlag = flag.String("newkey", "", "write a new profile key to this file")
+	keyFlag    = flag.String("key", "", "seal the secret read from stdin with the profile key in this file")
+)
+
+func main() {
+	flag.Parse()
+	switch {
+	case *newKeyFlag != "":
+		if _, err := os.Stat(*newKeyFlag); err == nil {
+			log.Fatalf("%s exists", *newKeyFlag)
+		}
+		key, err := wallets.NewProfileKey()
+		if err != nil {
+			log.Fatalln(err)
+		}
+		err = ioutil.WriteFile(*newKeyFlag, []byte(key+"\n"), 0600)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	case *keyFlag != "":
+		key, err := wallets.ReadProfileKey(*keyFlag)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		secret, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && secret == "" {
+			log.Fatalf("read secret: %v", err)
+		}
+		sealed, err := wallets.SealSecret(strings.TrimRight(secret, "\r\n"), key)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		os.Stdout.WriteString(sealed + "\n")
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
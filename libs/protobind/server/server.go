@@ -2,48 +2,99 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
 	"fmt"
-	"log"
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"reflect"
 	"runtime"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"github.com/devwarrior777/atomicswap/libs"
 	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/auth"
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/certs"
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/gateway"
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/logging"
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/rpcerr"
 	"github.com/devwarrior777/atomicswap/libs/protobind/server/svrcfg"
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/telemetry"
 	"github.com/devwarrior777/atomicswap/libs/protobind/server/wallets"
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/watch"
+	"github.com/devwarrior777/atomicswap/libs/utxo"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
-// Configuration
-var (
-	pidFile     = svrcfg.Config.PidFile
-	tls         = svrcfg.Config.UseTLS
-	certPath    = svrcfg.Config.CertPath
-	certKeyPath = svrcfg.Config.CertKeyPath
-	serverAddr  = svrcfg.Config.ServerAddr
-	serverPort  = svrcfg.Config.ServerPort
-)
+// configFile is the config file, by default the file named by
+// ATOMICSWAP_CONFIG or else config.ini
+var configFile = flag.String("config", "", "config file (default $"+svrcfg.EnvFile+" or "+svrcfg.DefaultFile+")")
+
+// cfg is the configuration the server started with. The listeners, TLS, auth
+// and telemetry are set up from it and change only on restart
+var cfg *svrcfg.ServerConfig
+
+// current holds the *svrcfg.ServerConfig last loaded, replaced on SIGHUP. The
+// reloadable settings are read from it
+var current atomic.Value
+
+// config gets the configuration last loaded
+func config() *svrcfg.ServerConfig {
+	return current.Load().(*svrcfg.ServerConfig)
+}
+
+// TLS files in use: those configured or made in tls_dir
+var certPath, certKeyPath, clientCA string
+
+// server log. Records are JSON from startLogging
+var logger = libs.NewLog(nil)
 
 // gRPC server instance
 var grpcServer *grpc.Server
 
+// JSON over HTTP gateway instance, if it has listeners
+var jsonGateway *gateway.Gateway
+
+// Prometheus metrics server instance, if it has listeners
+var metricsServer *telemetry.MetricsServer
+
+// stopTracing flushes the spans to the collector, if otlp_endpoint is set
+var stopTracing func(context.Context) error
+
+// monitor watches the chains for the swap event streams
+var monitor *watch.Monitor
+
 // swapLibServer implements swapLibServer
 type swapLibServer struct {
 }
 
-///////////////////////////////////
-// Only meta response will error //
-///////////////////////////////////
+///////////////////
+// Swap Commands //
+///////////////////
+
+// replyError gets the error a handler returns for a failed request: a gRPC
+// status error with codes and details or, with errno_payload, nil as the
+// error is in the response ERRNO and errstr fields
+func replyError(err error, coin bnd.COIN, network libs.Network) error {
+	if config().ErrnoPayload {
+		return nil
+	}
+	return rpcerr.Error(err, rpcerr.Request{Coin: coin.String(), Network: network.String()})
+}
 
 // PingWalletRPC pings the wallet node RPC client to establish if the node is running
 func (s *swapLibServer) PingWalletRPC(ctx context.Context, request *bnd.PingWalletRPCRequest) (*bnd.PingWalletRPCResponse, error) {
-	log.Printf("PingWalletRPC\n")
 	response := &bnd.PingWalletRPCResponse{Errorno: bnd.ERRNO_OK}
 	// get wallet
 	rpcinfo := libs.RPCInfo{}
@@ -52,24 +103,40 @@ func (s *swapLibServer) PingWalletRPC(ctx context.Context, request *bnd.PingWall
 	rpcinfo.Pass = request.Rpcpass
 	rpcinfo.WalletPass = request.Wpass
 	rpcinfo.Certs = request.Certs
-	wallet, err := wallets.WalletForCoin(request.Testnet, rpcinfo, request.Coin)
+	rpcinfo.JSONRPC = request.Jsonrpc
+	trace := telemetry.NewTrace(ctx, request.Coin)
+	rpcinfo.Observer = trace.Observe
+	rpcinfo.Log = logging.FromContext(ctx)
+	network := wallets.RequestNetwork(request.Network, request.Testnet)
+	wallet, err := wallets.WalletForCoin(network, rpcinfo, request.Coin, request.CoinDef, request.Profile)
 	if err != nil {
 		response.Errorno = bnd.ERRNO_UNSUPPORTED
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
+	wallet = trace.Wallet(wallet)
 	// ping wallet
-	err = wallet.PingRPC()
+	result, err := wallet.PingRPC()
 	if err != nil {
 		response.Errorno = bnd.ERRNO_LIBS
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
+	}
+	response.Chain = result.Chain
+	response.Blocks = result.Blocks
+	response.Version = result.Version
+	response.UserAgent = result.UserAgent
+	response.ProtocolVersion = result.ProtocolVersion
+	response.Capabilities = &bnd.Capabilities{
+		Estimatesmartfee: result.EstimateSmartFee,
+		FundFeeRate:      result.FundFeeRate,
+		ChangeType:       result.ChangeType,
+		DynamicFees:      result.DynamicFees,
 	}
 	return response, nil
 }
 
 func (s *swapLibServer) NewAddress(ctx context.Context, request *bnd.NewAddressRequest) (*bnd.NewAddressResponse, error) {
-	log.Printf("NewAddress\n")
 	response := &bnd.NewAddressResponse{Errorno: bnd.ERRNO_OK}
 	// get wallet
 	rpcinfo := libs.RPCInfo{}
@@ -78,25 +145,30 @@ func (s *swapLibServer) NewAddress(ctx context.Context, request *bnd.NewAddressR
 	rpcinfo.Pass = request.Rpcpass
 	rpcinfo.WalletPass = request.Wpass
 	rpcinfo.Certs = request.Certs
-	wallet, err := wallets.WalletForCoin(request.Testnet, rpcinfo, request.Coin)
+	rpcinfo.JSONRPC = request.Jsonrpc
+	trace := telemetry.NewTrace(ctx, request.Coin)
+	rpcinfo.Observer = trace.Observe
+	rpcinfo.Log = logging.FromContext(ctx)
+	network := wallets.RequestNetwork(request.Network, request.Testnet)
+	wallet, err := wallets.WalletForCoin(network, rpcinfo, request.Coin, request.CoinDef, request.Profile)
 	if err != nil {
 		response.Errorno = bnd.ERRNO_UNSUPPORTED
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
+	wallet = trace.Wallet(wallet)
 	// get new address
 	address, err := wallet.GetNewAddress()
 	if err != nil {
 		response.Errorno = bnd.ERRNO_LIBS
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
 	response.Address = address
 	return response, nil
 }
 
 func (s *swapLibServer) Initiate(ctx context.Context, request *bnd.InitiateRequest) (*bnd.InitiateResponse, error) {
-	log.Printf("Initiate\n")
 	response := &bnd.InitiateResponse{Errorno: bnd.ERRNO_OK}
 	// get wallet
 	rpcinfo := libs.RPCInfo{}
@@ -105,22 +177,31 @@ func (s *swapLibServer) Initiate(ctx context.Context, request *bnd.InitiateReque
 	rpcinfo.Pass = request.Rpcpass
 	rpcinfo.WalletPass = request.Wpass
 	rpcinfo.Certs = request.Certs
-	wallet, err := wallets.WalletForCoin(request.Testnet, rpcinfo, request.Coin)
+	rpcinfo.JSONRPC = request.Jsonrpc
+	trace := telemetry.NewTrace(ctx, request.Coin)
+	rpcinfo.Observer = trace.Observe
+	rpcinfo.Log = logging.FromContext(ctx)
+	network := wallets.RequestNetwork(request.Network, request.Testnet)
+	wallet, err := wallets.WalletForCoin(network, rpcinfo, request.Coin, request.CoinDef, request.Profile)
 	if err != nil {
 		response.Errorno = bnd.ERRNO_UNSUPPORTED
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
+	wallet = trace.Wallet(wallet)
 	// initiate
 	params := libs.InitiateParams{}
 	params.SecretHash = request.Secrethash
 	params.CP2Addr = request.PartAddress
 	params.CP2Amount = request.Amount
+	params.Account = request.Account
+	params.RequiredConfs = request.RequiredConfs
+	params.FeePerKb = request.FeePerKb
 	result, err := wallet.Initiate(params)
 	if err != nil {
 		response.Errorno = bnd.ERRNO_LIBS
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
 	response.Contract = result.Contract
 	response.ContractP2Sh = result.ContractP2SH
@@ -133,7 +214,6 @@ func (s *swapLibServer) Initiate(ctx context.Context, request *bnd.InitiateReque
 }
 
 func (s *swapLibServer) Participate(ctx context.Context, request *bnd.ParticipateRequest) (*bnd.ParticipateResponse, error) {
-	log.Printf("Participate\n")
 	response := &bnd.ParticipateResponse{Errorno: bnd.ERRNO_OK}
 	// get wallet
 	rpcinfo := libs.RPCInfo{}
@@ -142,22 +222,31 @@ func (s *swapLibServer) Participate(ctx context.Context, request *bnd.Participat
 	rpcinfo.Pass = request.Rpcpass
 	rpcinfo.WalletPass = request.Wpass
 	rpcinfo.Certs = request.Certs
-	wallet, err := wallets.WalletForCoin(request.Testnet, rpcinfo, request.Coin)
+	rpcinfo.JSONRPC = request.Jsonrpc
+	trace := telemetry.NewTrace(ctx, request.Coin)
+	rpcinfo.Observer = trace.Observe
+	rpcinfo.Log = logging.FromContext(ctx)
+	network := wallets.RequestNetwork(request.Network, request.Testnet)
+	wallet, err := wallets.WalletForCoin(network, rpcinfo, request.Coin, request.CoinDef, request.Profile)
 	if err != nil {
 		response.Errorno = bnd.ERRNO_UNSUPPORTED
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
+	wallet = trace.Wallet(wallet)
 	// participate
 	params := libs.ParticipateParams{}
 	params.SecretHash = request.Secrethash
 	params.CP1Addr = request.InitAddress
 	params.CP1Amount = request.Amount
+	params.Account = request.Account
+	params.RequiredConfs = request.RequiredConfs
+	params.FeePerKb = request.FeePerKb
 	result, err := wallet.Participate(params)
 	if err != nil {
 		response.Errorno = bnd.ERRNO_LIBS
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
 	response.Contract = result.Contract
 	response.ContractP2Sh = result.ContractP2SH
@@ -170,7 +259,6 @@ func (s *swapLibServer) Participate(ctx context.Context, request *bnd.Participat
 }
 
 func (s *swapLibServer) Redeem(ctx context.Context, request *bnd.RedeemRequest) (*bnd.RedeemResponse, error) {
-	log.Printf("Redeem\n")
 	response := &bnd.RedeemResponse{Errorno: bnd.ERRNO_OK}
 	// get wallet
 	rpcinfo := libs.RPCInfo{}
@@ -179,22 +267,30 @@ func (s *swapLibServer) Redeem(ctx context.Context, request *bnd.RedeemRequest)
 	rpcinfo.Pass = request.Rpcpass
 	rpcinfo.WalletPass = request.Wpass
 	rpcinfo.Certs = request.Certs
-	wallet, err := wallets.WalletForCoin(request.Testnet, rpcinfo, request.Coin)
+	rpcinfo.JSONRPC = request.Jsonrpc
+	trace := telemetry.NewTrace(ctx, request.Coin)
+	rpcinfo.Observer = trace.Observe
+	rpcinfo.Log = logging.FromContext(ctx)
+	network := wallets.RequestNetwork(request.Network, request.Testnet)
+	wallet, err := wallets.WalletForCoin(network, rpcinfo, request.Coin, request.CoinDef, request.Profile)
 	if err != nil {
 		response.Errorno = bnd.ERRNO_UNSUPPORTED
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
+	wallet = trace.Wallet(wallet)
 	// redeem
 	params := libs.RedeemParams{}
 	params.Secret = request.Secret
 	params.Contract = request.Contract
 	params.ContractTx = request.ContractTx
+	params.Account = request.Account
+	params.FeePerKb = request.FeePerKb
 	result, err := wallet.Redeem(params)
 	if err != nil {
 		response.Errorno = bnd.ERRNO_LIBS
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
 	response.RedeemTx = result.RedeemTx
 	response.RedeemTxHash = result.RedeemTxHash
@@ -204,7 +300,6 @@ func (s *swapLibServer) Redeem(ctx context.Context, request *bnd.RedeemRequest)
 }
 
 func (s *swapLibServer) Refund(ctx context.Context, request *bnd.RefundRequest) (*bnd.RefundResponse, error) {
-	log.Printf("Refund\n")
 	response := &bnd.RefundResponse{Errorno: bnd.ERRNO_OK}
 	// get wallet
 	rpcinfo := libs.RPCInfo{}
@@ -213,21 +308,29 @@ func (s *swapLibServer) Refund(ctx context.Context, request *bnd.RefundRequest)
 	rpcinfo.Pass = request.Rpcpass
 	rpcinfo.WalletPass = request.Wpass
 	rpcinfo.Certs = request.Certs
-	wallet, err := wallets.WalletForCoin(request.Testnet, rpcinfo, request.Coin)
+	rpcinfo.JSONRPC = request.Jsonrpc
+	trace := telemetry.NewTrace(ctx, request.Coin)
+	rpcinfo.Observer = trace.Observe
+	rpcinfo.Log = logging.FromContext(ctx)
+	network := wallets.RequestNetwork(request.Network, request.Testnet)
+	wallet, err := wallets.WalletForCoin(network, rpcinfo, request.Coin, request.CoinDef, request.Profile)
 	if err != nil {
 		response.Errorno = bnd.ERRNO_UNSUPPORTED
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
+	wallet = trace.Wallet(wallet)
 	// refund
 	params := libs.RefundParams{}
 	params.Contract = request.Contract
 	params.ContractTx = request.ContractTx
+	params.Account = request.Account
+	params.FeePerKb = request.FeePerKb
 	result, err := wallet.Refund(params)
 	if err != nil {
 		response.Errorno = bnd.ERRNO_LIBS
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
 	response.RefundTx = result.RefundTx
 	response.RefundTxHash = result.RefundTxHash
@@ -237,7 +340,6 @@ func (s *swapLibServer) Refund(ctx context.Context, request *bnd.RefundRequest)
 }
 
 func (s *swapLibServer) Publish(ctx context.Context, request *bnd.PublishRequest) (*bnd.PublishResponse, error) {
-	log.Printf("Publish\n")
 	response := &bnd.PublishResponse{Errorno: bnd.ERRNO_OK}
 	// get wallet
 	rpcinfo := libs.RPCInfo{}
@@ -246,56 +348,70 @@ func (s *swapLibServer) Publish(ctx context.Context, request *bnd.PublishRequest
 	rpcinfo.Pass = request.Rpcpass
 	rpcinfo.WalletPass = request.Wpass
 	rpcinfo.Certs = request.Certs
-	wallet, err := wallets.WalletForCoin(request.Testnet, rpcinfo, request.Coin)
+	rpcinfo.JSONRPC = request.Jsonrpc
+	trace := telemetry.NewTrace(ctx, request.Coin)
+	rpcinfo.Observer = trace.Observe
+	rpcinfo.Log = logging.FromContext(ctx)
+	network := wallets.RequestNetwork(request.Network, request.Testnet)
+	wallet, err := wallets.WalletForCoin(network, rpcinfo, request.Coin, request.CoinDef, request.Profile)
 	if err != nil {
 		response.Errorno = bnd.ERRNO_UNSUPPORTED
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
+	wallet = trace.Wallet(wallet)
 	// publish
 	txhash, err := wallet.Publish(request.Tx)
 	if err != nil {
 		response.Errorno = bnd.ERRNO_LIBS
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
 	response.TxHash = txhash
 	return response, nil
 }
 
 func (s *swapLibServer) ExtractSecret(ctx context.Context, request *bnd.ExtractSecretRequest) (*bnd.ExtractSecretResponse, error) {
-	log.Printf("ExtractSecret\n")
 	response := &bnd.ExtractSecretResponse{Errorno: bnd.ERRNO_OK}
 	// get wallet
 	rpcinfo := libs.RPCInfo{}
-	wallet, err := wallets.WalletForCoin(request.Testnet, rpcinfo, request.Coin)
+	trace := telemetry.NewTrace(ctx, request.Coin)
+	rpcinfo.Observer = trace.Observe
+	rpcinfo.Log = logging.FromContext(ctx)
+	network := wallets.RequestNetwork(request.Network, request.Testnet)
+	wallet, err := wallets.WalletForCoin(network, rpcinfo, request.Coin, request.CoinDef, "")
 	if err != nil {
 		response.Errorno = bnd.ERRNO_UNSUPPORTED
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
+	wallet = trace.Wallet(wallet)
 	// extract secret
 	secret, err := wallet.ExtractSecret(request.CpRedemptionTx, request.Secrethash)
 	if err != nil {
 		response.Errorno = bnd.ERRNO_LIBS
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
 	response.Secret = secret
 	return response, nil
 }
 
 func (s *swapLibServer) Audit(ctx context.Context, request *bnd.AuditRequest) (*bnd.AuditResponse, error) {
-	log.Printf("Audit\n")
 	response := &bnd.AuditResponse{Errorno: bnd.ERRNO_OK}
 	// get wallet
 	rpcinfo := libs.RPCInfo{}
-	wallet, err := wallets.WalletForCoin(request.Testnet, rpcinfo, request.Coin)
+	trace := telemetry.NewTrace(ctx, request.Coin)
+	rpcinfo.Observer = trace.Observe
+	rpcinfo.Log = logging.FromContext(ctx)
+	network := wallets.RequestNetwork(request.Network, request.Testnet)
+	wallet, err := wallets.WalletForCoin(network, rpcinfo, request.Coin, request.CoinDef, "")
 	if err != nil {
 		response.Errorno = bnd.ERRNO_UNSUPPORTED
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
+	wallet = trace.Wallet(wallet)
 	// audit
 	params := libs.AuditParams{}
 	params.Contract = request.Contract
@@ -304,7 +420,7 @@ func (s *swapLibServer) Audit(ctx context.Context, request *bnd.AuditRequest) (*
 	if err != nil {
 		response.Errorno = bnd.ERRNO_LIBS
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
 	response.ContractAmount = result.ContractAmount
 	response.ContractAddress = result.ContractAddress
@@ -316,7 +432,6 @@ func (s *swapLibServer) Audit(ctx context.Context, request *bnd.AuditRequest) (*
 }
 
 func (s *swapLibServer) GetTx(ctx context.Context, request *bnd.GetTxRequest) (*bnd.GetTxResponse, error) {
-	log.Printf("GetTx\n")
 	response := &bnd.GetTxResponse{Errorno: bnd.ERRNO_OK}
 	// get wallet
 	rpcinfo := libs.RPCInfo{}
@@ -325,18 +440,26 @@ func (s *swapLibServer) GetTx(ctx context.Context, request *bnd.GetTxRequest) (*
 	rpcinfo.Pass = request.Rpcpass
 	rpcinfo.WalletPass = request.Wpass
 	rpcinfo.Certs = request.Certs
-	wallet, err := wallets.WalletForCoin(request.Testnet, rpcinfo, request.Coin)
+	rpcinfo.JSONRPC = request.Jsonrpc
+	rpcinfo.NodeHostPort = request.NodeHostport
+	rpcinfo.NodeCerts = request.NodeCerts
+	trace := telemetry.NewTrace(ctx, request.Coin)
+	rpcinfo.Observer = trace.Observe
+	rpcinfo.Log = logging.FromContext(ctx)
+	network := wallets.RequestNetwork(request.Network, request.Testnet)
+	wallet, err := wallets.WalletForCoin(network, rpcinfo, request.Coin, request.CoinDef, request.Profile)
 	if err != nil {
 		response.Errorno = bnd.ERRNO_UNSUPPORTED
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
+	wallet = trace.Wallet(wallet)
 	// get tx
 	result, err := wallet.GetTx(request.Txid)
 	if err != nil {
 		response.Errorno = bnd.ERRNO_LIBS
 		response.Errstr = err.Error()
-		return response, nil
+		return response, replyError(err, request.Coin, network)
 	}
 	response.Confirmations = result.Confirmations
 	response.Blockhash = result.Blockhash
@@ -345,9 +468,152 @@ func (s *swapLibServer) GetTx(ctx context.Context, request *bnd.GetTxRequest) (*
 	response.Time = result.Time
 	response.TimeReceived = result.TimeReceived
 	response.Hex = result.Hex
+	response.Locked = result.Locked
 	return response, nil
 }
 
+/////////////////
+// Swap Events //
+/////////////////
+
+// WatchSwap streams the events of a contract until its spend has
+// final_confirmations or the client goes away
+func (s *swapLibServer) WatchSwap(request *bnd.WatchSwapRequest, stream bnd.SwapLib_WatchSwapServer) error {
+	logging.FromContext(stream.Context()).Info("watch swap", logging.RequestFields(request)...)
+	return streamEvents(stream, []*bnd.WatchSwapRequest{request})
+}
+
+// SubscribeEvents streams the events of several contracts until all their
+// spends have final_confirmations or the client goes away
+func (s *swapLibServer) SubscribeEvents(request *bnd.SubscribeEventsRequest, stream bnd.SwapLib_SubscribeEventsServer) error {
+	logging.FromContext(stream.Context()).Info("subscribe events", libs.F("swaps", len(request.Swaps)))
+	return streamEvents(stream, request.Swaps)
+}
+
+// eventStream is a server stream of swap events
+type eventStream interface {
+	Send(*bnd.SwapEvent) error
+	Context() context.Context
+}
+
+// streamEvents subscribes to the events of the swaps on the monitor and
+// sends them on the stream
+func streamEvents(stream eventStream, requests []*bnd.WatchSwapRequest) error {
+	type subEvent struct {
+		ev    *bnd.SwapEvent
+		final int64
+	}
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	merged := make(chan subEvent)
+	live := 0
+	for _, request := range requests {
+		sub, err := subscribe(request)
+		if err != nil {
+			replyErr := replyError(err, request.Coin, wallets.RequestNetwork(request.Network, request.Testnet))
+			if replyErr != nil {
+				return replyErr
+			}
+			return stream.Send(&bnd.SwapEvent{
+				Contract:     request.Contract,
+				ContractTxid: request.ContractTxid,
+				Errorno:      bnd.ERRNO_UNSUPPORTED,
+				Errstr:       status.Convert(err).Message(),
+			})
+		}
+		defer sub.Close()
+		live++
+		go func(sub *watch.Subscription, final int64) {
+			for ev := range sub.Events {
+				select {
+				case merged <- subEvent{ev, final}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case merged <- subEvent{nil, final}:
+			case <-ctx.Done():
+			}
+		}(sub, request.FinalConfirmations)
+	}
+	done := map[string]bool{}
+	for live > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e := <-merged:
+			if e.ev == nil {
+				return status.Error(codes.ResourceExhausted, "event stream too slow")
+			}
+			err := stream.Send(e.ev)
+			if err != nil {
+				return err
+			}
+			final := e.final > 0 && e.ev.Errorno == bnd.ERRNO_OK &&
+				(e.ev.SpendTxid != "" || e.ev.SpendConfirmations > 0) &&
+				e.ev.SpendConfirmations >= e.final
+			if final && !done[e.ev.ContractTxid] {
+				done[e.ev.ContractTxid] = true
+				live--
+			}
+		}
+	}
+	return nil
+}
+
+// credentialFingerprint is a hash of the credentials of a request so that a
+// subscriber only shares a poller started with the same credentials
+func credentialFingerprint(rpcinfo libs.RPCInfo) string {
+	h := sha256.New()
+	for _, field := range []string{rpcinfo.User, rpcinfo.Pass, rpcinfo.WalletPass,
+		rpcinfo.Certs, rpcinfo.NodeCerts, strconv.FormatBool(rpcinfo.JSONRPC)} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// subscribe subscribes to the events of a swap on the monitor
+func subscribe(request *bnd.WatchSwapRequest) (*watch.Subscription, error) {
+	if request.Contract == "" {
+		return nil, rpcerr.InvalidArgument("contract", "contract is needed")
+	}
+	if request.ContractTxid == "" {
+		return nil, rpcerr.InvalidArgument("contract_txid", "contract_txid is needed")
+	}
+	rpcinfo := libs.RPCInfo{}
+	rpcinfo.HostPort = request.Hostport
+	rpcinfo.User = request.Rpcuser
+	rpcinfo.Pass = request.Rpcpass
+	rpcinfo.WalletPass = request.Wpass
+	rpcinfo.Certs = request.Certs
+	rpcinfo.JSONRPC = request.Jsonrpc
+	rpcinfo.NodeHostPort = request.NodeHostport
+	rpcinfo.NodeCerts = request.NodeCerts
+	// the polls outlive the request so are traced on their own
+	trace := telemetry.NewTrace(context.Background(), request.Coin)
+	rpcinfo.Observer = trace.Observe
+	rpcinfo.Log = libs.NewLog(nil, logging.RequestFields(request)...)
+	network := wallets.RequestNetwork(request.Network, request.Testnet)
+	wallet, err := wallets.WalletForCoin(network, rpcinfo, request.Coin, request.CoinDef, request.Profile)
+	if err != nil {
+		return nil, err
+	}
+	wallet = trace.Wallet(wallet)
+	// subscribers of a contract through the same wallet with the same
+	// credentials share the poller
+	key := strings.Join([]string{request.Coin.String(), request.CoinDef, network.String(),
+		request.Profile, request.Hostport, request.NodeHostport, credentialFingerprint(rpcinfo),
+		request.Contract, request.ContractTxid}, "|")
+	params := libs.WatchParams{
+		Contract:     request.Contract,
+		ContractTxid: request.ContractTxid,
+		Rescan:       request.Rescan,
+	}
+	return monitor.Subscribe(key, wallet, params, rpcinfo.Log), nil
+}
+
 //////////
 // MAIN //
 //////////
@@ -359,29 +625,363 @@ func newServer() *swapLibServer {
 }
 
 func main() {
+	flag.Parse()
+	var err error
+	cfg, err = svrcfg.Load(*configFile)
+	if err != nil {
+		fatal("bad configuration", libs.F("error", err))
+	}
+	current.Store(cfg)
+	startLogging()
+	logger.Info("configuration", libs.F("file", cfg.File), libs.F("env_overrides", cfg.EnvOverrides))
 	ensureUniqueServerProcess()
-	lis, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", serverPort))
+	err = applySettings(cfg)
+	if err != nil {
+		fatal("bad configuration", libs.F("error", err))
+	}
+	monitor = watch.NewMonitor(time.Duration(cfg.WatchInterval) * time.Second)
+	listeners, err := listen(cfg.Listen)
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		fatal("failed to listen", libs.F("error", err))
 	}
-	log.Printf("Server listening on localhost:%v\n", serverPort)
 	var opts []grpc.ServerOption
-	if tls {
-		creds, err := credentials.NewServerTLSFromFile(certPath, certKeyPath)
+	if cfg.UseTLS {
+		creds, err := serverCredentials()
 		if err != nil {
-			log.Fatalf("Failed to generate credentials %v", err)
+			fatal("failed to generate credentials", libs.F("error", err))
 		}
 		opts = []grpc.ServerOption{grpc.Creds(creds)}
 	} else {
-		log.Println("Warning: No TLS")
+		logger.Warn("no TLS")
+	}
+	// requests are logged, counted and traced before auth so rejected ones are seen
+	unary := []grpc.UnaryServerInterceptor{logging.UnaryServerInterceptor, telemetry.UnaryServerInterceptor}
+	stream := []grpc.StreamServerInterceptor{logging.StreamServerInterceptor, telemetry.StreamServerInterceptor}
+	if cfg.RequireAuth {
+		store, err := auth.OpenStore(cfg.TokenFile)
+		if err != nil {
+			fatal("failed to open token file", libs.F("error", err))
+		}
+		unary = append(unary, auth.UnaryServerInterceptor(store))
+		stream = append(stream, auth.StreamServerInterceptor(store))
+	} else {
+		logger.Warn("no client authentication")
+	}
+	interceptorOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+	opts = append(opts, interceptorOpts...)
+	startTelemetry()
+	server := newServer()
+	if len(cfg.GatewayListen) > 0 {
+		startGateway(server, interceptorOpts)
 	}
 	// export process lock/pid file
 	setPidFile()
 	// Good to go
-	startSignalHandler()
 	grpcServer = grpc.NewServer(opts...)
-	bnd.RegisterSwapLibServer(grpcServer, newServer())
-	grpcServer.Serve(lis)
+	bnd.RegisterSwapLibServer(grpcServer, server)
+	startSignalHandler()
+	for _, lis := range listeners {
+		logger.Info("server listening", libs.F("addr", lis.Addr().String()))
+		go func(lis net.Listener) {
+			err := grpcServer.Serve(lis)
+			if err != nil {
+				fatal("server", libs.F("error", err))
+			}
+		}(lis)
+	}
+	// gracefulShutdown exits
+	select {}
+}
+
+///////////////
+// Listeners //
+///////////////
+
+// listen opens the listeners of the addresses. A unix socket file left by a
+// server that did not shut down is removed first
+func listen(addrs []svrcfg.Listener) ([]net.Listener, error) {
+	var listeners []net.Listener
+	for _, addr := range addrs {
+		if addr.Network == "unix" {
+			fi, err := os.Stat(addr.Address)
+			if err == nil && fi.Mode()&os.ModeSocket != 0 {
+				os.Remove(addr.Address)
+			}
+		}
+		lis, err := net.Listen(addr.Network, addr.Address)
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return nil, err
+		}
+		listeners = append(listeners, lis)
+	}
+	return listeners, nil
+}
+
+/////////////////
+// REST / JSON //
+/////////////////
+
+// startGateway starts the JSON over HTTP gateway. It shares the TLS settings
+// and the interceptors (auth, telemetry) of the gRPC server
+func startGateway(server *swapLibServer, interceptorOpts []grpc.ServerOption) {
+	listeners, err := listen(cfg.GatewayListen)
+	if err != nil {
+		fatal("failed to listen", libs.F("error", err))
+	}
+	gw, err := gateway.New(server, interceptorOpts...)
+	if err != nil {
+		fatal("failed to start the gateway", libs.F("error", err))
+	}
+	jsonGateway = gw
+	for _, lis := range listeners {
+		logger.Info("gateway listening", libs.F("addr", lis.Addr().String()))
+		go func(lis net.Listener) {
+			var err error
+			if cfg.UseTLS {
+				err = gw.ServeTLS(lis, certPath, certKeyPath, clientCA, cfg.ClientCerts)
+			} else {
+				err = gw.Serve(lis)
+			}
+			if err != nil {
+				fatal("gateway", libs.F("error", err))
+			}
+		}(lis)
+	}
+}
+
+/////////////
+// Logging //
+/////////////
+
+// startLogging makes the server and lib logs JSON records of log_level and
+// above on stderr
+func startLogging() {
+	level, err := libs.ParseLevel(config().LogLevel)
+	if err != nil {
+		fatal("bad log_level", libs.F("error", err))
+	}
+	libs.SetLogger(logging.NewJSONLogger(os.Stderr, level))
+}
+
+// fatal logs an error and exits
+func fatal(msg string, fields ...libs.Field) {
+	logger.Error(msg, fields...)
+	os.Exit(1)
+}
+
+///////////////
+// Telemetry //
+///////////////
+
+// startTelemetry serves the Prometheus metrics, if metrics_port or
+// metrics_listen is set, and exports the request spans to the collector, if
+// otlp_endpoint is set
+func startTelemetry() {
+	if cfg.OTLPEndpoint != "" {
+		stop, err := telemetry.StartTracing(cfg.OTLPEndpoint, cfg.TraceSampleRatio)
+		if err != nil {
+			fatal("failed to start tracing", libs.F("error", err))
+		}
+		stopTracing = stop
+		logger.Info("tracing", libs.F("otlp_endpoint", cfg.OTLPEndpoint))
+	}
+	if len(cfg.MetricsListen) == 0 {
+		return
+	}
+	listeners, err := listen(cfg.MetricsListen)
+	if err != nil {
+		fatal("failed to listen", libs.F("error", err))
+	}
+	metricsServer = telemetry.NewMetricsServer()
+	for _, lis := range listeners {
+		logger.Info("metrics listening", libs.F("addr", lis.Addr().String()))
+		go func(lis net.Listener) {
+			err := metricsServer.Serve(lis)
+			if err != nil {
+				fatal("metrics", libs.F("error", err))
+			}
+		}(lis)
+	}
+}
+
+/////////////////////////
+// Reloadable Settings //
+/////////////////////////
+
+// applySettings applies the settings that may be reloaded: the coin
+// definitions, the eth swap contracts and the wallet profiles. Every setting is
+// loaded and checked before any is applied so a bad one leaves the settings as
+// they were
+func applySettings(c *svrcfg.ServerConfig) error {
+	resolved, err := resolveProfiles(c)
+	if err != nil {
+		return fmt.Errorf("wallet profiles: %v", err)
+	}
+	var defs map[string]*utxo.CoinDef
+	if c.CoinDefDir != "" {
+		defs, err = utxo.LoadCoinDefs(c.CoinDefDir)
+		if err != nil {
+			return fmt.Errorf("coin definitions: %v", err)
+		}
+	}
+	// the contracts are all checked before any is set
+	err = wallets.SetETHSwapContracts(c.ETHSwapContract, c.ETHTestnetSwapContract, c.ETHRegtestSwapContract)
+	if err != nil {
+		return fmt.Errorf("eth swap contracts: %v", err)
+	}
+	if defs != nil {
+		wallets.SetCoinDefs(defs)
+	}
+	wallets.SetProfiles(resolved)
+	logger.Info("wallet profiles", libs.F("profiles", len(resolved)))
+	return nil
+}
+
+// reloadConfig loads the config file again and applies the settings that do
+// not need a restart: log_level, errno_payload, coin_def_dir, the eth swap
+// contracts, watch_interval and the wallet profiles. A bad config is logged and
+// the settings kept
+func reloadConfig() {
+	next, err := svrcfg.Load(*configFile)
+	if err == nil {
+		err = applySettings(next)
+	}
+	if err != nil {
+		logger.Error("config not reloaded", libs.F("error", err))
+		return
+	}
+	current.Store(next)
+	startLogging()
+	monitor.SetInterval(time.Duration(next.WatchInterval) * time.Second)
+	logger.Info("config reloaded", libs.F("file", next.File), libs.F("env_overrides", next.EnvOverrides))
+	if keys := restartKeys(cfg, next); len(keys) > 0 {
+		logger.Warn("config changes need a restart", libs.F("keys", keys))
+	}
+}
+
+// restartKeys gets the keys changed from the started configuration that are
+// only applied on restart
+func restartKeys(started, next *svrcfg.ServerConfig) []string {
+	settings := []struct {
+		key           string
+		started, next interface{}
+	}{
+		{"pidfile", started.PidFile, next.PidFile},
+		{"use_tls", started.UseTLS, next.UseTLS},
+		{"cert_path", started.CertPath, next.CertPath},
+		{"cert_key_path", started.CertKeyPath, next.CertKeyPath},
+		{"tls_dir", started.TLSDir, next.TLSDir},
+		{"client_ca_path", started.ClientCAPath, next.ClientCAPath},
+		{"require_client_cert", started.ClientCerts, next.ClientCerts},
+		{"listen", started.Listen, next.Listen},
+		{"gateway_listen", started.GatewayListen, next.GatewayListen},
+		{"require_auth", started.RequireAuth, next.RequireAuth},
+		{"token_file", started.TokenFile, next.TokenFile},
+		{"metrics_listen", started.MetricsListen, next.MetricsListen},
+		{"otlp_endpoint", started.OTLPEndpoint, next.OTLPEndpoint},
+		{"trace_sample_ratio", started.TraceSampleRatio, next.TraceSampleRatio},
+	}
+	var keys []string
+	for _, s := range settings {
+		if !reflect.DeepEqual(s.started, s.next) {
+			keys = append(keys, s.key)
+		}
+	}
+	return keys
+}
+
+/////////////////////
+// Wallet Profiles //
+/////////////////////
+
+// resolveProfiles resolves the configured wallet profiles, opening their
+// secrets
+func resolveProfiles(c *svrcfg.ServerConfig) (map[string]*wallets.Profile, error) {
+	var key []byte
+	if c.ProfileKeyFile != "" {
+		var err error
+		key, err = wallets.ReadProfileKey(c.ProfileKeyFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	resolved := make(map[string]*wallets.Profile)
+	for id, p := range c.Profiles {
+		coin, ok := bnd.COIN_value[strings.ToUpper(p.Coin)]
+		if !ok {
+			return nil, fmt.Errorf("profile %s: unknown coin %q", id, p.Coin)
+		}
+		network, err := libs.ParseNetwork(p.Network)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %v", id, err)
+		}
+		rpcpass, err := wallets.OpenSecret(p.RPCPass, p.RPCPassFile, key)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: rpcpass: %v", id, err)
+		}
+		wpass, err := wallets.OpenSecret(p.WalletPass, p.WalletPassFile, key)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: wpass: %v", id, err)
+		}
+		resolved[id] = &wallets.Profile{
+			Coin:    bnd.COIN(coin),
+			CoinDef: p.CoinDef,
+			Network: network,
+			RPCInfo: libs.RPCInfo{
+				HostPort:     p.HostPort,
+				User:         p.RPCUser,
+				Pass:         rpcpass,
+				WalletPass:   wpass,
+				Certs:        p.Certs,
+				JSONRPC:      p.JSONRPC,
+				NodeHostPort: p.NodeHostPort,
+				NodeCerts:    p.NodeCerts,
+			},
+		}
+	}
+	return resolved, nil
+}
+
+///////////////
+// TLS Certs //
+///////////////
+
+// serverCredentials makes the server TLS credentials. Without a configured
+// cert_path and cert_key_path a self-signed CA and a server cert it signs are
+// made in tls_dir on first start. Clients trust tls_dir/ca.crt
+func serverCredentials() (credentials.TransportCredentials, error) {
+	certPath, certKeyPath, clientCA = cfg.CertPath, cfg.CertKeyPath, cfg.ClientCAPath
+	if certPath == "" && certKeyPath == "" {
+		var err error
+		hosts := []string{"localhost", "127.0.0.1", "::1", cfg.ServerAddr, cfg.HostOverride}
+		for _, l := range append(cfg.Listen, cfg.GatewayListen...) {
+			if ip := net.ParseIP(l.Host()); ip == nil || !ip.IsUnspecified() {
+				hosts = append(hosts, l.Host())
+			}
+		}
+		certPath, certKeyPath, err = certs.EnsureServerCert(cfg.TLSDir, hosts)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("TLS cert", libs.F("cert_path", certPath))
+		if clientCA == "" {
+			clientCA = filepath.Join(cfg.TLSDir, certs.CACertFile)
+		}
+	}
+	if cfg.ClientCerts {
+		logger.Info("client certificates required", libs.F("client_ca_path", clientCA))
+	}
+	tlsConfig, err := certs.ServerTLSConfig(certPath, certKeyPath, clientCA, cfg.ClientCerts)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(tlsConfig), nil
 }
 
 /////////////////////////
@@ -390,15 +990,15 @@ func main() {
 
 func ensureUniqueServerProcess() {
 	if runtime.GOOS == "windows" {
-		log.Fatalln("This server does not run on Windows")
+		fatal("this server does not run on Windows")
 	}
 	if checkPidfileExists() {
-		log.Fatalln("server already running")
+		fatal("server already running", libs.F("pidfile", cfg.PidFile))
 	}
 }
 
 func checkPidfileExists() bool {
-	_, err := os.Stat(pidFile)
+	_, err := os.Stat(cfg.PidFile)
 	if err == nil {
 		return true
 	}
@@ -413,38 +1013,56 @@ func checkPidfileExists() bool {
 // Allow shutdown process to discover and gracefully stop server
 func setPidFile() {
 	pid := strconv.FormatInt(int64(os.Getpid()), 10)
-	f, err := os.Create(pidFile)
+	f, err := os.Create(cfg.PidFile)
 	if err != nil {
-		log.Fatalf("cannot create pid file: %s\n", pidFile)
+		fatal("cannot create pid file", libs.F("pidfile", cfg.PidFile), libs.F("error", err))
 	}
 	defer f.Close()
 	f.WriteString(pid)
 	f.Sync()
-	log.Printf("server pid: %s\n", pid)
+	logger.Info("server pid", libs.F("pid", pid))
 }
 
 ///////////////////////////////////////
 // Graceful Shutdown Signal Handling //
 ///////////////////////////////////////
 
-// Capture SIGINT or SIGTERM
+// Capture SIGINT or SIGTERM, and SIGHUP to reload the config
 func startSignalHandler() {
 	sigs := make(chan os.Signal, 1)
-	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 	go signalHandler(sigs)
 }
 
 func signalHandler(sigs chan os.Signal) {
-	sig := <-sigs
-	fmt.Printf("\nReceived SIG: %v\n", sig)
-	gracefulShutdown()
+	for sig := range sigs {
+		logger.Info("received signal", libs.F("signal", sig.String()))
+		if sig == syscall.SIGHUP {
+			reloadConfig()
+			continue
+		}
+		gracefulShutdown()
+	}
 }
 
 func gracefulShutdown() {
-	log.Println("waiting for server to gracefully shut down...")
-	grpcServer.GracefulStop()
-	log.Println("...server has shut down")
-	os.Remove(pidFile)
-	log.Println("removed lock file")
+	logger.Info("waiting for server to gracefully shut down")
+	if jsonGateway != nil {
+		jsonGateway.Stop()
+	}
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+	if metricsServer != nil {
+		metricsServer.Stop()
+	}
+	if stopTracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		stopTracing(ctx)
+		cancel()
+	}
+	logger.Info("server has shut down")
+	os.Remove(cfg.PidFile)
+	logger.Info("removed lock file", libs.F("pidfile", cfg.PidFile))
 	os.Exit(0)
 }
@@ -1,51 +1,372 @@
+// Package svrcfg loads the server configuration: an ini file with keys
+// overridden by ATOMICSWAP_<SECTION>_<KEY> environment variables. Bad values
+// are reported as errors by Load, all at once
 package svrcfg
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"os"
+	"strconv"
+	"strings"
 
+	"github.com/devwarrior777/atomicswap/libs"
 	"github.com/go-ini/ini"
 )
 
 const (
-	configFile = "config.ini"
+	// DefaultFile is the config file loaded when none is named. It may be
+	// missing, leaving the defaults and environment overrides
+	DefaultFile = "config.ini"
+
+	// EnvFile is the environment variable naming the config file
+	EnvFile = "ATOMICSWAP_CONFIG"
+
+	// EnvPrefix prefixes the environment variables overriding config keys:
+	// ATOMICSWAP_SERVER_LOG_LEVEL overrides [server] log_level
+	EnvPrefix = "ATOMICSWAP_"
 )
 
-type config struct {
-	AppMode string
+// envSections are the sections whose keys the environment may override
+var envSections = []string{"server", "coins", "auth", "telemetry"}
+
+// ServerConfig is the server configuration
+type ServerConfig struct {
+	File         string   // config file loaded, empty if none
+	EnvOverrides []string // keys set from the environment as section.key
+	AppMode      string
 	// [server]
-	PidFile      string
-	UseTLS       bool
-	CertPath     string
-	CertKeyPath  string
-	ServerAddr   string
-	ServerPort   int
-	HostOverride string
+	PidFile       string
+	UseTLS        bool
+	CertPath      string
+	CertKeyPath   string
+	TLSDir        string
+	ClientCAPath  string
+	ClientCerts   bool
+	ClientCert    string // client certificate (svrtest)
+	ClientKey     string // client certificate key (svrtest)
+	ServerAddr    string
+	ServerPort    int
+	Listen        []Listener // gRPC listeners, server_addr:server_port by default
+	GatewayPort   int        // JSON over HTTP gateway port, 0 for no gateway
+	GatewayListen []Listener // gateway listeners, server_addr:gateway_port by default
+	HostOverride  string
+	ErrnoPayload  bool   // errors in the response ERRNO fields, not gRPC status
+	LogLevel      string // debug, info, warn or error
+	// [coins]
+	CoinDefDir             string
+	ETHSwapContract        string
+	ETHTestnetSwapContract string
+	ETHRegtestSwapContract string
+	WatchInterval          int // seconds between polls of watched contracts
+	// [auth]
+	RequireAuth bool
+	TokenFile   string
+	AuthToken   string // client token (svrtest)
+	// [telemetry]
+	MetricsPort      int        // Prometheus /metrics port, 0 for no metrics
+	MetricsListen    []Listener // metrics listeners, server_addr:metrics_port by default
+	OTLPEndpoint     string     // OTLP gRPC collector host:port, empty for no tracing
+	TraceSampleRatio float64    // fraction of new traces sampled
+	// [profile.<id>]
+	ProfileKeyFile string
+	Profiles       map[string]*ProfileConfig
+}
+
+// ProfileConfig is a [profile.<id>] wallet profile section. Secrets are plain,
+// sealed with the profile key (enc:...) or read from the _file path
+type ProfileConfig struct {
+	Coin           string
+	CoinDef        string
+	Network        string
+	HostPort       string
+	RPCUser        string
+	RPCPass        string
+	RPCPassFile    string
+	WalletPass     string
+	WalletPassFile string
+	Certs          string
+	JSONRPC        bool
+	NodeHostPort   string
+	NodeCerts      string
 }
 
-// Config is the exported configuration
-var Config = &config{}
+const profileSectionPrefix = "profile."
 
-// sets up the exported configuration for any packages that import this config pkg
-func init() {
-	cfg, err := ini.Load(configFile)
+// Load loads the config file at path, or if path is empty the file named by
+// ATOMICSWAP_CONFIG or else config.ini, applies the environment overrides and
+// checks the values. A named file must exist
+func Load(path string) (*ServerConfig, error) {
+	if path == "" {
+		path = os.Getenv(EnvFile)
+	}
+	var cfg *ini.File
+	var err error
+	if path == "" {
+		path = DefaultFile
+		cfg, err = ini.LooseLoad(path)
+	} else {
+		cfg, err = ini.Load(path)
+	}
 	if err != nil {
-		fmt.Printf("Fail to read file: %v", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("config file: %v", err)
 	}
+	c := &ServerConfig{}
+	if _, err := os.Stat(path); err == nil {
+		c.File = path
+	}
+	c.EnvOverrides = applyEnv(cfg, os.Environ())
+	r := &reader{}
 
 	// [DEFAULT]
-	Config.AppMode = cfg.Section("").Key("app_mode").String()
+	c.AppMode = cfg.Section("").Key("app_mode").String()
 
 	// [server]
 	serverSection := cfg.Section("server")
-	Config.PidFile = serverSection.Key("pidfile").String()
-	Config.UseTLS = serverSection.Key("use_tls").MustBool(false)
-	Config.CertPath = serverSection.Key("cert_path").String()
-	Config.CertKeyPath = serverSection.Key("cert_key_path").String()
-	Config.ServerAddr = serverSection.Key("server_addr").String()
-	Config.ServerPort = serverSection.Key("server_port").MustInt(10000)
-	Config.HostOverride = serverSection.Key("host_override").String()
-
-	fmt.Printf("%v\n", Config)
+	c.PidFile = serverSection.Key("pidfile").MustString("/tmp/atomicswap.grpc.server.pid")
+	c.UseTLS = r.bool(serverSection, "use_tls", false)
+	c.CertPath = serverSection.Key("cert_path").String()
+	c.CertKeyPath = serverSection.Key("cert_key_path").String()
+	c.TLSDir = serverSection.Key("tls_dir").MustString("tls")
+	c.ClientCAPath = serverSection.Key("client_ca_path").String()
+	c.ClientCerts = r.bool(serverSection, "require_client_cert", false)
+	c.ClientCert = serverSection.Key("client_cert_path").String()
+	c.ClientKey = serverSection.Key("client_key_path").String()
+	c.ServerAddr = serverSection.Key("server_addr").String()
+	c.ServerPort = r.port(serverSection, "server_port", 10000)
+	c.Listen = r.listeners(serverSection, "listen", c.ServerAddr, c.ServerPort)
+	c.GatewayPort = r.port(serverSection, "gateway_port", 0)
+	c.GatewayListen = r.listeners(serverSection, "gateway_listen", c.ServerAddr, c.GatewayPort)
+	c.HostOverride = serverSection.Key("host_override").String()
+	c.ErrnoPayload = r.bool(serverSection, "errno_payload", false)
+	c.LogLevel = serverSection.Key("log_level").MustString("info")
+	c.ProfileKeyFile = serverSection.Key("profile_key_file").String()
+
+	// [coins]
+	coinsSection := cfg.Section("coins")
+	c.CoinDefDir = coinsSection.Key("coin_def_dir").String()
+	c.ETHSwapContract = coinsSection.Key("eth_swap_contract").String()
+	c.ETHTestnetSwapContract = coinsSection.Key("eth_testnet_swap_contract").String()
+	c.ETHRegtestSwapContract = coinsSection.Key("eth_regtest_swap_contract").String()
+	c.WatchInterval = r.int(coinsSection, "watch_interval", 15)
+
+	// [auth]
+	authSection := cfg.Section("auth")
+	c.RequireAuth = r.bool(authSection, "require_auth", false)
+	c.TokenFile = authSection.Key("token_file").MustString("tokens.json")
+	c.AuthToken = authSection.Key("token").String()
+
+	// [telemetry]
+	telemetrySection := cfg.Section("telemetry")
+	c.MetricsPort = r.port(telemetrySection, "metrics_port", 0)
+	c.MetricsListen = r.listeners(telemetrySection, "metrics_listen", c.ServerAddr, c.MetricsPort)
+	c.OTLPEndpoint = telemetrySection.Key("otlp_endpoint").String()
+	c.TraceSampleRatio = r.float(telemetrySection, "trace_sample_ratio", 1)
+
+	// [profile.<id>]
+	c.Profiles = make(map[string]*ProfileConfig)
+	for _, section := range cfg.Sections() {
+		if !strings.HasPrefix(section.Name(), profileSectionPrefix) {
+			continue
+		}
+		id := strings.TrimPrefix(section.Name(), profileSectionPrefix)
+		c.Profiles[id] = &ProfileConfig{
+			Coin:           section.Key("coin").String(),
+			CoinDef:        section.Key("coin_def").String(),
+			Network:        section.Key("network").MustString("mainnet"),
+			HostPort:       section.Key("hostport").String(),
+			RPCUser:        section.Key("rpcuser").String(),
+			RPCPass:        section.Key("rpcpass").String(),
+			RPCPassFile:    section.Key("rpcpass_file").String(),
+			WalletPass:     section.Key("wpass").String(),
+			WalletPassFile: section.Key("wpass_file").String(),
+			Certs:          section.Key("certs").String(),
+			JSONRPC:        r.bool(section, "jsonrpc", false),
+			NodeHostPort:   section.Key("node_hostport").String(),
+			NodeCerts:      section.Key("node_certs").String(),
+		}
+	}
+
+	r.check(c)
+	if len(r.errs) > 0 {
+		name := path
+		if c.File == "" {
+			name = "environment"
+		}
+		return nil, fmt.Errorf("config %s: %s", name, strings.Join(r.errs, "; "))
+	}
+	return c, nil
+}
+
+// applyEnv sets the keys named by the ATOMICSWAP_<SECTION>_<KEY> environment
+// variables and returns them as section.key
+func applyEnv(cfg *ini.File, environ []string) []string {
+	var keys []string
+	for _, kv := range environ {
+		i := strings.Index(kv, "=")
+		if i < 0 || !strings.HasPrefix(kv[:i], EnvPrefix) {
+			continue
+		}
+		name := strings.ToLower(strings.TrimPrefix(kv[:i], EnvPrefix))
+		for _, section := range envSections {
+			if !strings.HasPrefix(name, section+"_") {
+				continue
+			}
+			key := strings.TrimPrefix(name, section+"_")
+			cfg.Section(section).Key(key).SetValue(kv[i+1:])
+			keys = append(keys, section+"."+key)
+		}
+	}
+	return keys
+}
+
+// reader reads typed keys, collecting the errors of bad values
+type reader struct {
+	errs []string
+}
+
+func (r *reader) errorf(format string, args ...interface{}) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+// bool reads a boolean key, def if empty
+func (r *reader) bool(section *ini.Section, key string, def bool) bool {
+	k := section.Key(key)
+	if k.String() == "" {
+		return def
+	}
+	v, err := k.Bool()
+	if err != nil {
+		r.errorf("[%s] %s: %q is not true or false", section.Name(), key, k.String())
+		return def
+	}
+	return v
+}
+
+// int reads an integer key, def if empty
+func (r *reader) int(section *ini.Section, key string, def int) int {
+	k := section.Key(key)
+	if k.String() == "" {
+		return def
+	}
+	v, err := k.Int()
+	if err != nil {
+		r.errorf("[%s] %s: %q is not an integer", section.Name(), key, k.String())
+		return def
+	}
+	return v
+}
+
+// port reads a port key, def if empty. 0 is no port
+func (r *reader) port(section *ini.Section, key string, def int) int {
+	v := r.int(section, key, def)
+	if v < 0 || v > 65535 {
+		r.errorf("[%s] %s: %d is not a port", section.Name(), key, v)
+		return def
+	}
+	return v
+}
+
+// float reads a number key, def if empty
+func (r *reader) float(section *ini.Section, key string, def float64) float64 {
+	k := section.Key(key)
+	if k.String() == "" {
+		return def
+	}
+	v, err := k.Float64()
+	if err != nil {
+		r.errorf("[%s] %s: %q is not a number", section.Name(), key, k.String())
+		return def
+	}
+	return v
+}
+
+// listeners reads a comma separated list of listen addresses. Empty is addr
+// (localhost by default) with port, or no listeners when port is 0
+func (r *reader) listeners(section *ini.Section, key, addr string, port int) []Listener {
+	values := section.Key(key).Strings(",")
+	if len(values) == 0 {
+		if port == 0 {
+			return nil
+		}
+		if addr == "" {
+			addr = "localhost"
+		}
+		values = []string{net.JoinHostPort(addr, strconv.Itoa(port))}
+	}
+	listeners := make([]Listener, 0, len(values))
+	for _, v := range values {
+		l, err := ParseListener(v)
+		if err != nil {
+			r.errorf("[%s] %s: %v", section.Name(), key, err)
+			continue
+		}
+		listeners = append(listeners, l)
+	}
+	return listeners
+}
+
+// check checks the values that are well formed but out of range or in
+// conflict
+func (r *reader) check(c *ServerConfig) {
+	if _, err := libs.ParseLevel(c.LogLevel); err != nil {
+		r.errorf("[server] log_level: %v", err)
+	}
+	if c.ClientCerts && !c.UseTLS {
+		r.errorf("[server] require_client_cert needs use_tls")
+	}
+	if c.WatchInterval < 1 {
+		r.errorf("[coins] watch_interval: %d is not a number of seconds", c.WatchInterval)
+	}
+	if c.TraceSampleRatio < 0 || c.TraceSampleRatio > 1 {
+		r.errorf("[telemetry] trace_sample_ratio: %v is not between 0 and 1", c.TraceSampleRatio)
+	}
+	if c.ServerPort == 0 && len(c.Listen) == 0 {
+		r.errorf("[server] no server_port or listen address")
+	}
+}
+
+// Listener is a listen address: a TCP host:port or a unix domain socket
+type Listener struct {
+	Network string // tcp or unix
+	Address string // host:port or socket path
+}
+
+// ParseListener parses a listen address: host:port, [ipv6]:port or
+// unix:/path/to/socket
+func ParseListener(s string) (Listener, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "unix:") {
+		path := strings.TrimPrefix(s, "unix:")
+		if path == "" {
+			return Listener{}, errors.New("unix: needs a socket path")
+		}
+		return Listener{Network: "unix", Address: path}, nil
+	}
+	_, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return Listener{}, err
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return Listener{}, fmt.Errorf("%q has no port", s)
+	}
+	return Listener{Network: "tcp", Address: s}, nil
+}
+
+// Host gets the host of a TCP listener, empty for a unix socket
+func (l Listener) Host() string {
+	if l.Network != "tcp" {
+		return ""
+	}
+	host, _, _ := net.SplitHostPort(l.Address)
+	return host
+}
+
+func (l Listener) String() string {
+	if l.Network == "unix" {
+		return "unix:" + l.Address
+	}
+	return l.Address
 }
@@ -0,0 +1,197 @@
+package svrtest
+
+import (
+	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+)
+
+/*
+TEST DATA FOR THE BCH WALLET RPC COMMANDS
+
+You will need your own testdata that reflects your coins configurations:
+ - Network (mainnet, testnet, regtest...)
+ - RPC Info to connect to your BCH RPC wallet node(s)
+*/
+
+var bchPingWalletRPCRequest = bnd.PingWalletRPCRequest{
+	Coin:     bnd.COIN_BCH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var bchTestnetPingWalletRPCRequest = bnd.PingWalletRPCRequest{
+	Coin:     bnd.COIN_BCH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var bchNewAddressRequest = bnd.NewAddressRequest{
+	Coin:     bnd.COIN_BCH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var bchTestnetNewAddressRequest = bnd.NewAddressRequest{
+	Coin:     bnd.COIN_BCH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var bchInitiateRequest = bnd.InitiateRequest{
+	Coin:     bnd.COIN_BCH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var bchTestnetInitiateRequest = bnd.InitiateRequest{
+	Coin:     bnd.COIN_BCH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var bchParticipateRequest = bnd.ParticipateRequest{
+	Coin:     bnd.COIN_BCH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var bchTestnetParticipateRequest = bnd.ParticipateRequest{
+	Coin:     bnd.COIN_BCH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var bchRedeemRequest = bnd.RedeemRequest{
+	Coin:     bnd.COIN_BCH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var bchTestnetRedeemRequest = bnd.RedeemRequest{
+	Coin:     bnd.COIN_BCH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var bchRefundRequest = bnd.RefundRequest{
+	Coin:     bnd.COIN_BCH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var bchTestnetRefundRequest = bnd.RefundRequest{
+	Coin:     bnd.COIN_BCH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var bchExtractSecretRequest = bnd.ExtractSecretRequest{
+	Coin:    bnd.COIN_BCH,
+	Network: bnd.NETWORK_MAINNET,
+}
+
+var bchTestnetExtractSecretRequest = bnd.ExtractSecretRequest{
+	Coin:    bnd.COIN_BCH,
+	Network: bnd.NETWORK_TESTNET,
+}
+
+var bchAuditRequest = bnd.AuditRequest{
+	Coin:    bnd.COIN_BCH,
+	Network: bnd.NETWORK_MAINNET,
+}
+
+var bchTestnetAuditRequest = bnd.AuditRequest{
+	Coin:    bnd.COIN_BCH,
+	Network: bnd.NETWORK_TESTNET,
+}
+
+var bchPublishRequest = bnd.PublishRequest{
+	Coin:     bnd.COIN_BCH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var bchTestnetPublishRequest = bnd.PublishRequest{
+	Coin:     bnd.COIN_BCH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var bchGetTxRequest = bnd.GetTxRequest{
+	Coin:     bnd.COIN_BCH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var bchTestnetGetTxRequest = bnd.GetTxRequest{
+	Coin:     bnd.COIN_BCH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
@@ -7,14 +7,30 @@ import (
 
 // Change these to reflect the coins you have configured
 const (
-	// BTC        = false
-	// BTCTestnet = false
-	LTC        = false
-	LTCTestnet = true
-	XZC        = false
-	XZCTestnet = false
-	DCR        = false
-	DCRTestnet = true
+	// BTC         = false
+	// BTCTestnet  = false
+	LTC         = false
+	LTCTestnet  = true
+	XZC         = false
+	XZCTestnet  = false
+	DCR         = false
+	DCRTestnet  = true
+	BCH         = false
+	BCHTestnet  = false
+	PART        = false
+	PARTTestnet = false
+	QTUM        = false
+	QTUMTestnet = false
+	UTXO        = false
+	UTXOTestnet = false
+	DOGE        = false
+	DOGETestnet = false
+	DASH        = false
+	DASHTestnet = false
+	ZEC         = false
+	ZECTestnet  = false
+	ETH         = false
+	ETHTestnet  = false
 )
 
 func TestClient(t *testing.T) {
@@ -72,6 +88,134 @@ func TestClient(t *testing.T) {
 			return
 		}
 	}
+	if BCH {
+		fmt.Println("\nTest BCH")
+		err := testBCH(false)
+		if err != nil {
+			t.Errorf("Status: %v\n", err.Error())
+			return
+		}
+	}
+	if BCHTestnet {
+		fmt.Println("\nTest BCH [testnet]")
+		err := testBCH(true)
+		if err != nil {
+			t.Errorf("Status: %v\n", err.Error())
+			return
+		}
+	}
+	if PART {
+		fmt.Println("\nTest PART")
+		err := testPART(false)
+		if err != nil {
+			t.Errorf("Status: %v\n", err.Error())
+			return
+		}
+	}
+	if PARTTestnet {
+		fmt.Println("\nTest PART [testnet]")
+		err := testPART(true)
+		if err != nil {
+			t.Errorf("Status: %v\n", err.Error())
+			return
+		}
+	}
+	if QTUM {
+		fmt.Println("\nTest QTUM")
+		err := testQTUM(false)
+		if err != nil {
+			t.Errorf("Status: %v\n", err.Error())
+			return
+		}
+	}
+	if QTUMTestnet {
+		fmt.Println("\nTest QTUM [testnet]")
+		err := testQTUM(true)
+		if err != nil {
+			t.Errorf("Status: %v\n", err.Error())
+			return
+		}
+	}
+	if UTXO {
+		fmt.Println("\nTest UTXO")
+		err := testUTXO(false)
+		if err != nil {
+			t.Errorf("Status: %v\n", err.Error())
+			return
+		}
+	}
+	if UTXOTestnet {
+		fmt.Println("\nTest UTXO [testnet]")
+		err := testUTXO(true)
+		if err != nil {
+			t.Errorf("Status: %v\n", err.Error())
+			return
+		}
+	}
+	if DOGE {
+		fmt.Println("\nTest DOGE")
+		err := testDOGE(false)
+		if err != nil {
+			t.Errorf("Status: %v\n", err.Error())
+			return
+		}
+	}
+	if DOGETestnet {
+		fmt.Println("\nTest DOGE [testnet]")
+		err := testDOGE(true)
+		if err != nil {
+			t.Errorf("Status: %v\n", err.Error())
+			return
+		}
+	}
+	if DASH {
+		fmt.Println("\nTest DASH")
+		err := testDASH(false)
+		if err != nil {
+			t.Errorf("Status: %v\n", err.Error())
+			return
+		}
+	}
+	if DASHTestnet {
+		fmt.Println("\nTest DASH [testnet]")
+		err := testDASH(true)
+		if err != nil {
+			t.Errorf("Status: %v\n", err.Error())
+			return
+		}
+	}
+	if ZEC {
+		fmt.Println("\nTest ZEC")
+		err := testZEC(false)
+		if err != nil {
+			t.Errorf("Status: %v\n", err.Error())
+			return
+		}
+	}
+	if ZECTestnet {
+		fmt.Println("\nTest ZEC [testnet]")
+		err := testZEC(true)
+		if err != nil {
+			t.Errorf("Status: %v\n", err.Error())
+			return
+		}
+	}
+	if ETH {
+		fmt.Println("\nTest ETH")
+		err := testETH(false)
+		if err != nil {
+			t.Errorf("Status: %v\n", err.Error())
+			return
+		}
+	}
+	if ETHTestnet {
+		fmt.Println("\nTest ETH [testnet]")
+		err := testETH(true)
+		if err != nil {
+			t.Errorf("Status: %v\n", err.Error())
+			return
+		}
+	}
 
 	//...
 }
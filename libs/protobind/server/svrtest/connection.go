@@ -6,34 +6,40 @@ import (
 	"log"
 
 	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/auth"
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/certs"
 	"github.com/devwarrior777/atomicswap/libs/protobind/server/svrcfg"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
-var (
-	useTLS             = svrcfg.Config.UseTLS
-	certPath           = svrcfg.Config.CertPath
-	serverAddr         = svrcfg.Config.ServerAddr
-	serverPort         = svrcfg.Config.ServerPort
-	serverHostOverride = svrcfg.Config.HostOverride
-)
-
-// getClientConnection gets a connection to the swap session server
+// getClientConnection gets a connection to the swap session server. The
+// server address and client credentials are from the config file named by
+// ATOMICSWAP_CONFIG or else config.ini
 func getClientConnection() (*grpc.ClientConn, error) {
+	cfg, err := svrcfg.Load("")
+	if err != nil {
+		return nil, err
+	}
 	var opts []grpc.DialOption
-	if useTLS {
-		creds, err := credentials.NewClientTLSFromFile(certPath, serverHostOverride)
+	if cfg.UseTLS {
+		config, err := certs.ClientTLSConfig(cfg.CertPath, cfg.ClientCert, cfg.ClientKey, cfg.HostOverride)
 		if err != nil {
 			log.Fatalf("Failed to create TLS credentials %v", err)
 		}
-		opts = append(opts, grpc.WithTransportCredentials(creds))
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(config)))
 	} else {
 		log.Println("Warning: No TLS")
 		opts = append(opts, grpc.WithInsecure())
 	}
-	serverHostPort := fmt.Sprintf("%s:%d", serverAddr, serverPort)
+	if cfg.AuthToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(auth.Credentials{
+			Token:         cfg.AuthToken,
+			AllowInsecure: !cfg.UseTLS,
+		}))
+	}
+	serverHostPort := fmt.Sprintf("%s:%d", cfg.ServerAddr, cfg.ServerPort)
 	conn, err := grpc.Dial(serverHostPort, opts...)
 	if err != nil {
 		return nil, err
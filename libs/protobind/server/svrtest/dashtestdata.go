@@ -0,0 +1,197 @@
+package svrtest
+
+import (
+	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+)
+
+/*
+TEST DATA FOR THE DASH WALLET RPC COMMANDS
+
+You will need your own testdata that reflects your coins configurations:
+ - Network (mainnet, testnet, regtest...)
+ - RPC Info to connect to your DASH RPC wallet node(s)
+*/
+
+var dashPingWalletRPCRequest = bnd.PingWalletRPCRequest{
+	Coin:     bnd.COIN_DASH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dashTestnetPingWalletRPCRequest = bnd.PingWalletRPCRequest{
+	Coin:     bnd.COIN_DASH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dashNewAddressRequest = bnd.NewAddressRequest{
+	Coin:     bnd.COIN_DASH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dashTestnetNewAddressRequest = bnd.NewAddressRequest{
+	Coin:     bnd.COIN_DASH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dashInitiateRequest = bnd.InitiateRequest{
+	Coin:     bnd.COIN_DASH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var dashTestnetInitiateRequest = bnd.InitiateRequest{
+	Coin:     bnd.COIN_DASH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var dashParticipateRequest = bnd.ParticipateRequest{
+	Coin:     bnd.COIN_DASH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var dashTestnetParticipateRequest = bnd.ParticipateRequest{
+	Coin:     bnd.COIN_DASH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var dashRedeemRequest = bnd.RedeemRequest{
+	Coin:     bnd.COIN_DASH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dashTestnetRedeemRequest = bnd.RedeemRequest{
+	Coin:     bnd.COIN_DASH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dashRefundRequest = bnd.RefundRequest{
+	Coin:     bnd.COIN_DASH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dashTestnetRefundRequest = bnd.RefundRequest{
+	Coin:     bnd.COIN_DASH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dashExtractSecretRequest = bnd.ExtractSecretRequest{
+	Coin:    bnd.COIN_DASH,
+	Network: bnd.NETWORK_MAINNET,
+}
+
+var dashTestnetExtractSecretRequest = bnd.ExtractSecretRequest{
+	Coin:    bnd.COIN_DASH,
+	Network: bnd.NETWORK_TESTNET,
+}
+
+var dashAuditRequest = bnd.AuditRequest{
+	Coin:    bnd.COIN_DASH,
+	Network: bnd.NETWORK_MAINNET,
+}
+
+var dashTestnetAuditRequest = bnd.AuditRequest{
+	Coin:    bnd.COIN_DASH,
+	Network: bnd.NETWORK_TESTNET,
+}
+
+var dashPublishRequest = bnd.PublishRequest{
+	Coin:     bnd.COIN_DASH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dashTestnetPublishRequest = bnd.PublishRequest{
+	Coin:     bnd.COIN_DASH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dashGetTxRequest = bnd.GetTxRequest{
+	Coin:     bnd.COIN_DASH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dashTestnetGetTxRequest = bnd.GetTxRequest{
+	Coin:     bnd.COIN_DASH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
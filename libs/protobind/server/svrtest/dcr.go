@@ -35,7 +35,7 @@ func testDCR(testnet bool) error {
 	if ping.Errorno != bnd.ERRNO_OK {
 		return fmt.Errorf("%v %s", ping.Errorno, ping.Errstr)
 	}
-	fmt.Println("Ping success")
+	fmt.Printf("Ping success - %s %d blocks, version %s %s\n", ping.Chain, ping.Blocks, ping.Version, ping.UserAgent)
 
 	// new address
 	newaddressreq := dcrNewAddressRequest
@@ -121,17 +121,15 @@ func testDCR(testnet bool) error {
 		s := status.Convert(err)
 		return fmt.Errorf("status: %d - %v - %v", s.Code(), s.Code(), s.Message())
 	}
-	if audit.Errorno == bnd.ERRNO_OK {
-		// if here it is an error in the lib
-		fmt.Printf("Audit contract amount:         %d\n", audit.ContractAmount)
-		fmt.Printf("Audit contract address:        %s\n", audit.ContractAddress)
-		fmt.Printf("Audit contract secret hash:    %s\n", audit.ContractSecrethash)
-		fmt.Printf("Audit recipient address:       %s\n", audit.RecipientAddress)
-		fmt.Printf("Audit refund address:          %s\n", audit.RefundAddress)
-		fmt.Printf("Audit refund locktime:         %d\n", audit.RefundLocktime)
-		return errors.New("got audit from an an unsupported lib function")
-	}
-	fmt.Printf("Expected error getting audit: %v %s\n", audit.Errorno, audit.Errstr)
+	if audit.Errorno != bnd.ERRNO_OK {
+		return fmt.Errorf("%v %s", audit.Errorno, audit.Errstr)
+	}
+	fmt.Printf("Audit contract amount:         %d\n", audit.ContractAmount)
+	fmt.Printf("Audit contract address:        %s\n", audit.ContractAddress)
+	fmt.Printf("Audit contract secret hash:    %s\n", audit.ContractSecrethash)
+	fmt.Printf("Audit recipient address:       %s\n", audit.RecipientAddress)
+	fmt.Printf("Audit refund address:          %s\n", audit.RefundAddress)
+	fmt.Printf("Audit refund locktime:         %d\n", audit.RefundLocktime)
 
 	// redeem
 	redeemreq := dcrRedeemRequest
@@ -170,12 +168,10 @@ func testDCR(testnet bool) error {
 		s := status.Convert(err)
 		return fmt.Errorf("status: %d - %v - %v", s.Code(), s.Code(), s.Message())
 	}
-	if extract.Errorno == bnd.ERRNO_OK {
-		// if here it is an error in the lib
-		fmt.Printf("ExtractSecret secret:          %s\n", extract.Secret)
-		return errors.New("extractSecret from an an unsupported lib function")
+	if extract.Errorno != bnd.ERRNO_OK {
+		return fmt.Errorf("%v %s", extract.Errorno, extract.Errstr)
 	}
-	fmt.Printf("Expected error getting audit: %v %s\n", extract.Errorno, extract.Errstr)
+	fmt.Printf("ExtractSecret secret:          %s\n", extract.Secret)
 
 	// refund
 	refundreq := dcrRefundRequest
@@ -7,14 +7,16 @@ import (
 /*
 TEST DATA FOR THE WALLET RPC COMMANDS
 You will need your own testdata that reflects your coins configurations:
- - Testnet or not
+ - Network (mainnet, testnet, regtest...)
  - RPC Info to connect to your DCR gRPC wallet node(s)
  - Your TLS cert path for DCR gRPC wallet - if not the default
+ - Jsonrpc with Rpcuser & Rpcpass to use the DCR wallet JSON-RPC API instead
+ - The wallet account to fund and receive swaps - if not the default account
 */
 
 var dcrPingWalletRPCRequest = bnd.PingWalletRPCRequest{
 	Coin:     bnd.COIN_DCR,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "",
 	Rpcpass:  "",
@@ -24,7 +26,7 @@ var dcrPingWalletRPCRequest = bnd.PingWalletRPCRequest{
 
 var dcrTestnetPingWalletRPCRequest = bnd.PingWalletRPCRequest{
 	Coin:     bnd.COIN_DCR,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "",
 	Rpcpass:  "",
@@ -34,7 +36,7 @@ var dcrTestnetPingWalletRPCRequest = bnd.PingWalletRPCRequest{
 
 var dcrNewAddressRequest = bnd.NewAddressRequest{
 	Coin:     bnd.COIN_DCR,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "",
 	Rpcpass:  "",
@@ -44,7 +46,7 @@ var dcrNewAddressRequest = bnd.NewAddressRequest{
 
 var dcrTestnetNewAddressRequest = bnd.NewAddressRequest{
 	Coin:     bnd.COIN_DCR,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "",
 	Rpcpass:  "",
@@ -53,52 +55,60 @@ var dcrTestnetNewAddressRequest = bnd.NewAddressRequest{
 }
 
 var dcrInitiateRequest = bnd.InitiateRequest{
-	Coin:     bnd.COIN_DCR,
-	Testnet:  false,
-	Hostport: "localhost",
-	Rpcuser:  "",
-	Rpcpass:  "",
-	Wpass:    "123",
-	Certs:    "",
-	Amount:   10000000,
+	Coin:          bnd.COIN_DCR,
+	Network:       bnd.NETWORK_MAINNET,
+	Hostport:      "localhost",
+	Rpcuser:       "",
+	Rpcpass:       "",
+	Wpass:         "123",
+	Certs:         "",
+	Amount:        10000000,
+	Account:       "", // default account
+	RequiredConfs: 1,
 }
 
 var dcrTestnetInitiateRequest = bnd.InitiateRequest{
-	Coin:     bnd.COIN_DCR,
-	Testnet:  true,
-	Hostport: "localhost",
-	Rpcuser:  "",
-	Rpcpass:  "",
-	Wpass:    "123",
-	Certs:    "",
-	Amount:   10000000,
+	Coin:          bnd.COIN_DCR,
+	Network:       bnd.NETWORK_TESTNET,
+	Hostport:      "localhost",
+	Rpcuser:       "",
+	Rpcpass:       "",
+	Wpass:         "123",
+	Certs:         "",
+	Amount:        10000000,
+	Account:       "", // default account
+	RequiredConfs: 1,
 }
 
 var dcrParticipateRequest = bnd.ParticipateRequest{
-	Coin:     bnd.COIN_DCR,
-	Testnet:  false,
-	Hostport: "localhost",
-	Rpcuser:  "",
-	Rpcpass:  "",
-	Wpass:    "123",
-	Certs:    "",
-	Amount:   10000000,
+	Coin:          bnd.COIN_DCR,
+	Network:       bnd.NETWORK_MAINNET,
+	Hostport:      "localhost",
+	Rpcuser:       "",
+	Rpcpass:       "",
+	Wpass:         "123",
+	Certs:         "",
+	Amount:        10000000,
+	Account:       "", // default account
+	RequiredConfs: 1,
 }
 
 var dcrTestnetParticipateRequest = bnd.ParticipateRequest{
-	Coin:     bnd.COIN_DCR,
-	Testnet:  true,
-	Hostport: "localhost",
-	Rpcuser:  "",
-	Rpcpass:  "",
-	Wpass:    "123",
-	Certs:    "",
-	Amount:   10000000,
+	Coin:          bnd.COIN_DCR,
+	Network:       bnd.NETWORK_TESTNET,
+	Hostport:      "localhost",
+	Rpcuser:       "",
+	Rpcpass:       "",
+	Wpass:         "123",
+	Certs:         "",
+	Amount:        10000000,
+	Account:       "", // default account
+	RequiredConfs: 1,
 }
 
 var dcrRedeemRequest = bnd.RedeemRequest{
 	Coin:     bnd.COIN_DCR,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "",
 	Rpcpass:  "",
@@ -108,7 +118,7 @@ var dcrRedeemRequest = bnd.RedeemRequest{
 
 var dcrTestnetRedeemRequest = bnd.RedeemRequest{
 	Coin:     bnd.COIN_DCR,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "",
 	Rpcpass:  "",
@@ -118,7 +128,7 @@ var dcrTestnetRedeemRequest = bnd.RedeemRequest{
 
 var dcrRefundRequest = bnd.RefundRequest{
 	Coin:     bnd.COIN_DCR,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "",
 	Rpcpass:  "",
@@ -128,7 +138,7 @@ var dcrRefundRequest = bnd.RefundRequest{
 
 var dcrTestnetRefundRequest = bnd.RefundRequest{
 	Coin:     bnd.COIN_DCR,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "",
 	Rpcpass:  "",
@@ -138,27 +148,27 @@ var dcrTestnetRefundRequest = bnd.RefundRequest{
 
 var dcrExtractSecretRequest = bnd.ExtractSecretRequest{
 	Coin:    bnd.COIN_DCR,
-	Testnet: false,
+	Network: bnd.NETWORK_MAINNET,
 }
 
 var dcrTestnetExtractSecretRequest = bnd.ExtractSecretRequest{
 	Coin:    bnd.COIN_DCR,
-	Testnet: true,
+	Network: bnd.NETWORK_TESTNET,
 }
 
 var dcrAuditRequest = bnd.AuditRequest{
 	Coin:    bnd.COIN_DCR,
-	Testnet: false,
+	Network: bnd.NETWORK_MAINNET,
 }
 
 var dcrTestnetAuditRequest = bnd.AuditRequest{
 	Coin:    bnd.COIN_DCR,
-	Testnet: true,
+	Network: bnd.NETWORK_TESTNET,
 }
 
 var dcrPublishRequest = bnd.PublishRequest{
 	Coin:     bnd.COIN_DCR,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "",
 	Rpcpass:  "",
@@ -168,7 +178,7 @@ var dcrPublishRequest = bnd.PublishRequest{
 
 var dcrTestnetPublishRequest = bnd.PublishRequest{
 	Coin:     bnd.COIN_DCR,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "",
 	Rpcpass:  "",
@@ -178,7 +188,7 @@ var dcrTestnetPublishRequest = bnd.PublishRequest{
 
 var dcrGetTxRequest = bnd.GetTxRequest{
 	Coin:     bnd.COIN_DCR,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "",
 	Rpcpass:  "",
@@ -188,7 +198,7 @@ var dcrGetTxRequest = bnd.GetTxRequest{
 
 var dcrTestnetGetTxRequest = bnd.GetTxRequest{
 	Coin:     bnd.COIN_DCR,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "",
 	Rpcpass:  "",
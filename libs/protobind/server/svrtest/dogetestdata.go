@@ -0,0 +1,197 @@
+package svrtest
+
+import (
+	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+)
+
+/*
+TEST DATA FOR THE DOGE WALLET RPC COMMANDS
+
+You will need your own testdata that reflects your coins configurations:
+ - Network (mainnet, testnet, regtest...)
+ - RPC Info to connect to your DOGE RPC wallet node(s)
+*/
+
+var dogePingWalletRPCRequest = bnd.PingWalletRPCRequest{
+	Coin:     bnd.COIN_DOGE,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dogeTestnetPingWalletRPCRequest = bnd.PingWalletRPCRequest{
+	Coin:     bnd.COIN_DOGE,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dogeNewAddressRequest = bnd.NewAddressRequest{
+	Coin:     bnd.COIN_DOGE,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dogeTestnetNewAddressRequest = bnd.NewAddressRequest{
+	Coin:     bnd.COIN_DOGE,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dogeInitiateRequest = bnd.InitiateRequest{
+	Coin:     bnd.COIN_DOGE,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   1000000000, // 10 DOGE
+}
+
+var dogeTestnetInitiateRequest = bnd.InitiateRequest{
+	Coin:     bnd.COIN_DOGE,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   1000000000, // 10 DOGE
+}
+
+var dogeParticipateRequest = bnd.ParticipateRequest{
+	Coin:     bnd.COIN_DOGE,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   1000000000, // 10 DOGE
+}
+
+var dogeTestnetParticipateRequest = bnd.ParticipateRequest{
+	Coin:     bnd.COIN_DOGE,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   1000000000, // 10 DOGE
+}
+
+var dogeRedeemRequest = bnd.RedeemRequest{
+	Coin:     bnd.COIN_DOGE,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dogeTestnetRedeemRequest = bnd.RedeemRequest{
+	Coin:     bnd.COIN_DOGE,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dogeRefundRequest = bnd.RefundRequest{
+	Coin:     bnd.COIN_DOGE,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dogeTestnetRefundRequest = bnd.RefundRequest{
+	Coin:     bnd.COIN_DOGE,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dogeExtractSecretRequest = bnd.ExtractSecretRequest{
+	Coin:    bnd.COIN_DOGE,
+	Network: bnd.NETWORK_MAINNET,
+}
+
+var dogeTestnetExtractSecretRequest = bnd.ExtractSecretRequest{
+	Coin:    bnd.COIN_DOGE,
+	Network: bnd.NETWORK_TESTNET,
+}
+
+var dogeAuditRequest = bnd.AuditRequest{
+	Coin:    bnd.COIN_DOGE,
+	Network: bnd.NETWORK_MAINNET,
+}
+
+var dogeTestnetAuditRequest = bnd.AuditRequest{
+	Coin:    bnd.COIN_DOGE,
+	Network: bnd.NETWORK_TESTNET,
+}
+
+var dogePublishRequest = bnd.PublishRequest{
+	Coin:     bnd.COIN_DOGE,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dogeTestnetPublishRequest = bnd.PublishRequest{
+	Coin:     bnd.COIN_DOGE,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dogeGetTxRequest = bnd.GetTxRequest{
+	Coin:     bnd.COIN_DOGE,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var dogeTestnetGetTxRequest = bnd.GetTxRequest{
+	Coin:     bnd.COIN_DOGE,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
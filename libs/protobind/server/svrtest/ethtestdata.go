@@ -0,0 +1,200 @@
+package svrtest
+
+import (
+	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+)
+
+/*
+TEST DATA FOR THE ETH WALLET RPC COMMANDS
+
+You will need your own testdata that reflects your coins configurations:
+ - Network (mainnet, testnet, regtest...)
+ - RPC Info to connect to your ETH RPC node(s)
+ - The eth-main and eth-test wallet profiles of the server config.ini, their
+   certs the keystore directory of the swap account and wpass its passphrase
+ - The swap contract address for the network in the server config.ini
+*/
+
+var ethPingWalletRPCRequest = bnd.PingWalletRPCRequest{
+	Coin:     bnd.COIN_ETH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost:8545",
+	Rpcuser:  "",
+	Rpcpass:  "",
+	Wpass:    "123",
+	Profile:  "eth-main",
+}
+
+var ethTestnetPingWalletRPCRequest = bnd.PingWalletRPCRequest{
+	Coin:     bnd.COIN_ETH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost:8545",
+	Rpcuser:  "",
+	Rpcpass:  "",
+	Wpass:    "123",
+	Profile:  "eth-test",
+}
+
+var ethNewAddressRequest = bnd.NewAddressRequest{
+	Coin:     bnd.COIN_ETH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost:8545",
+	Rpcuser:  "",
+	Rpcpass:  "",
+	Wpass:    "123",
+	Profile:  "eth-main",
+}
+
+var ethTestnetNewAddressRequest = bnd.NewAddressRequest{
+	Coin:     bnd.COIN_ETH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost:8545",
+	Rpcuser:  "",
+	Rpcpass:  "",
+	Wpass:    "123",
+	Profile:  "eth-test",
+}
+
+var ethInitiateRequest = bnd.InitiateRequest{
+	Coin:     bnd.COIN_ETH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost:8545",
+	Rpcuser:  "",
+	Rpcpass:  "",
+	Wpass:    "123",
+	Profile:  "eth-main",
+	Amount:   10000000,
+}
+
+var ethTestnetInitiateRequest = bnd.InitiateRequest{
+	Coin:     bnd.COIN_ETH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost:8545",
+	Rpcuser:  "",
+	Rpcpass:  "",
+	Wpass:    "123",
+	Profile:  "eth-test",
+	Amount:   10000000,
+}
+
+var ethParticipateRequest = bnd.ParticipateRequest{
+	Coin:     bnd.COIN_ETH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost:8545",
+	Rpcuser:  "",
+	Rpcpass:  "",
+	Wpass:    "123",
+	Profile:  "eth-main",
+	Amount:   10000000,
+}
+
+var ethTestnetParticipateRequest = bnd.ParticipateRequest{
+	Coin:     bnd.COIN_ETH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost:8545",
+	Rpcuser:  "",
+	Rpcpass:  "",
+	Wpass:    "123",
+	Profile:  "eth-test",
+	Amount:   10000000,
+}
+
+var ethRedeemRequest = bnd.RedeemRequest{
+	Coin:     bnd.COIN_ETH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost:8545",
+	Rpcuser:  "",
+	Rpcpass:  "",
+	Wpass:    "123",
+	Profile:  "eth-main",
+}
+
+var ethTestnetRedeemRequest = bnd.RedeemRequest{
+	Coin:     bnd.COIN_ETH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost:8545",
+	Rpcuser:  "",
+	Rpcpass:  "",
+	Wpass:    "123",
+	Profile:  "eth-test",
+}
+
+var ethRefundRequest = bnd.RefundRequest{
+	Coin:     bnd.COIN_ETH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost:8545",
+	Rpcuser:  "",
+	Rpcpass:  "",
+	Wpass:    "123",
+	Profile:  "eth-main",
+}
+
+var ethTestnetRefundRequest = bnd.RefundRequest{
+	Coin:     bnd.COIN_ETH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost:8545",
+	Rpcuser:  "",
+	Rpcpass:  "",
+	Wpass:    "123",
+	Profile:  "eth-test",
+}
+
+var ethExtractSecretRequest = bnd.ExtractSecretRequest{
+	Coin:    bnd.COIN_ETH,
+	Network: bnd.NETWORK_MAINNET,
+}
+
+var ethTestnetExtractSecretRequest = bnd.ExtractSecretRequest{
+	Coin:    bnd.COIN_ETH,
+	Network: bnd.NETWORK_TESTNET,
+}
+
+var ethAuditRequest = bnd.AuditRequest{
+	Coin:    bnd.COIN_ETH,
+	Network: bnd.NETWORK_MAINNET,
+}
+
+var ethTestnetAuditRequest = bnd.AuditRequest{
+	Coin:    bnd.COIN_ETH,
+	Network: bnd.NETWORK_TESTNET,
+}
+
+var ethPublishRequest = bnd.PublishRequest{
+	Coin:     bnd.COIN_ETH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost:8545",
+	Rpcuser:  "",
+	Rpcpass:  "",
+	Wpass:    "123",
+	Profile:  "eth-main",
+}
+
+var ethTestnetPublishRequest = bnd.PublishRequest{
+	Coin:     bnd.COIN_ETH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost:8545",
+	Rpcuser:  "",
+	Rpcpass:  "",
+	Wpass:    "123",
+	Profile:  "eth-test",
+}
+
+var ethGetTxRequest = bnd.GetTxRequest{
+	Coin:     bnd.COIN_ETH,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost:8545",
+	Rpcuser:  "",
+	Rpcpass:  "",
+	Wpass:    "123",
+	Profile:  "eth-main",
+}
+
+var ethTestnetGetTxRequest = bnd.GetTxRequest{
+	Coin:     bnd.COIN_ETH,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost:8545",
+	Rpcuser:  "",
+	Rpcpass:  "",
+	Wpass:    "123",
+	Profile:  "eth-test",
+}
@@ -35,7 +35,7 @@ func testLTC(testnet bool) error {
 	if ping.Errorno != bnd.ERRNO_OK {
 		return fmt.Errorf("%v %s", ping.Errorno, ping.Errstr)
 	}
-	fmt.Println("Ping success")
+	fmt.Printf("Ping success - %s %d blocks, version %s %s\n", ping.Chain, ping.Blocks, ping.Version, ping.UserAgent)
 
 	// new address
 	newaddressreq := ltcNewAddressRequest
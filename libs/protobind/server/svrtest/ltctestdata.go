@@ -8,13 +8,13 @@ import (
 TEST DATA FOR THE LTC WALLET RPC COMMANDS
 
 You will need your own testdata that reflects your coins configurations:
- - Testnet or not
+ - Network (mainnet, testnet, regtest...)
  - RPC Info to connect to your LTC RPC wallet node(s)
 */
 
 var ltcPingWalletRPCRequest = bnd.PingWalletRPCRequest{
 	Coin:     bnd.COIN_LTC,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -24,7 +24,7 @@ var ltcPingWalletRPCRequest = bnd.PingWalletRPCRequest{
 
 var ltcTestnetPingWalletRPCRequest = bnd.PingWalletRPCRequest{
 	Coin:     bnd.COIN_LTC,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -34,7 +34,7 @@ var ltcTestnetPingWalletRPCRequest = bnd.PingWalletRPCRequest{
 
 var ltcNewAddressRequest = bnd.NewAddressRequest{
 	Coin:     bnd.COIN_LTC,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -44,7 +44,7 @@ var ltcNewAddressRequest = bnd.NewAddressRequest{
 
 var ltcTestnetNewAddressRequest = bnd.NewAddressRequest{
 	Coin:     bnd.COIN_LTC,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -54,7 +54,7 @@ var ltcTestnetNewAddressRequest = bnd.NewAddressRequest{
 
 var ltcInitiateRequest = bnd.InitiateRequest{
 	Coin:     bnd.COIN_LTC,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -65,7 +65,7 @@ var ltcInitiateRequest = bnd.InitiateRequest{
 
 var ltcTestnetInitiateRequest = bnd.InitiateRequest{
 	Coin:     bnd.COIN_LTC,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -76,7 +76,7 @@ var ltcTestnetInitiateRequest = bnd.InitiateRequest{
 
 var ltcParticipateRequest = bnd.ParticipateRequest{
 	Coin:     bnd.COIN_LTC,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -87,7 +87,7 @@ var ltcParticipateRequest = bnd.ParticipateRequest{
 
 var ltcTestnetParticipateRequest = bnd.ParticipateRequest{
 	Coin:     bnd.COIN_LTC,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -98,7 +98,7 @@ var ltcTestnetParticipateRequest = bnd.ParticipateRequest{
 
 var ltcRedeemRequest = bnd.RedeemRequest{
 	Coin:     bnd.COIN_LTC,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -108,7 +108,7 @@ var ltcRedeemRequest = bnd.RedeemRequest{
 
 var ltcTestnetRedeemRequest = bnd.RedeemRequest{
 	Coin:     bnd.COIN_LTC,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -118,7 +118,7 @@ var ltcTestnetRedeemRequest = bnd.RedeemRequest{
 
 var ltcRefundRequest = bnd.RefundRequest{
 	Coin:     bnd.COIN_LTC,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -128,7 +128,7 @@ var ltcRefundRequest = bnd.RefundRequest{
 
 var ltcTestnetRefundRequest = bnd.RefundRequest{
 	Coin:     bnd.COIN_LTC,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -138,27 +138,27 @@ var ltcTestnetRefundRequest = bnd.RefundRequest{
 
 var ltcExtractSecretRequest = bnd.ExtractSecretRequest{
 	Coin:    bnd.COIN_LTC,
-	Testnet: false,
+	Network: bnd.NETWORK_MAINNET,
 }
 
 var ltcTestnetExtractSecretRequest = bnd.ExtractSecretRequest{
 	Coin:    bnd.COIN_LTC,
-	Testnet: true,
+	Network: bnd.NETWORK_TESTNET,
 }
 
 var ltcAuditRequest = bnd.AuditRequest{
 	Coin:    bnd.COIN_LTC,
-	Testnet: false,
+	Network: bnd.NETWORK_MAINNET,
 }
 
 var ltcTestnetAuditRequest = bnd.AuditRequest{
 	Coin:    bnd.COIN_LTC,
-	Testnet: true,
+	Network: bnd.NETWORK_TESTNET,
 }
 
 var ltcPublishRequest = bnd.PublishRequest{
 	Coin:     bnd.COIN_LTC,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -168,7 +168,7 @@ var ltcPublishRequest = bnd.PublishRequest{
 
 var ltcTestnetPublishRequest = bnd.PublishRequest{
 	Coin:     bnd.COIN_LTC,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -178,7 +178,7 @@ var ltcTestnetPublishRequest = bnd.PublishRequest{
 
 var ltcGetTxRequest = bnd.GetTxRequest{
 	Coin:     bnd.COIN_LTC,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -188,7 +188,7 @@ var ltcGetTxRequest = bnd.GetTxRequest{
 
 var ltcTestnetGetTxRequest = bnd.GetTxRequest{
 	Coin:     bnd.COIN_LTC,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
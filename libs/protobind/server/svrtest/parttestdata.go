@@ -0,0 +1,197 @@
+package svrtest
+
+import (
+	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+)
+
+/*
+TEST DATA FOR THE PART WALLET RPC COMMANDS
+
+You will need your own testdata that reflects your coins configurations:
+ - Network (mainnet, testnet, regtest...)
+ - RPC Info to connect to your PART RPC wallet node(s)
+*/
+
+var partPingWalletRPCRequest = bnd.PingWalletRPCRequest{
+	Coin:     bnd.COIN_PART,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var partTestnetPingWalletRPCRequest = bnd.PingWalletRPCRequest{
+	Coin:     bnd.COIN_PART,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var partNewAddressRequest = bnd.NewAddressRequest{
+	Coin:     bnd.COIN_PART,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var partTestnetNewAddressRequest = bnd.NewAddressRequest{
+	Coin:     bnd.COIN_PART,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var partInitiateRequest = bnd.InitiateRequest{
+	Coin:     bnd.COIN_PART,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var partTestnetInitiateRequest = bnd.InitiateRequest{
+	Coin:     bnd.COIN_PART,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var partParticipateRequest = bnd.ParticipateRequest{
+	Coin:     bnd.COIN_PART,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var partTestnetParticipateRequest = bnd.ParticipateRequest{
+	Coin:     bnd.COIN_PART,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var partRedeemRequest = bnd.RedeemRequest{
+	Coin:     bnd.COIN_PART,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var partTestnetRedeemRequest = bnd.RedeemRequest{
+	Coin:     bnd.COIN_PART,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var partRefundRequest = bnd.RefundRequest{
+	Coin:     bnd.COIN_PART,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var partTestnetRefundRequest = bnd.RefundRequest{
+	Coin:     bnd.COIN_PART,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var partExtractSecretRequest = bnd.ExtractSecretRequest{
+	Coin:    bnd.COIN_PART,
+	Network: bnd.NETWORK_MAINNET,
+}
+
+var partTestnetExtractSecretRequest = bnd.ExtractSecretRequest{
+	Coin:    bnd.COIN_PART,
+	Network: bnd.NETWORK_TESTNET,
+}
+
+var partAuditRequest = bnd.AuditRequest{
+	Coin:    bnd.COIN_PART,
+	Network: bnd.NETWORK_MAINNET,
+}
+
+var partTestnetAuditRequest = bnd.AuditRequest{
+	Coin:    bnd.COIN_PART,
+	Network: bnd.NETWORK_TESTNET,
+}
+
+var partPublishRequest = bnd.PublishRequest{
+	Coin:     bnd.COIN_PART,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var partTestnetPublishRequest = bnd.PublishRequest{
+	Coin:     bnd.COIN_PART,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var partGetTxRequest = bnd.GetTxRequest{
+	Coin:     bnd.COIN_PART,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var partTestnetGetTxRequest = bnd.GetTxRequest{
+	Coin:     bnd.COIN_PART,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
@@ -0,0 +1,197 @@
+package svrtest
+
+import (
+	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+)
+
+/*
+TEST DATA FOR THE QTUM WALLET RPC COMMANDS
+
+You will need your own testdata that reflects your coins configurations:
+ - Network (mainnet, testnet, regtest...)
+ - RPC Info to connect to your QTUM RPC wallet node(s)
+*/
+
+var qtumPingWalletRPCRequest = bnd.PingWalletRPCRequest{
+	Coin:     bnd.COIN_QTUM,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var qtumTestnetPingWalletRPCRequest = bnd.PingWalletRPCRequest{
+	Coin:     bnd.COIN_QTUM,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var qtumNewAddressRequest = bnd.NewAddressRequest{
+	Coin:     bnd.COIN_QTUM,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var qtumTestnetNewAddressRequest = bnd.NewAddressRequest{
+	Coin:     bnd.COIN_QTUM,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var qtumInitiateRequest = bnd.InitiateRequest{
+	Coin:     bnd.COIN_QTUM,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var qtumTestnetInitiateRequest = bnd.InitiateRequest{
+	Coin:     bnd.COIN_QTUM,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var qtumParticipateRequest = bnd.ParticipateRequest{
+	Coin:     bnd.COIN_QTUM,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var qtumTestnetParticipateRequest = bnd.ParticipateRequest{
+	Coin:     bnd.COIN_QTUM,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var qtumRedeemRequest = bnd.RedeemRequest{
+	Coin:     bnd.COIN_QTUM,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var qtumTestnetRedeemRequest = bnd.RedeemRequest{
+	Coin:     bnd.COIN_QTUM,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var qtumRefundRequest = bnd.RefundRequest{
+	Coin:     bnd.COIN_QTUM,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var qtumTestnetRefundRequest = bnd.RefundRequest{
+	Coin:     bnd.COIN_QTUM,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var qtumExtractSecretRequest = bnd.ExtractSecretRequest{
+	Coin:    bnd.COIN_QTUM,
+	Network: bnd.NETWORK_MAINNET,
+}
+
+var qtumTestnetExtractSecretRequest = bnd.ExtractSecretRequest{
+	Coin:    bnd.COIN_QTUM,
+	Network: bnd.NETWORK_TESTNET,
+}
+
+var qtumAuditRequest = bnd.AuditRequest{
+	Coin:    bnd.COIN_QTUM,
+	Network: bnd.NETWORK_MAINNET,
+}
+
+var qtumTestnetAuditRequest = bnd.AuditRequest{
+	Coin:    bnd.COIN_QTUM,
+	Network: bnd.NETWORK_TESTNET,
+}
+
+var qtumPublishRequest = bnd.PublishRequest{
+	Coin:     bnd.COIN_QTUM,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var qtumTestnetPublishRequest = bnd.PublishRequest{
+	Coin:     bnd.COIN_QTUM,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var qtumGetTxRequest = bnd.GetTxRequest{
+	Coin:     bnd.COIN_QTUM,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var qtumTestnetGetTxRequest = bnd.GetTxRequest{
+	Coin:     bnd.COIN_QTUM,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
@@ -0,0 +1,218 @@
+package svrtest
+
+import (
+	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+)
+
+/*
+TEST DATA FOR THE GENERIC UTXO WALLET RPC COMMANDS
+
+You will need your own testdata that reflects your coins configurations:
+ - Network (mainnet, testnet, regtest...)
+ - The coin definition of your coin (here Vertcoin)
+ - RPC Info to connect to your coin's RPC wallet node(s)
+*/
+
+var utxoPingWalletRPCRequest = bnd.PingWalletRPCRequest{
+	Coin:     bnd.COIN_UTXO,
+	Network:  bnd.NETWORK_MAINNET,
+	CoinDef:  "VTC",
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var utxoTestnetPingWalletRPCRequest = bnd.PingWalletRPCRequest{
+	Coin:     bnd.COIN_UTXO,
+	Network:  bnd.NETWORK_TESTNET,
+	CoinDef:  "VTC",
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var utxoNewAddressRequest = bnd.NewAddressRequest{
+	Coin:     bnd.COIN_UTXO,
+	Network:  bnd.NETWORK_MAINNET,
+	CoinDef:  "VTC",
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var utxoTestnetNewAddressRequest = bnd.NewAddressRequest{
+	Coin:     bnd.COIN_UTXO,
+	Network:  bnd.NETWORK_TESTNET,
+	CoinDef:  "VTC",
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var utxoInitiateRequest = bnd.InitiateRequest{
+	Coin:     bnd.COIN_UTXO,
+	Network:  bnd.NETWORK_MAINNET,
+	CoinDef:  "VTC",
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var utxoTestnetInitiateRequest = bnd.InitiateRequest{
+	Coin:     bnd.COIN_UTXO,
+	Network:  bnd.NETWORK_TESTNET,
+	CoinDef:  "VTC",
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var utxoParticipateRequest = bnd.ParticipateRequest{
+	Coin:     bnd.COIN_UTXO,
+	Network:  bnd.NETWORK_MAINNET,
+	CoinDef:  "VTC",
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var utxoTestnetParticipateRequest = bnd.ParticipateRequest{
+	Coin:     bnd.COIN_UTXO,
+	Network:  bnd.NETWORK_TESTNET,
+	CoinDef:  "VTC",
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var utxoRedeemRequest = bnd.RedeemRequest{
+	Coin:     bnd.COIN_UTXO,
+	Network:  bnd.NETWORK_MAINNET,
+	CoinDef:  "VTC",
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var utxoTestnetRedeemRequest = bnd.RedeemRequest{
+	Coin:     bnd.COIN_UTXO,
+	Network:  bnd.NETWORK_TESTNET,
+	CoinDef:  "VTC",
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var utxoRefundRequest = bnd.RefundRequest{
+	Coin:     bnd.COIN_UTXO,
+	Network:  bnd.NETWORK_MAINNET,
+	CoinDef:  "VTC",
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var utxoTestnetRefundRequest = bnd.RefundRequest{
+	Coin:     bnd.COIN_UTXO,
+	Network:  bnd.NETWORK_TESTNET,
+	CoinDef:  "VTC",
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var utxoExtractSecretRequest = bnd.ExtractSecretRequest{
+	Coin:    bnd.COIN_UTXO,
+	Network: bnd.NETWORK_MAINNET,
+	CoinDef: "VTC",
+}
+
+var utxoTestnetExtractSecretRequest = bnd.ExtractSecretRequest{
+	Coin:    bnd.COIN_UTXO,
+	Network: bnd.NETWORK_TESTNET,
+	CoinDef: "VTC",
+}
+
+var utxoAuditRequest = bnd.AuditRequest{
+	Coin:    bnd.COIN_UTXO,
+	Network: bnd.NETWORK_MAINNET,
+	CoinDef: "VTC",
+}
+
+var utxoTestnetAuditRequest = bnd.AuditRequest{
+	Coin:    bnd.COIN_UTXO,
+	Network: bnd.NETWORK_TESTNET,
+	CoinDef: "VTC",
+}
+
+var utxoPublishRequest = bnd.PublishRequest{
+	Coin:     bnd.COIN_UTXO,
+	Network:  bnd.NETWORK_MAINNET,
+	CoinDef:  "VTC",
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var utxoTestnetPublishRequest = bnd.PublishRequest{
+	Coin:     bnd.COIN_UTXO,
+	Network:  bnd.NETWORK_TESTNET,
+	CoinDef:  "VTC",
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var utxoGetTxRequest = bnd.GetTxRequest{
+	Coin:     bnd.COIN_UTXO,
+	Network:  bnd.NETWORK_MAINNET,
+	CoinDef:  "VTC",
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var utxoTestnetGetTxRequest = bnd.GetTxRequest{
+	Coin:     bnd.COIN_UTXO,
+	Network:  bnd.NETWORK_TESTNET,
+	CoinDef:  "VTC",
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
@@ -35,7 +35,7 @@ func testXZC(testnet bool) error {
 	if pingresp.Errorno != bnd.ERRNO_OK {
 		return fmt.Errorf("%v %s", pingresp.Errorno, pingresp.Errstr)
 	}
-	fmt.Println("Ping success")
+	fmt.Printf("Ping success - %s %d blocks, version %s %s\n", pingresp.Chain, pingresp.Blocks, pingresp.Version, pingresp.UserAgent)
 
 	// new address
 	newaddressreq := xzcNewAddressRequest
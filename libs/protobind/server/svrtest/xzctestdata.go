@@ -7,13 +7,13 @@ import (
 /*
 TEST DATA FOR THE XZC WALLET RPC COMMANDS
 You will need your own testdata that reflects your coins configurations:
- - Testnet or not
+ - Network (mainnet, testnet, regtest...)
  - RPC Info to connect to your XZC RPC wallet node(s)
 */
 
 var xzcPingWalletRPCRequest = bnd.PingWalletRPCRequest{
 	Coin:     bnd.COIN_XZC,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -23,7 +23,7 @@ var xzcPingWalletRPCRequest = bnd.PingWalletRPCRequest{
 
 var xzcTestnetPingWalletRPCRequest = bnd.PingWalletRPCRequest{
 	Coin:     bnd.COIN_XZC,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -33,7 +33,7 @@ var xzcTestnetPingWalletRPCRequest = bnd.PingWalletRPCRequest{
 
 var xzcNewAddressRequest = bnd.NewAddressRequest{
 	Coin:     bnd.COIN_XZC,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -43,7 +43,7 @@ var xzcNewAddressRequest = bnd.NewAddressRequest{
 
 var xzcTestnetNewAddressRequest = bnd.NewAddressRequest{
 	Coin:     bnd.COIN_XZC,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -53,7 +53,7 @@ var xzcTestnetNewAddressRequest = bnd.NewAddressRequest{
 
 var xzcInitiateRequest = bnd.InitiateRequest{
 	Coin:     bnd.COIN_XZC,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -64,7 +64,7 @@ var xzcInitiateRequest = bnd.InitiateRequest{
 
 var xzcTestnetInitiateRequest = bnd.InitiateRequest{
 	Coin:     bnd.COIN_XZC,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -75,7 +75,7 @@ var xzcTestnetInitiateRequest = bnd.InitiateRequest{
 
 var xzcParticipateRequest = bnd.ParticipateRequest{
 	Coin:     bnd.COIN_XZC,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -86,7 +86,7 @@ var xzcParticipateRequest = bnd.ParticipateRequest{
 
 var xzcTestnetParticipateRequest = bnd.ParticipateRequest{
 	Coin:     bnd.COIN_XZC,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -97,7 +97,7 @@ var xzcTestnetParticipateRequest = bnd.ParticipateRequest{
 
 var xzcRedeemRequest = bnd.RedeemRequest{
 	Coin:     bnd.COIN_XZC,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -107,7 +107,7 @@ var xzcRedeemRequest = bnd.RedeemRequest{
 
 var xzcTestnetRedeemRequest = bnd.RedeemRequest{
 	Coin:     bnd.COIN_XZC,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -117,7 +117,7 @@ var xzcTestnetRedeemRequest = bnd.RedeemRequest{
 
 var xzcRefundRequest = bnd.RefundRequest{
 	Coin:     bnd.COIN_XZC,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -127,7 +127,7 @@ var xzcRefundRequest = bnd.RefundRequest{
 
 var xzcTestnetRefundRequest = bnd.RefundRequest{
 	Coin:     bnd.COIN_XZC,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -137,27 +137,27 @@ var xzcTestnetRefundRequest = bnd.RefundRequest{
 
 var xzcExtractSecretRequest = bnd.ExtractSecretRequest{
 	Coin:    bnd.COIN_XZC,
-	Testnet: false,
+	Network: bnd.NETWORK_MAINNET,
 }
 
 var xzcTestnetExtractSecretRequest = bnd.ExtractSecretRequest{
 	Coin:    bnd.COIN_XZC,
-	Testnet: true,
+	Network: bnd.NETWORK_TESTNET,
 }
 
 var xzcAuditRequest = bnd.AuditRequest{
 	Coin:    bnd.COIN_XZC,
-	Testnet: false,
+	Network: bnd.NETWORK_MAINNET,
 }
 
 var xzcTestnetAuditRequest = bnd.AuditRequest{
 	Coin:    bnd.COIN_XZC,
-	Testnet: true,
+	Network: bnd.NETWORK_TESTNET,
 }
 
 var xzcPublishRequest = bnd.PublishRequest{
 	Coin:     bnd.COIN_XZC,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -167,7 +167,7 @@ var xzcPublishRequest = bnd.PublishRequest{
 
 var xzcTestnetPublishRequest = bnd.PublishRequest{
 	Coin:     bnd.COIN_XZC,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -177,7 +177,7 @@ var xzcTestnetPublishRequest = bnd.PublishRequest{
 
 var xzcGetTxRequest = bnd.GetTxRequest{
 	Coin:     bnd.COIN_XZC,
-	Testnet:  false,
+	Network:  bnd.NETWORK_MAINNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
@@ -187,7 +187,7 @@ var xzcGetTxRequest = bnd.GetTxRequest{
 
 var xzcTestnetGetTxRequest = bnd.GetTxRequest{
 	Coin:     bnd.COIN_XZC,
-	Testnet:  true,
+	Network:  bnd.NETWORK_TESTNET,
 	Hostport: "localhost",
 	Rpcuser:  "dev",
 	Rpcpass:  "dev",
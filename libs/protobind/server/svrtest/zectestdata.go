@@ -0,0 +1,197 @@
+package svrtest
+
+import (
+	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+)
+
+/*
+TEST DATA FOR THE ZEC WALLET RPC COMMANDS
+
+You will need your own testdata that reflects your coins configurations:
+ - Network (mainnet, testnet, regtest...)
+ - RPC Info to connect to your ZEC RPC wallet node(s)
+*/
+
+var zecPingWalletRPCRequest = bnd.PingWalletRPCRequest{
+	Coin:     bnd.COIN_ZEC,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var zecTestnetPingWalletRPCRequest = bnd.PingWalletRPCRequest{
+	Coin:     bnd.COIN_ZEC,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var zecNewAddressRequest = bnd.NewAddressRequest{
+	Coin:     bnd.COIN_ZEC,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var zecTestnetNewAddressRequest = bnd.NewAddressRequest{
+	Coin:     bnd.COIN_ZEC,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var zecInitiateRequest = bnd.InitiateRequest{
+	Coin:     bnd.COIN_ZEC,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var zecTestnetInitiateRequest = bnd.InitiateRequest{
+	Coin:     bnd.COIN_ZEC,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var zecParticipateRequest = bnd.ParticipateRequest{
+	Coin:     bnd.COIN_ZEC,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var zecTestnetParticipateRequest = bnd.ParticipateRequest{
+	Coin:     bnd.COIN_ZEC,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+	Amount:   10000000,
+}
+
+var zecRedeemRequest = bnd.RedeemRequest{
+	Coin:     bnd.COIN_ZEC,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var zecTestnetRedeemRequest = bnd.RedeemRequest{
+	Coin:     bnd.COIN_ZEC,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var zecRefundRequest = bnd.RefundRequest{
+	Coin:     bnd.COIN_ZEC,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var zecTestnetRefundRequest = bnd.RefundRequest{
+	Coin:     bnd.COIN_ZEC,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var zecExtractSecretRequest = bnd.ExtractSecretRequest{
+	Coin:    bnd.COIN_ZEC,
+	Network: bnd.NETWORK_MAINNET,
+}
+
+var zecTestnetExtractSecretRequest = bnd.ExtractSecretRequest{
+	Coin:    bnd.COIN_ZEC,
+	Network: bnd.NETWORK_TESTNET,
+}
+
+var zecAuditRequest = bnd.AuditRequest{
+	Coin:    bnd.COIN_ZEC,
+	Network: bnd.NETWORK_MAINNET,
+}
+
+var zecTestnetAuditRequest = bnd.AuditRequest{
+	Coin:    bnd.COIN_ZEC,
+	Network: bnd.NETWORK_TESTNET,
+}
+
+var zecPublishRequest = bnd.PublishRequest{
+	Coin:     bnd.COIN_ZEC,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var zecTestnetPublishRequest = bnd.PublishRequest{
+	Coin:     bnd.COIN_ZEC,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var zecGetTxRequest = bnd.GetTxRequest{
+	Coin:     bnd.COIN_ZEC,
+	Network:  bnd.NETWORK_MAINNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
+
+var zecTestnetGetTxRequest = bnd.GetTxRequest{
+	Coin:     bnd.COIN_ZEC,
+	Network:  bnd.NETWORK_TESTNET,
+	Hostport: "localhost",
+	Rpcuser:  "dev",
+	Rpcpass:  "dev",
+	Wpass:    "123",
+	Certs:    "",
+}
@@ -0,0 +1,117 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/rpcerr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// coinRequest is a request for a coin's wallet
+type coinRequest interface {
+	GetCoin() bnd.COIN
+}
+
+// UnaryServerInterceptor counts and times the unary SwapLib RPCs and starts
+// their spans, continuing a trace in the request traceparent metadata
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	method := methodName(info.FullMethod)
+	ctx, span := startServerSpan(ctx, info.FullMethod)
+	defer span.End()
+	if r, ok := req.(coinRequest); ok {
+		span.SetAttributes(attribute.String("atomicswap.coin", r.GetCoin().String()))
+	}
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	rpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	code := rpcerr.ResultCode(resp, err)
+	rpcRequests.WithLabelValues(method, code).Inc()
+	endServerSpan(span, code, err)
+	return resp, err
+}
+
+// StreamServerInterceptor counts the SwapLib RPC streams and starts their
+// spans, continuing a trace in the request traceparent metadata
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	ctx, span := startServerSpan(ss.Context(), info.FullMethod)
+	defer span.End()
+	err := handler(srv, &tracedStream{ServerStream: ss, ctx: ctx})
+	code := rpcerr.ResultCode(nil, err)
+	rpcRequests.WithLabelValues(methodName(info.FullMethod), code).Inc()
+	endServerSpan(span, code, err)
+	return err
+}
+
+// tracedStream is a server stream with the context of its span
+type tracedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedStream) Context() context.Context {
+	return s.ctx
+}
+
+// startServerSpan starts the span of a request
+func startServerSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	ctx, span := startSpan(ctx, strings.TrimPrefix(fullMethod, "/"), trace.SpanKindServer)
+	span.SetAttributes(
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.method", methodName(fullMethod)),
+	)
+	return ctx, span
+}
+
+// endServerSpan sets the result of a request on its span
+func endServerSpan(span trace.Span, code string, err error) {
+	span.SetAttributes(attribute.String("atomicswap.code", code))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, status.Convert(err).Message())
+	} else if code != codes.OK.String() {
+		span.SetStatus(otelcodes.Error, code)
+	}
+}
+
+// methodName gets the method of a full gRPC method name
+// (/package.service/method)
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+// metadataCarrier carries the trace context in gRPC metadata
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
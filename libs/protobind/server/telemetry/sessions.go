@@ -0,0 +1,50 @@
+package telemetry
+
+import (
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// sessionCollector collects the queue and unlock state of the wallet sessions
+// of the swap libs. The wallet label is coin/network/host:port
+type sessionCollector struct {
+	queueDepth *prometheus.Desc
+	unlocked   *prometheus.Desc
+	wait       *prometheus.Desc
+	maxWait    *prometheus.Desc
+}
+
+func init() {
+	prometheus.MustRegister(&sessionCollector{
+		queueDepth: prometheus.NewDesc("atomicswap_wallet_queue_depth",
+			"Wallet-mutating commands running or waiting their turn by wallet.",
+			[]string{"wallet"}, nil),
+		unlocked: prometheus.NewDesc("atomicswap_wallet_unlocked_commands",
+			"Commands needing the wallet unlocked by wallet.",
+			[]string{"wallet"}, nil),
+		wait: prometheus.NewDesc("atomicswap_wallet_queue_wait_seconds",
+			"Time wallet-mutating commands waited for their turn by wallet.",
+			[]string{"wallet"}, nil),
+		maxWait: prometheus.NewDesc("atomicswap_wallet_queue_wait_max_seconds",
+			"Longest wait of a wallet-mutating command for its turn by wallet.",
+			[]string{"wallet"}, nil),
+	})
+}
+
+// Describe sends the descriptors of the session metrics
+func (c *sessionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.queueDepth
+	ch <- c.unlocked
+	ch <- c.wait
+	ch <- c.maxWait
+}
+
+// Collect sends the session metrics
+func (c *sessionCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, s := range libs.Sessions() {
+		ch <- prometheus.MustNewConstMetric(c.queueDepth, prometheus.GaugeValue, float64(s.Queued), s.Wallet)
+		ch <- prometheus.MustNewConstMetric(c.unlocked, prometheus.GaugeValue, float64(s.Unlocked), s.Wallet)
+		ch <- prometheus.MustNewConstSummary(c.wait, s.Waits, s.TotalWait.Seconds(), nil, s.Wallet)
+		ch <- prometheus.MustNewConstMetric(c.maxWait, prometheus.GaugeValue, s.MaxWait.Seconds(), s.Wallet)
+	}
+}
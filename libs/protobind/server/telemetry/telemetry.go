@@ -0,0 +1,141 @@
+// Package telemetry has the server metrics, served for Prometheus, and the
+// OpenTelemetry tracing of requests. A request span is started by the gRPC
+// interceptors and has a child span for each wallet command (wallets layer)
+// which in turn has a child span for each wallet or node RPC call the swap
+// libs make. Spans are exported with OTLP to a collector, such as a local
+// OpenTelemetry collector or Jaeger
+//
+// Amounts and fees are in the coin's smallest unit: satoshis for the bitcoin
+// derived coins, atoms for dcr and gwei for eth
+package telemetry
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName is the service name of the exported spans
+const ServiceName = "atomicswap"
+
+// tracer makes the server spans
+var tracer = otel.Tracer("github.com/devwarrior777/atomicswap/libs/protobind/server")
+
+/////////////
+// Metrics //
+/////////////
+
+var (
+	// SwapLib RPCs by method and result code. The code is the gRPC status
+	// code or, for errno_payload responses, the response ERRNO
+	rpcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "atomicswap",
+		Name:      "rpc_requests_total",
+		Help:      "SwapLib RPC requests by method and result code.",
+	}, []string{"method", "code"})
+
+	// unary SwapLib RPC latency. Event streams last until the swaps are final
+	// so are only counted
+	rpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "atomicswap",
+		Name:      "rpc_duration_seconds",
+		Help:      "SwapLib unary RPC latency by method.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 14),
+	}, []string{"method"})
+
+	// wallet and node RPC calls made by the swap libs
+	walletRPCDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "atomicswap",
+		Name:      "wallet_rpc_duration_seconds",
+		Help:      "Wallet and node RPC call latency by coin and method.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+	}, []string{"coin", "method"})
+
+	walletRPCFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "atomicswap",
+		Name:      "wallet_rpc_failures_total",
+		Help:      "Failed wallet and node RPC calls by coin and method.",
+	}, []string{"coin", "method"})
+
+	// fees of the contract, redeem and refund transactions made
+	feesPaid = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "atomicswap",
+		Name:      "fees_paid_total",
+		Help:      "Fees of the transactions made, in the coin's smallest unit, by coin and command.",
+	}, []string{"coin", "command"})
+
+	// amounts paid into the contracts made
+	amountSwapped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "atomicswap",
+		Name:      "amount_swapped_total",
+		Help:      "Amounts paid into contracts, in the coin's smallest unit, by coin and command.",
+	}, []string{"coin", "command"})
+)
+
+// MetricsServer serves the metrics for Prometheus on /metrics
+type MetricsServer struct {
+	httpServer *http.Server
+}
+
+// NewMetricsServer makes a metrics server
+func NewMetricsServer() *MetricsServer {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	return &MetricsServer{httpServer: &http.Server{Handler: mux}}
+}
+
+// Serve serves the metrics on lis until Stop
+func (m *MetricsServer) Serve(lis net.Listener) error {
+	err := m.httpServer.Serve(lis)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// Stop stops the metrics server
+func (m *MetricsServer) Stop() {
+	m.httpServer.Shutdown(context.Background())
+}
+
+/////////////
+// Tracing //
+/////////////
+
+// StartTracing exports the spans with OTLP over gRPC to the collector at
+// endpoint (host:port) without TLS. sampleRatio is the fraction of the traces
+// started here that are sampled - traces continued from a client keep the
+// client's choice. The returned func flushes the spans and stops the export
+func StartTracing(endpoint string, sampleRatio float64) (func(context.Context) error, error) {
+	exporter, err := otlptracegrpc.New(context.Background(),
+		otlptracegrpc.WithEndpoint(endpoint),
+		otlptracegrpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceNameKey.String(ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// startSpan starts a span of the server tracer
+func startSpan(ctx context.Context, name string, kind trace.SpanKind) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithSpanKind(kind))
+}
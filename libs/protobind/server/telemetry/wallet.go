@@ -0,0 +1,176 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/wallets"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Trace traces the wallet commands of a request and the wallet and node RPC
+// calls they make, and keeps their metrics. The calls are told to the trace
+// by the RPCInfo observer (Observe)
+type Trace struct {
+	coin string
+	mu   sync.Mutex
+	ctx  context.Context // the wallet command span, or the request span
+}
+
+// NewTrace starts tracing the wallet of a request for the coin. ctx has the
+// request span
+func NewTrace(ctx context.Context, coin bnd.COIN) *Trace {
+	return &Trace{coin: coin.String(), ctx: ctx}
+}
+
+// Observe is a libs.RPCObserver starting a span for a wallet or node RPC call
+// and timing it
+func (t *Trace) Observe(method string) func(err error) {
+	t.mu.Lock()
+	ctx := t.ctx
+	t.mu.Unlock()
+	_, span := startSpan(ctx, method, trace.SpanKindClient)
+	span.SetAttributes(
+		attribute.String("rpc.method", method),
+		attribute.String("atomicswap.coin", t.coin),
+	)
+	start := time.Now()
+	return func(err error) {
+		walletRPCDuration.WithLabelValues(t.coin, method).Observe(time.Since(start).Seconds())
+		if err != nil {
+			walletRPCFailures.WithLabelValues(t.coin, method).Inc()
+			span.RecordError(err)
+			span.SetStatus(otelcodes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// start starts the span of a wallet command. The RPC calls observed until
+// the returned func is called are its children
+func (t *Trace) start(command string) func(err error) {
+	t.mu.Lock()
+	parent := t.ctx
+	ctx, span := startSpan(parent, "wallets."+command, trace.SpanKindInternal)
+	t.ctx = ctx
+	t.mu.Unlock()
+	span.SetAttributes(attribute.String("atomicswap.coin", t.coin))
+	return func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(otelcodes.Error, err.Error())
+		}
+		span.End()
+		t.mu.Lock()
+		t.ctx = parent
+		t.mu.Unlock()
+	}
+}
+
+// Wallet wraps a wallet so its commands are traced and the amounts and fees
+// of the transactions it makes are counted
+func (t *Trace) Wallet(w wallets.Wallet) wallets.Wallet {
+	return &tracedWallet{wallet: w, trace: t}
+}
+
+// tracedWallet is a wallet traced by a Trace. It implements wallets.Wallet
+type tracedWallet struct {
+	wallet wallets.Wallet
+	trace  *Trace
+}
+
+func (w *tracedWallet) PingRPC() (*libs.PingResult, error) {
+	end := w.trace.start("PingRPC")
+	result, err := w.wallet.PingRPC()
+	end(err)
+	return result, err
+}
+
+func (w *tracedWallet) GetNewAddress() (string, error) {
+	end := w.trace.start("GetNewAddress")
+	address, err := w.wallet.GetNewAddress()
+	end(err)
+	return address, err
+}
+
+func (w *tracedWallet) Initiate(params libs.InitiateParams) (*libs.InitiateResult, error) {
+	end := w.trace.start("Initiate")
+	result, err := w.wallet.Initiate(params)
+	end(err)
+	if err == nil {
+		amountSwapped.WithLabelValues(w.trace.coin, "initiate").Add(float64(params.CP2Amount))
+		feesPaid.WithLabelValues(w.trace.coin, "initiate").Add(float64(result.ContractFee))
+	}
+	return result, err
+}
+
+func (w *tracedWallet) Participate(params libs.ParticipateParams) (*libs.ParticipateResult, error) {
+	end := w.trace.start("Participate")
+	result, err := w.wallet.Participate(params)
+	end(err)
+	if err == nil {
+		amountSwapped.WithLabelValues(w.trace.coin, "participate").Add(float64(params.CP1Amount))
+		feesPaid.WithLabelValues(w.trace.coin, "participate").Add(float64(result.ContractFee))
+	}
+	return result, err
+}
+
+func (w *tracedWallet) Redeem(params libs.RedeemParams) (*libs.RedeemResult, error) {
+	end := w.trace.start("Redeem")
+	result, err := w.wallet.Redeem(params)
+	end(err)
+	if err == nil {
+		feesPaid.WithLabelValues(w.trace.coin, "redeem").Add(float64(result.RedeemFee))
+	}
+	return result, err
+}
+
+func (w *tracedWallet) Refund(params libs.RefundParams) (*libs.RefundResult, error) {
+	end := w.trace.start("Refund")
+	result, err := w.wallet.Refund(params)
+	end(err)
+	if err == nil {
+		feesPaid.WithLabelValues(w.trace.coin, "refund").Add(float64(result.RefundFee))
+	}
+	return result, err
+}
+
+func (w *tracedWallet) AuditContract(params libs.AuditParams) (*libs.AuditResult, error) {
+	end := w.trace.start("AuditContract")
+	result, err := w.wallet.AuditContract(params)
+	end(err)
+	return result, err
+}
+
+func (w *tracedWallet) Publish(tx string) (string, error) {
+	end := w.trace.start("Publish")
+	txhash, err := w.wallet.Publish(tx)
+	end(err)
+	return txhash, err
+}
+
+func (w *tracedWallet) ExtractSecret(redemptionTx string, secretHash string) (string, error) {
+	end := w.trace.start("ExtractSecret")
+	secret, err := w.wallet.ExtractSecret(redemptionTx, secretHash)
+	end(err)
+	return secret, err
+}
+
+func (w *tracedWallet) GetTx(txid string) (*libs.GetTxResult, error) {
+	end := w.trace.start("GetTx")
+	result, err := w.wallet.GetTx(txid)
+	end(err)
+	return result, err
+}
+
+func (w *tracedWallet) WatchContract(params libs.WatchParams) (*libs.WatchResult, error) {
+	end := w.trace.start("WatchContract")
+	result, err := w.wallet.WatchContract(params)
+	end(err)
+	return result, err
+}
@@ -0,0 +1,74 @@
+package main
+
+// tokens mints, revokes and lists the API tokens of the server token file:
+//
+//	tokens -file tokens.json -mint read -label watcher
+//	tokens -file tokens.json -mint read,spend -label dragon
+//	tokens -file tokens.json -revoke 9c1f0e6a2b7d4c31
+//	tokens -file tokens.json -list
+//
+// A running server picks up changes to the file on the next call
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/auth"
+)
+
+var (
+	fileFlag   = flag.String("file", "tokens.json", "token file - [auth] token_file of the server config.ini")
+	mintFlag   = flag.String("mint", "", "mint a token with these comma separated scopes: read, spend")
+	labelFlag  = flag.String("label", "", "label of a minted token")
+	revokeFlag = flag.String("revoke", "", "revoke the token with this id")
+	listFlag   = flag.Bool("list", false, "list the tokens")
+)
+
+func main() {
+	flag.Parse()
+	store, err := auth.OpenStore(*fileFlag)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	switch {
+	case *mintFlag != "":
+		scopes, err := auth.ParseScopes(*mintFlag)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		credential, token, err := store.Mint(*labelFlag, scopes)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Printf("token %s minted - the credential is shown only once:\n%s\n", token.ID, credential)
+	case *revokeFlag != "":
+		err = store.Revoke(*revokeFlag)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Printf("token %s revoked\n", *revokeFlag)
+	case *listFlag:
+		tokens, err := store.List()
+		if err != nil {
+			log.Fatalln(err)
+		}
+		for _, t := range tokens {
+			scopes := make([]string, len(t.Scopes))
+			for i, s := range t.Scopes {
+				scopes[i] = string(s)
+			}
+			state := "live"
+			if t.Revoked {
+				state = "revoked"
+			}
+			fmt.Printf("%s  %-10s %-7s %s  %s\n", t.ID, strings.Join(scopes, ","), state,
+				t.Created.Format("2006-01-02 15:04"), t.Label)
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
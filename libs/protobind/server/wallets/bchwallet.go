@@ -0,0 +1,70 @@
+package wallets
+
+import (
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/devwarrior777/atomicswap/libs/bch"
+)
+
+// NewBCHWallet constructs a BCHWallet
+func NewBCHWallet(network libs.Network, rpcinfo libs.RPCInfo) *BCHWallet {
+	b := &BCHWallet{
+		Network: network,
+		RPCInfo: rpcinfo,
+	}
+	return b
+}
+
+// PingRPC tests if wallet node RPC is available
+func (b *BCHWallet) PingRPC() (*libs.PingResult, error) {
+	return bch.PingRPC(b.Network, b.RPCInfo)
+}
+
+// GetNewAddress gets a new address from the controlled wallet
+func (b *BCHWallet) GetNewAddress() (string, error) {
+	return bch.GetNewAddress(b.Network, b.RPCInfo)
+}
+
+// Initiate command builds a P2SH contract and a transaction to fund it
+func (b *BCHWallet) Initiate(params libs.InitiateParams) (*libs.InitiateResult, error) {
+	return bch.Initiate(b.Network, b.RPCInfo, params)
+}
+
+// Participate command builds a P2SH contract and a transaction to fund it
+func (b *BCHWallet) Participate(params libs.ParticipateParams) (*libs.ParticipateResult, error) {
+	return bch.Participate(b.Network, b.RPCInfo, params)
+}
+
+// Redeem command builds a transaction to redeem a contract
+func (b *BCHWallet) Redeem(params libs.RedeemParams) (*libs.RedeemResult, error) {
+	return bch.Redeem(b.Network, b.RPCInfo, params)
+}
+
+// Refund command builds a refund transaction for an unredeemed contract
+func (b *BCHWallet) Refund(params libs.RefundParams) (*libs.RefundResult, error) {
+	return bch.Refund(b.Network, b.RPCInfo, params)
+}
+
+// AuditContract command
+func (b *BCHWallet) AuditContract(params libs.AuditParams) (*libs.AuditResult, error) {
+	return bch.AuditContract(b.Network, params)
+}
+
+// Publish command broadcasts a raw hex transaction
+func (b *BCHWallet) Publish(tx string) (string, error) {
+	return bch.Publish(b.Network, b.RPCInfo, tx)
+}
+
+// ExtractSecret returns a secret from the scriptSig of a transaction redeeming a contract
+func (b *BCHWallet) ExtractSecret(redemptionTx string, secretHash string) (string, error) {
+	return bch.ExtractSecret(redemptionTx, secretHash)
+}
+
+// GetTx gets info on a broadcasted transaction
+func (b *BCHWallet) GetTx(txid string) (*libs.GetTxResult, error) {
+	return bch.GetTx(b.Network, b.RPCInfo, txid)
+}
+
+// WatchContract gets the state of a contract on the chain
+func (b *BCHWallet) WatchContract(params libs.WatchParams) (*libs.WatchResult, error) {
+	return bch.WatchContract(b.Network, b.RPCInfo, params)
+}
@@ -0,0 +1,70 @@
+package wallets
+
+import (
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/devwarrior777/atomicswap/libs/dash"
+)
+
+// NewDASHWallet constructs a DASHWallet
+func NewDASHWallet(network libs.Network, rpcinfo libs.RPCInfo) *DASHWallet {
+	d := &DASHWallet{
+		Network: network,
+		RPCInfo: rpcinfo,
+	}
+	return d
+}
+
+// PingRPC tests if wallet node RPC is available
+func (d *DASHWallet) PingRPC() (*libs.PingResult, error) {
+	return dash.PingRPC(d.Network, d.RPCInfo)
+}
+
+// GetNewAddress gets a new address from the controlled wallet
+func (d *DASHWallet) GetNewAddress() (string, error) {
+	return dash.GetNewAddress(d.Network, d.RPCInfo)
+}
+
+// Initiate command builds a P2SH contract and a transaction to fund it
+func (d *DASHWallet) Initiate(params libs.InitiateParams) (*libs.InitiateResult, error) {
+	return dash.Initiate(d.Network, d.RPCInfo, params)
+}
+
+// Participate command builds a P2SH contract and a transaction to fund it
+func (d *DASHWallet) Participate(params libs.ParticipateParams) (*libs.ParticipateResult, error) {
+	return dash.Participate(d.Network, d.RPCInfo, params)
+}
+
+// Redeem command builds a transaction to redeem a contract
+func (d *DASHWallet) Redeem(params libs.RedeemParams) (*libs.RedeemResult, error) {
+	return dash.Redeem(d.Network, d.RPCInfo, params)
+}
+
+// Refund command builds a refund transaction for an unredeemed contract
+func (d *DASHWallet) Refund(params libs.RefundParams) (*libs.RefundResult, error) {
+	return dash.Refund(d.Network, d.RPCInfo, params)
+}
+
+// AuditContract command
+func (d *DASHWallet) AuditContract(params libs.AuditParams) (*libs.AuditResult, error) {
+	return dash.AuditContract(d.Network, params)
+}
+
+// Publish command broadcasts a raw hex transaction
+func (d *DASHWallet) Publish(tx string) (string, error) {
+	return dash.Publish(d.Network, d.RPCInfo, tx)
+}
+
+// ExtractSecret returns a secret from the scriptSig of a transaction redeeming a contract
+func (d *DASHWallet) ExtractSecret(redemptionTx string, secretHash string) (string, error) {
+	return dash.ExtractSecret(redemptionTx, secretHash)
+}
+
+// GetTx gets info on a broadcasted transaction
+func (d *DASHWallet) GetTx(txid string) (*libs.GetTxResult, error) {
+	return dash.GetTx(d.Network, d.RPCInfo, txid)
+}
+
+// WatchContract gets the state of a contract on the chain
+func (d *DASHWallet) WatchContract(params libs.WatchParams) (*libs.WatchResult, error) {
+	return dash.WatchContract(d.Network, d.RPCInfo, params)
+}
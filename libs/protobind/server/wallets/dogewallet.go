@@ -0,0 +1,70 @@
+package wallets
+
+import (
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/devwarrior777/atomicswap/libs/doge"
+)
+
+// NewDOGEWallet constructs a DOGEWallet
+func NewDOGEWallet(network libs.Network, rpcinfo libs.RPCInfo) *DOGEWallet {
+	d := &DOGEWallet{
+		Network: network,
+		RPCInfo: rpcinfo,
+	}
+	return d
+}
+
+// PingRPC tests if wallet node RPC is available
+func (d *DOGEWallet) PingRPC() (*libs.PingResult, error) {
+	return doge.PingRPC(d.Network, d.RPCInfo)
+}
+
+// GetNewAddress gets a new address from the controlled wallet
+func (d *DOGEWallet) GetNewAddress() (string, error) {
+	return doge.GetNewAddress(d.Network, d.RPCInfo)
+}
+
+// Initiate command builds a P2SH contract and a transaction to fund it
+func (d *DOGEWallet) Initiate(params libs.InitiateParams) (*libs.InitiateResult, error) {
+	return doge.Initiate(d.Network, d.RPCInfo, params)
+}
+
+// Participate command builds a P2SH contract and a transaction to fund it
+func (d *DOGEWallet) Participate(params libs.ParticipateParams) (*libs.ParticipateResult, error) {
+	return doge.Participate(d.Network, d.RPCInfo, params)
+}
+
+// Redeem command builds a transaction to redeem a contract
+func (d *DOGEWallet) Redeem(params libs.RedeemParams) (*libs.RedeemResult, error) {
+	return doge.Redeem(d.Network, d.RPCInfo, params)
+}
+
+// Refund command builds a refund transaction for an unredeemed contract
+func (d *DOGEWallet) Refund(params libs.RefundParams) (*libs.RefundResult, error) {
+	return doge.Refund(d.Network, d.RPCInfo, params)
+}
+
+// AuditContract command
+func (d *DOGEWallet) AuditContract(params libs.AuditParams) (*libs.AuditResult, error) {
+	return doge.AuditContract(d.Network, params)
+}
+
+// Publish command broadcasts a raw hex transaction
+func (d *DOGEWallet) Publish(tx string) (string, error) {
+	return doge.Publish(d.Network, d.RPCInfo, tx)
+}
+
+// ExtractSecret returns a secret from the scriptSig of a transaction redeeming a contract
+func (d *DOGEWallet) ExtractSecret(redemptionTx string, secretHash string) (string, error) {
+	return doge.ExtractSecret(redemptionTx, secretHash)
+}
+
+// GetTx gets info on a broadcasted transaction
+func (d *DOGEWallet) GetTx(txid string) (*libs.GetTxResult, error) {
+	return doge.GetTx(d.Network, d.RPCInfo, txid)
+}
+
+// WatchContract gets the state of a contract on the chain
+func (d *DOGEWallet) WatchContract(params libs.WatchParams) (*libs.WatchResult, error) {
+	return doge.WatchContract(d.Network, d.RPCInfo, params)
+}
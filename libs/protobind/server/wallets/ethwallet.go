@@ -0,0 +1,102 @@
+package wallets
+
+import (
+	"fmt"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/devwarrior777/atomicswap/libs/eth"
+)
+
+// SetETHSwapContracts sets the deployed swap contract addresses used for new
+// ETH swaps. An empty address leaves the network without a swap contract. All
+// the addresses are checked before any is set so a bad one changes nothing
+func SetETHSwapContracts(mainnet, testnet, regtest string) error {
+	contracts := map[libs.Network]string{
+		libs.Mainnet: mainnet,
+		libs.Testnet: testnet,
+		libs.Regtest: regtest,
+	}
+	for network, addr := range contracts {
+		if addr == "" {
+			continue
+		}
+		err := eth.CheckSwapContract(network, addr)
+		if err != nil {
+			return fmt.Errorf("%s: %v", network, err)
+		}
+	}
+	for network, addr := range contracts {
+		if addr == "" {
+			continue
+		}
+		err := eth.SetSwapContract(network, addr)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewETHWallet constructs an ETHWallet
+func NewETHWallet(network libs.Network, rpcinfo libs.RPCInfo) *ETHWallet {
+	e := &ETHWallet{
+		Network: network,
+		RPCInfo: rpcinfo,
+	}
+	return e
+}
+
+// PingRPC tests if wallet node RPC is available
+func (e *ETHWallet) PingRPC() (*libs.PingResult, error) {
+	return eth.PingRPC(e.Network, e.RPCInfo)
+}
+
+// GetNewAddress gets a new address from the controlled wallet
+func (e *ETHWallet) GetNewAddress() (string, error) {
+	return eth.GetNewAddress(e.Network, e.RPCInfo)
+}
+
+// Initiate command builds a P2SH contract and a transaction to fund it
+func (e *ETHWallet) Initiate(params libs.InitiateParams) (*libs.InitiateResult, error) {
+	return eth.Initiate(e.Network, e.RPCInfo, params)
+}
+
+// Participate command builds a P2SH contract and a transaction to fund it
+func (e *ETHWallet) Participate(params libs.ParticipateParams) (*libs.ParticipateResult, error) {
+	return eth.Participate(e.Network, e.RPCInfo, params)
+}
+
+// Redeem command builds a transaction to redeem a contract
+func (e *ETHWallet) Redeem(params libs.RedeemParams) (*libs.RedeemResult, error) {
+	return eth.Redeem(e.Network, e.RPCInfo, params)
+}
+
+// Refund command builds a refund transaction for an unredeemed contract
+func (e *ETHWallet) Refund(params libs.RefundParams) (*libs.RefundResult, error) {
+	return eth.Refund(e.Network, e.RPCInfo, params)
+}
+
+// AuditContract command
+func (e *ETHWallet) AuditContract(params libs.AuditParams) (*libs.AuditResult, error) {
+	return eth.AuditContract(e.Network, params)
+}
+
+// Publish command broadcasts a raw hex transaction
+func (e *ETHWallet) Publish(tx string) (string, error) {
+	return eth.Publish(e.Network, e.RPCInfo, tx)
+}
+
+// ExtractSecret returns a secret from the scriptSig of a transaction redeeming a contract
+func (e *ETHWallet) ExtractSecret(redemptionTx string, secretHash string) (string, error) {
+	return eth.ExtractSecret(redemptionTx, secretHash)
+}
+
+// GetTx gets info on a broadcasted transaction
+func (e *ETHWallet) GetTx(txid string) (*libs.GetTxResult, error) {
+	return eth.GetTx(e.Network, e.RPCInfo, txid)
+}
+
+// WatchContract gets the state of a contract on the chain
+func (e *ETHWallet) WatchContract(params libs.WatchParams) (*libs.WatchResult, error) {
+	return eth.WatchContract(e.Network, e.RPCInfo, params)
+}
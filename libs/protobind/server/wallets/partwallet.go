@@ -0,0 +1,70 @@
+package wallets
+
+import (
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/devwarrior777/atomicswap/libs/part"
+)
+
+// NewPARTWallet constructs a PARTWallet
+func NewPARTWallet(network libs.Network, rpcinfo libs.RPCInfo) *PARTWallet {
+	p := &PARTWallet{
+		Network: network,
+		RPCInfo: rpcinfo,
+	}
+	return p
+}
+
+// PingRPC tests if wallet node RPC is available
+func (p *PARTWallet) PingRPC() (*libs.PingResult, error) {
+	return part.PingRPC(p.Network, p.RPCInfo)
+}
+
+// GetNewAddress gets a new address from the controlled wallet
+func (p *PARTWallet) GetNewAddress() (string, error) {
+	return part.GetNewAddress(p.Network, p.RPCInfo)
+}
+
+// Initiate command builds a P2SH contract and a transaction to fund it
+func (p *PARTWallet) Initiate(params libs.InitiateParams) (*libs.InitiateResult, error) {
+	return part.Initiate(p.Network, p.RPCInfo, params)
+}
+
+// Participate command builds a P2SH contract and a transaction to fund it
+func (p *PARTWallet) Participate(params libs.ParticipateParams) (*libs.ParticipateResult, error) {
+	return part.Participate(p.Network, p.RPCInfo, params)
+}
+
+// Redeem command builds a transaction to redeem a contract
+func (p *PARTWallet) Redeem(params libs.RedeemParams) (*libs.RedeemResult, error) {
+	return part.Redeem(p.Network, p.RPCInfo, params)
+}
+
+// Refund command builds a refund transaction for an unredeemed contract
+func (p *PARTWallet) Refund(params libs.RefundParams) (*libs.RefundResult, error) {
+	return part.Refund(p.Network, p.RPCInfo, params)
+}
+
+// AuditContract command
+func (p *PARTWallet) AuditContract(params libs.AuditParams) (*libs.AuditResult, error) {
+	return part.AuditContract(p.Network, params)
+}
+
+// Publish command broadcasts a raw hex transaction
+func (p *PARTWallet) Publish(tx string) (string, error) {
+	return part.Publish(p.Network, p.RPCInfo, tx)
+}
+
+// ExtractSecret returns a secret from the scriptSig of a transaction redeeming a contract
+func (p *PARTWallet) ExtractSecret(redemptionTx string, secretHash string) (string, error) {
+	return part.ExtractSecret(redemptionTx, secretHash)
+}
+
+// GetTx gets info on a broadcasted transaction
+func (p *PARTWallet) GetTx(txid string) (*libs.GetTxResult, error) {
+	return part.GetTx(p.Network, p.RPCInfo, txid)
+}
+
+// WatchContract gets the state of a contract on the chain
+func (p *PARTWallet) WatchContract(params libs.WatchParams) (*libs.WatchResult, error) {
+	return part.WatchContract(p.Network, p.RPCInfo, params)
+}
@@ -0,0 +1,60 @@
+package wallets
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+)
+
+// A Profile is a named wallet configured on the server. Requests that name a
+// profile use its node address and credentials instead of their own
+type Profile struct {
+	Coin    bnd.COIN
+	CoinDef string // coin definition symbol for COIN_UTXO
+	Network libs.Network
+	RPCInfo libs.RPCInfo
+}
+
+// profiles are the wallet profiles keyed by id
+var profiles = struct {
+	sync.RWMutex
+	byID map[string]*Profile
+}{byID: map[string]*Profile{}}
+
+// SetProfiles sets the wallet profiles requests can name, replacing those set
+// before
+func SetProfiles(p map[string]*Profile) {
+	profiles.Lock()
+	profiles.byID = p
+	profiles.Unlock()
+}
+
+// resolveProfile sets the node connection of a named profile on the RPCInfo
+// of a request, checking the profile is for the coin and network of the
+// request. The request's observer and log are kept
+func resolveProfile(id string, network libs.Network, coin bnd.COIN, coinDef string, rpcinfo libs.RPCInfo) (libs.RPCInfo, error) {
+	profiles.RLock()
+	p, ok := profiles.byID[id]
+	profiles.RUnlock()
+	if !ok {
+		return libs.RPCInfo{}, fmt.Errorf("unknown wallet profile %q", id)
+	}
+	if p.Coin != coin || (coin == bnd.COIN_UTXO && !strings.EqualFold(p.CoinDef, coinDef)) {
+		return libs.RPCInfo{}, fmt.Errorf("wallet profile %q is not for coin %s", id, bnd.COIN_name[int32(coin)])
+	}
+	if p.Network != network {
+		return libs.RPCInfo{}, fmt.Errorf("wallet profile %q is for %s not %s", id, p.Network, network)
+	}
+	rpcinfo.User = p.RPCInfo.User
+	rpcinfo.Pass = p.RPCInfo.Pass
+	rpcinfo.HostPort = p.RPCInfo.HostPort
+	rpcinfo.WalletPass = p.RPCInfo.WalletPass
+	rpcinfo.Certs = p.RPCInfo.Certs
+	rpcinfo.JSONRPC = p.RPCInfo.JSONRPC
+	rpcinfo.NodeHostPort = p.RPCInfo.NodeHostPort
+	rpcinfo.NodeCerts = p.RPCInfo.NodeCerts
+	return rpcinfo, nil
+}
@@ -0,0 +1,96 @@
+package wallets
+
+import (
+	"testing"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+)
+
+func TestWalletForCoinProfile(t *testing.T) {
+	SetProfiles(map[string]*Profile{
+		"ltc-test": {
+			Coin:    bnd.COIN_LTC,
+			Network: libs.Testnet,
+			RPCInfo: libs.RPCInfo{
+				User:       "dev",
+				Pass:       "rpcpass",
+				HostPort:   "localhost:19332",
+				WalletPass: "wpass",
+			},
+		},
+	})
+	defer SetProfiles(map[string]*Profile{})
+
+	log := libs.NewLog(nil, libs.F("request_id", "1"))
+	observed := false
+	rpcinfo := libs.RPCInfo{
+		HostPort: "elsewhere:1",
+		User:     "request",
+		Observer: func(string) func(error) {
+			observed = true
+			return func(error) {}
+		},
+		Log: log,
+	}
+	wallet, err := WalletForCoin(libs.Testnet, rpcinfo, bnd.COIN_LTC, "", "ltc-test")
+	if err != nil {
+		t.Fatalf("WalletForCoin: %v", err)
+	}
+	got := wallet.(*LTCWallet).RPCInfo
+	if got.HostPort != "localhost:19332" || got.User != "dev" || got.Pass != "rpcpass" || got.WalletPass != "wpass" {
+		t.Errorf("profile connection not used: %v", got)
+	}
+	if got.Log != log {
+		t.Error("request log lost")
+	}
+	got.Observer.Observe("getblockcount")(nil)
+	if !observed {
+		t.Error("request observer lost")
+	}
+
+	_, err = WalletForCoin(libs.Mainnet, rpcinfo, bnd.COIN_LTC, "", "ltc-test")
+	if err == nil {
+		t.Error("profile used for the wrong network")
+	}
+	_, err = WalletForCoin(libs.Testnet, rpcinfo, bnd.COIN_BCH, "", "ltc-test")
+	if err == nil {
+		t.Error("profile used for the wrong coin")
+	}
+}
+
+func TestWalletForCoinETHKeystore(t *testing.T) {
+	SetProfiles(map[string]*Profile{
+		"eth-test": {
+			Coin:    bnd.COIN_ETH,
+			Network: libs.Testnet,
+			RPCInfo: libs.RPCInfo{HostPort: "localhost:8545", Certs: "/srv/eth/keystore"},
+		},
+	})
+	defer SetProfiles(map[string]*Profile{})
+
+	// a keystore named by the request is not used, with or without a profile
+	rpcinfo := libs.RPCInfo{HostPort: "localhost:8545", Certs: "/tmp/keystore"}
+	for _, profile := range []string{"", "eth-test"} {
+		_, err := WalletForCoin(libs.Testnet, rpcinfo, bnd.COIN_ETH, "", profile)
+		if err == nil {
+			t.Errorf("profile %q: request keystore accepted", profile)
+		}
+	}
+
+	rpcinfo.Certs = ""
+	wallet, err := WalletForCoin(libs.Testnet, rpcinfo, bnd.COIN_ETH, "", "eth-test")
+	if err != nil {
+		t.Fatalf("WalletForCoin: %v", err)
+	}
+	if got := wallet.(*ETHWallet).RPCInfo.Certs; got != "/srv/eth/keystore" {
+		t.Errorf("keystore %q", got)
+	}
+	wallet, err = WalletForCoin(libs.Testnet, rpcinfo, bnd.COIN_ETH, "", "")
+	if err != nil {
+		t.Fatalf("WalletForCoin: %v", err)
+	}
+	if got := wallet.(*ETHWallet).RPCInfo.Certs; got != "" {
+		t.Errorf("keystore %q without a profile", got)
+	}
+}
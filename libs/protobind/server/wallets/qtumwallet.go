@@ -0,0 +1,70 @@
+package wallets
+
+import (
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/devwarrior777/atomicswap/libs/qtum"
+)
+
+// NewQTUMWallet constructs a QTUMWallet
+func NewQTUMWallet(network libs.Network, rpcinfo libs.RPCInfo) *QTUMWallet {
+	q := &QTUMWallet{
+		Network: network,
+		RPCInfo: rpcinfo,
+	}
+	return q
+}
+
+// PingRPC tests if wallet node RPC is available
+func (q *QTUMWallet) PingRPC() (*libs.PingResult, error) {
+	return qtum.PingRPC(q.Network, q.RPCInfo)
+}
+
+// GetNewAddress gets a new address from the controlled wallet
+func (q *QTUMWallet) GetNewAddress() (string, error) {
+	return qtum.GetNewAddress(q.Network, q.RPCInfo)
+}
+
+// Initiate command builds a P2SH contract and a transaction to fund it
+func (q *QTUMWallet) Initiate(params libs.InitiateParams) (*libs.InitiateResult, error) {
+	return qtum.Initiate(q.Network, q.RPCInfo, params)
+}
+
+// Participate command builds a P2SH contract and a transaction to fund it
+func (q *QTUMWallet) Participate(params libs.ParticipateParams) (*libs.ParticipateResult, error) {
+	return qtum.Participate(q.Network, q.RPCInfo, params)
+}
+
+// Redeem command builds a transaction to redeem a contract
+func (q *QTUMWallet) Redeem(params libs.RedeemParams) (*libs.RedeemResult, error) {
+	return qtum.Redeem(q.Network, q.RPCInfo, params)
+}
+
+// Refund command builds a refund transaction for an unredeemed contract
+func (q *QTUMWallet) Refund(params libs.RefundParams) (*libs.RefundResult, error) {
+	return qtum.Refund(q.Network, q.RPCInfo, params)
+}
+
+// AuditContract command
+func (q *QTUMWallet) AuditContract(params libs.AuditParams) (*libs.AuditResult, error) {
+	return qtum.AuditContract(q.Network, params)
+}
+
+// Publish command broadcasts a raw hex transaction
+func (q *QTUMWallet) Publish(tx string) (string, error) {
+	return qtum.Publish(q.Network, q.RPCInfo, tx)
+}
+
+// ExtractSecret returns a secret from the scriptSig of a transaction redeeming a contract
+func (q *QTUMWallet) ExtractSecret(redemptionTx string, secretHash string) (string, error) {
+	return qtum.ExtractSecret(redemptionTx, secretHash)
+}
+
+// GetTx gets info on a broadcasted transaction
+func (q *QTUMWallet) GetTx(txid string) (*libs.GetTxResult, error) {
+	return qtum.GetTx(q.Network, q.RPCInfo, txid)
+}
+
+// WatchContract gets the state of a contract on the chain
+func (q *QTUMWallet) WatchContract(params libs.WatchParams) (*libs.WatchResult, error) {
+	return qtum.WatchContract(q.Network, q.RPCInfo, params)
+}
@@ -0,0 +1,106 @@
+package wallets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// Profile secrets in the server configuration are either plain, read from a
+// file or sealed with the profile key. Sealed secrets are the AES-256-GCM
+// nonce and ciphertext, base64 encoded with the sealedPrefix
+const sealedPrefix = "enc:"
+
+// ProfileKeySize is the size of the key profile secrets are sealed with
+const ProfileKeySize = 32
+
+// NewProfileKey makes a random profile key, hex encoded for a key file
+func NewProfileKey() (string, error) {
+	key := make([]byte, ProfileKeySize)
+	_, err := rand.Read(key)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(key), nil
+}
+
+// ReadProfileKey reads a hex encoded profile key file
+func ReadProfileKey(path string) ([]byte, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil {
+		return nil, fmt.Errorf("profile key %s: %v", path, err)
+	}
+	if len(key) != ProfileKeySize {
+		return nil, fmt.Errorf("profile key %s: want %d bytes, got %d", path, ProfileKeySize, len(key))
+	}
+	return key, nil
+}
+
+// SealSecret seals a secret with the profile key for the server configuration
+func SealSecret(secret string, key []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(secret), nil)
+	return sealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// OpenSecret gets a profile secret from its configured value or, if value is
+// empty, from the first line of file. Sealed values are opened with the
+// profile key
+func OpenSecret(value, file string, key []byte) (string, error) {
+	if value == "" && file != "" {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		value = strings.TrimRight(strings.SplitN(string(b), "\n", 2)[0], "\r")
+	}
+	if !strings.HasPrefix(value, sealedPrefix) {
+		return value, nil
+	}
+	if key == nil {
+		return "", errors.New("sealed secret without a profile key")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, sealedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("sealed secret: %v", err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("sealed secret: too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	secret, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("sealed secret: %v", err)
+	}
+	return string(secret), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,95 @@
+package wallets
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/devwarrior777/atomicswap/libs/utxo"
+)
+
+// coinDefs are the generic UTXO coin definitions keyed by symbol
+var coinDefs = struct {
+	sync.RWMutex
+	defs map[string]*utxo.CoinDef
+}{defs: map[string]*utxo.CoinDef{}}
+
+// SetCoinDefs replaces the generic UTXO coin definitions with those loaded by
+// utxo.LoadCoinDefs. Wallets already made keep their definition
+func SetCoinDefs(defs map[string]*utxo.CoinDef) {
+	coinDefs.Lock()
+	coinDefs.defs = defs
+	coinDefs.Unlock()
+}
+
+// NewUTXOWallet constructs a UTXOWallet for the coin definition symbol
+func NewUTXOWallet(network libs.Network, rpcinfo libs.RPCInfo, coinDef string) (*UTXOWallet, error) {
+	coinDefs.RLock()
+	def, ok := coinDefs.defs[strings.ToUpper(coinDef)]
+	coinDefs.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no coin definition for %q", coinDef)
+	}
+	u := &UTXOWallet{
+		Network: network,
+		RPCInfo: rpcinfo,
+		Def:     def,
+	}
+	return u, nil
+}
+
+// PingRPC tests if wallet node RPC is available
+func (u *UTXOWallet) PingRPC() (*libs.PingResult, error) {
+	return utxo.PingRPC(u.Def, u.Network, u.RPCInfo)
+}
+
+// GetNewAddress gets a new address from the controlled wallet
+func (u *UTXOWallet) GetNewAddress() (string, error) {
+	return utxo.GetNewAddress(u.Def, u.Network, u.RPCInfo)
+}
+
+// Initiate command builds a P2SH contract and a transaction to fund it
+func (u *UTXOWallet) Initiate(params libs.InitiateParams) (*libs.InitiateResult, error) {
+	return utxo.Initiate(u.Def, u.Network, u.RPCInfo, params)
+}
+
+// Participate command builds a P2SH contract and a transaction to fund it
+func (u *UTXOWallet) Participate(params libs.ParticipateParams) (*libs.ParticipateResult, error) {
+	return utxo.Participate(u.Def, u.Network, u.RPCInfo, params)
+}
+
+// Redeem command builds a transaction to redeem a contract
+func (u *UTXOWallet) Redeem(params libs.RedeemParams) (*libs.RedeemResult, error) {
+	return utxo.Redeem(u.Def, u.Network, u.RPCInfo, params)
+}
+
+// Refund command builds a refund transaction for an unredeemed contract
+func (u *UTXOWallet) Refund(params libs.RefundParams) (*libs.RefundResult, error) {
+	return utxo.Refund(u.Def, u.Network, u.RPCInfo, params)
+}
+
+// AuditContract command
+func (u *UTXOWallet) AuditContract(params libs.AuditParams) (*libs.AuditResult, error) {
+	return utxo.AuditContract(u.Def, u.Network, params)
+}
+
+// Publish command broadcasts a raw hex transaction
+func (u *UTXOWallet) Publish(tx string) (string, error) {
+	return utxo.Publish(u.Def, u.Network, u.RPCInfo, tx)
+}
+
+// ExtractSecret returns a secret from the scriptSig of a transaction redeeming a contract
+func (u *UTXOWallet) ExtractSecret(redemptionTx string, secretHash string) (string, error) {
+	return utxo.ExtractSecret(redemptionTx, secretHash)
+}
+
+// GetTx gets info on a broadcasted transaction
+func (u *UTXOWallet) GetTx(txid string) (*libs.GetTxResult, error) {
+	return utxo.GetTx(u.Def, u.Network, u.RPCInfo, txid)
+}
+
+// WatchContract gets the state of a contract on the chain
+func (u *UTXOWallet) WatchContract(params libs.WatchParams) (*libs.WatchResult, error) {
+	return utxo.WatchContract(u.Def, u.Network, u.RPCInfo, params)
+}
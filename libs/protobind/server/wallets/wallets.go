@@ -1,10 +1,12 @@
 package wallets
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/devwarrior777/atomicswap/libs"
 	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+	"github.com/devwarrior777/atomicswap/libs/utxo"
 )
 
 ////////////////////////////////////
@@ -14,8 +16,9 @@ import (
 // Wallet methods needed to access an RPC wallet node
 type Wallet interface {
 
-	// PingRPC tests if wallet node RPC is available
-	PingRPC() error
+	// PingRPC tests if wallet node RPC is available and reports the node's
+	// chain, version and capabilities
+	PingRPC() (*libs.PingResult, error)
 
 	// GetNewAddress gets a new address from the controlled wallet
 	GetNewAddress() (string, error)
@@ -43,6 +46,10 @@ type Wallet interface {
 
 	// GetTx gets info on a broadcasted transaction
 	GetTx(txid string) (*libs.GetTxResult, error)
+
+	// WatchContract gets the state of a contract on the chain: the contract tx
+	// confirmations and the spend, if any, with the secret of a redeem
+	WatchContract(params libs.WatchParams) (*libs.WatchResult, error)
 }
 
 /////////////////////////////
@@ -51,40 +58,135 @@ type Wallet interface {
 
 // A BTCWallet can access a Bitcoin wallet node and implements Wallet
 type BTCWallet struct {
-	Testnet bool
+	Network libs.Network
 	RPCInfo libs.RPCInfo
 }
 
 // An LTCWallet can access a Litecoin wallet node and implements Wallet
 type LTCWallet struct {
-	Testnet bool
+	Network libs.Network
 	RPCInfo libs.RPCInfo
 }
 
 // An XZCWallet can access a Zcoin wallet node and implements Wallet
 type XZCWallet struct {
-	Testnet bool
+	Network libs.Network
 	RPCInfo libs.RPCInfo
 }
 
 // A DCRWallet can access a Decred wallet and implements Wallet
 type DCRWallet struct {
-	Testnet bool
+	Network libs.Network
+	RPCInfo libs.RPCInfo
+}
+
+// A BCHWallet can access a Bitcoin Cash wallet node and implements Wallet
+type BCHWallet struct {
+	Network libs.Network
+	RPCInfo libs.RPCInfo
+}
+
+// A PARTWallet can access a Particl wallet node and implements Wallet
+type PARTWallet struct {
+	Network libs.Network
+	RPCInfo libs.RPCInfo
+}
+
+// A QTUMWallet can access a Qtum wallet node and implements Wallet
+type QTUMWallet struct {
+	Network libs.Network
+	RPCInfo libs.RPCInfo
+}
+
+// A UTXOWallet can access the wallet node of any bitcoin derived coin which
+// has a coin definition and implements Wallet
+type UTXOWallet struct {
+	Network libs.Network
+	RPCInfo libs.RPCInfo
+	Def     *utxo.CoinDef
+}
+
+// A DOGEWallet can access a Dogecoin wallet node and implements Wallet
+type DOGEWallet struct {
+	Network libs.Network
+	RPCInfo libs.RPCInfo
+}
+
+// A DASHWallet can access a Dash wallet node and implements Wallet
+type DASHWallet struct {
+	Network libs.Network
+	RPCInfo libs.RPCInfo
+}
+
+// A ZECWallet can access a Zcash wallet node and implements Wallet
+type ZECWallet struct {
+	Network libs.Network
+	RPCInfo libs.RPCInfo
+}
+
+// An ETHWallet can access an Ethereum node and a keystore account and
+// implements Wallet
+type ETHWallet struct {
+	Network libs.Network
 	RPCInfo libs.RPCInfo
 }
 
 //...
 
-// WalletForCoin gets a concrete wallet for a coin name
-// func WalletForCoin(testnet bool, rpcinfo libs.RPCInfo, coinName string) (Wallet, error) {
-func WalletForCoin(testnet bool, rpcinfo libs.RPCInfo, coin bnd.COIN) (Wallet, error) {
+// RequestNetwork gets the network of a request. The NETWORK field supersedes
+// the testnet flag which is still honoured for older clients
+func RequestNetwork(network bnd.NETWORK, testnet bool) libs.Network {
+	if network == bnd.NETWORK_MAINNET && testnet {
+		return libs.Testnet
+	}
+	return libs.Network(network)
+}
+
+// WalletForCoin gets a concrete wallet for a coin name. coinDef is the coin
+// definition symbol and is only used for the generic coin COIN_UTXO. If a
+// wallet profile is named its node connection is used rather than the
+// request's, keeping the request's RPC observer and log. The server reads and
+// writes the eth keystore so it is only taken from a profile, never from the
+// request's certs
+// func WalletForCoin(network libs.Network, rpcinfo libs.RPCInfo, coinName string) (Wallet, error) {
+func WalletForCoin(network libs.Network, rpcinfo libs.RPCInfo, coin bnd.COIN, coinDef, profile string) (Wallet, error) {
+	if coin == bnd.COIN_ETH && rpcinfo.Certs != "" {
+		return nil, errors.New("the eth keystore is set by a wallet profile, not the request certs")
+	}
+	if profile != "" {
+		var err error
+		rpcinfo, err = resolveProfile(profile, network, coin, coinDef, rpcinfo)
+		if err != nil {
+			return nil, err
+		}
+	}
 	switch coin {
 	case bnd.COIN_LTC:
-		return NewLTCWallet(testnet, rpcinfo), nil
+		return NewLTCWallet(network, rpcinfo), nil
 	case bnd.COIN_XZC:
-		return NewXZCWallet(testnet, rpcinfo), nil
+		return NewXZCWallet(network, rpcinfo), nil
 	case bnd.COIN_DCR:
-		return NewDCRWallet(testnet, rpcinfo), nil
+		return NewDCRWallet(network, rpcinfo), nil
+	case bnd.COIN_BCH:
+		return NewBCHWallet(network, rpcinfo), nil
+	case bnd.COIN_PART:
+		return NewPARTWallet(network, rpcinfo), nil
+	case bnd.COIN_QTUM:
+		return NewQTUMWallet(network, rpcinfo), nil
+	case bnd.COIN_UTXO:
+		u, err := NewUTXOWallet(network, rpcinfo, coinDef)
+		if err != nil {
+			return nil, err
+		}
+		return u, nil
+	case bnd.COIN_DOGE:
+		return NewDOGEWallet(network, rpcinfo), nil
+	case bnd.COIN_DASH:
+		return NewDASHWallet(network, rpcinfo), nil
+	case bnd.COIN_ZEC:
+		return NewZECWallet(network, rpcinfo), nil
+	case bnd.COIN_ETH:
+		return NewETHWallet(network, rpcinfo), nil
 	}
 	return nil, fmt.Errorf("unsupported coin %s", bnd.COIN_name[int32(coin)])
 }
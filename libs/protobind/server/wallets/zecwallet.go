@@ -0,0 +1,70 @@
+package wallets
+
+import (
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/devwarrior777/atomicswap/libs/zec"
+)
+
+// NewZECWallet constructs a ZECWallet
+func NewZECWallet(network libs.Network, rpcinfo libs.RPCInfo) *ZECWallet {
+	z := &ZECWallet{
+		Network: network,
+		RPCInfo: rpcinfo,
+	}
+	return z
+}
+
+// PingRPC tests if wallet node RPC is available
+func (z *ZECWallet) PingRPC() (*libs.PingResult, error) {
+	return zec.PingRPC(z.Network, z.RPCInfo)
+}
+
+// GetNewAddress gets a new address from the controlled wallet
+func (z *ZECWallet) GetNewAddress() (string, error) {
+	return zec.GetNewAddress(z.Network, z.RPCInfo)
+}
+
+// Initiate command builds a P2SH contract and a transaction to fund it
+func (z *ZECWallet) Initiate(params libs.InitiateParams) (*libs.InitiateResult, error) {
+	return zec.Initiate(z.Network, z.RPCInfo, params)
+}
+
+// Participate command builds a P2SH contract and a transaction to fund it
+func (z *ZECWallet) Participate(params libs.ParticipateParams) (*libs.ParticipateResult, error) {
+	return zec.Participate(z.Network, z.RPCInfo, params)
+}
+
+// Redeem command builds a transaction to redeem a contract
+func (z *ZECWallet) Redeem(params libs.RedeemParams) (*libs.RedeemResult, error) {
+	return zec.Redeem(z.Network, z.RPCInfo, params)
+}
+
+// Refund command builds a refund transaction for an unredeemed contract
+func (z *ZECWallet) Refund(params libs.RefundParams) (*libs.RefundResult, error) {
+	return zec.Refund(z.Network, z.RPCInfo, params)
+}
+
+// AuditContract command
+func (z *ZECWallet) AuditContract(params libs.AuditParams) (*libs.AuditResult, error) {
+	return zec.AuditContract(z.Network, params)
+}
+
+// Publish command broadcasts a raw hex transaction
+func (z *ZECWallet) Publish(tx string) (string, error) {
+	return zec.Publish(z.Network, z.RPCInfo, tx)
+}
+
+// ExtractSecret returns a secret from the scriptSig of a transaction redeeming a contract
+func (z *ZECWallet) ExtractSecret(redemptionTx string, secretHash string) (string, error) {
+	return zec.ExtractSecret(redemptionTx, secretHash)
+}
+
+// GetTx gets info on a broadcasted transaction
+func (z *ZECWallet) GetTx(txid string) (*libs.GetTxResult, error) {
+	return zec.GetTx(z.Network, z.RPCInfo, txid)
+}
+
+// WatchContract gets the state of a contract on the chain
+func (z *ZECWallet) WatchContract(params libs.WatchParams) (*libs.WatchResult, error) {
+	return zec.WatchContract(z.Network, z.RPCInfo, params)
+}
@@ -0,0 +1,296 @@
+package watch
+
+import (
+	"sync"
+	"time"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	bnd "github.com/devwarrior777/atomicswap/libs/protobind"
+	"github.com/devwarrior777/atomicswap/libs/protobind/server/wallets"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before it
+// is dropped
+const subscriberBuffer = 64
+
+// Monitor polls the chains of watched contracts through the coin wallets and
+// fans the swap events out to subscribers. Subscribers of the same contract
+// share one poller
+type Monitor struct {
+	interval time.Duration
+	mu       sync.Mutex
+	watches  map[string]*watch
+}
+
+// NewMonitor makes a Monitor polling every interval
+func NewMonitor(interval time.Duration) *Monitor {
+	return &Monitor{
+		interval: interval,
+		watches:  make(map[string]*watch),
+	}
+}
+
+// SetInterval sets the poll interval of the contracts watched from now on.
+// Contracts already watched keep their interval
+func (m *Monitor) SetInterval(interval time.Duration) {
+	m.mu.Lock()
+	m.interval = interval
+	m.mu.Unlock()
+}
+
+// Subscription is a subscriber's stream of swap events. Events is closed if
+// the subscriber falls too far behind
+type Subscription struct {
+	Events <-chan *bnd.SwapEvent
+	events chan *bnd.SwapEvent
+	w      *watch
+	m      *Monitor
+}
+
+// Subscribe subscribes to the events of a contract. key identifies the
+// contract and the wallet watching it. A new subscriber is sent the events of
+// the contract state so far. log is the log of the poller if one is started
+func (m *Monitor) Subscribe(key string, wallet wallets.Wallet, params libs.WatchParams, log *libs.Log) *Subscription {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w, ok := m.watches[key]
+	if !ok {
+		w = &watch{
+			wallet: wallet,
+			params: params,
+			log:    log,
+			subs:   make(map[*Subscription]bool),
+			stop:   make(chan struct{}),
+		}
+		w.params.Import = true
+		m.watches[key] = w
+		go w.run(m.interval)
+	}
+	s := &Subscription{events: make(chan *bnd.SwapEvent, subscriberBuffer), w: w, m: m}
+	s.Events = s.events
+	w.add(s)
+	return s
+}
+
+// Close ends a subscription. The contract poller stops with its last
+// subscriber
+func (s *Subscription) Close() {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	if s.w.remove(s) {
+		for key, w := range s.m.watches {
+			if w == s.w {
+				delete(s.m.watches, key)
+			}
+		}
+		close(s.w.stop)
+	}
+}
+
+// watch is the poller of one contract
+type watch struct {
+	wallet wallets.Wallet
+	params libs.WatchParams
+	log    *libs.Log
+	stop   chan struct{}
+
+	mu      sync.Mutex
+	subs    map[*Subscription]bool
+	last    *libs.WatchResult
+	lastErr string
+}
+
+func (w *watch) add(s *Subscription) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subs[s] = true
+	for _, ev := range w.events(nil, w.last) {
+		s.send(ev)
+	}
+}
+
+// remove removes a subscriber and reports if it was the last one
+func (w *watch) remove(s *Subscription) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.subs[s] {
+		delete(w.subs, s)
+		if s.events != nil {
+			close(s.events)
+			s.events = nil
+		}
+	}
+	return len(w.subs) == 0
+}
+
+// send sends an event or, if the subscriber is too far behind, closes its
+// events
+func (s *Subscription) send(ev *bnd.SwapEvent) {
+	if s.events == nil {
+		return
+	}
+	select {
+	case s.events <- ev:
+	default:
+		close(s.events)
+		s.events = nil
+	}
+}
+
+func (w *watch) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		w.poll()
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll gets the contract state and sends the events of the change since the
+// last poll. A poll error is sent once until the next successful poll
+func (w *watch) poll() {
+	result, err := w.wallet.WatchContract(w.params)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err != nil {
+		if err.Error() != w.lastErr {
+			w.log.Warn("watch poll failed", libs.F("contract_txid", w.params.ContractTxid), libs.F("error", err))
+			w.lastErr = err.Error()
+			w.broadcast(&bnd.SwapEvent{
+				Contract:     w.params.Contract,
+				ContractTxid: w.params.ContractTxid,
+				Errorno:      bnd.ERRNO_LIBS,
+				Errstr:       err.Error(),
+			})
+		}
+		return
+	}
+	w.lastErr = ""
+	w.params.Import = false
+	w.params.Rescan = false
+	events := w.events(w.last, result)
+	if w.last != nil && reorged(w.last, result) {
+		// search again for the spend from the contract block
+		w.params.ScanHeight = 0
+		w.params.SpendTxid = ""
+	} else {
+		if result.ScannedHeight > 0 {
+			w.params.ScanHeight = result.ScannedHeight + 1
+		}
+		w.params.SpendTxid = result.SpendTxid
+	}
+	w.last = result
+	for _, ev := range events {
+		w.broadcast(ev)
+	}
+}
+
+// events gets the events of the contract state change
+func (w *watch) events(prev, cur *libs.WatchResult) []*bnd.SwapEvent {
+	events := Events(prev, cur)
+	for _, ev := range events {
+		ev.Contract = w.params.Contract
+		ev.ContractTxid = w.params.ContractTxid
+	}
+	return events
+}
+
+func (w *watch) broadcast(ev *bnd.SwapEvent) {
+	for s := range w.subs {
+		s.send(ev)
+	}
+}
+
+// Events gets the swap events of the change of a contract state from prev,
+// which is nil for the first state
+func Events(prev, cur *libs.WatchResult) []*bnd.SwapEvent {
+	if cur == nil {
+		return nil
+	}
+	var events []*bnd.SwapEvent
+	event := func(kind bnd.SWAP_EVENT) {
+		ev := &bnd.SwapEvent{
+			Event:              kind,
+			Confirmations:      cur.Confirmations,
+			Blockhash:          cur.Blockhash,
+			Blocks:             cur.Blocks,
+			RefundLocktime:     cur.RefundLocktime,
+			SpendTxid:          cur.SpendTxid,
+			SpendTx:            cur.SpendTx,
+			SpendConfirmations: cur.SpendConfirmations,
+		}
+		if kind == bnd.SWAP_EVENT_REDEEMED {
+			ev.Secret = cur.Secret
+		}
+		events = append(events, ev)
+	}
+	if prev == nil {
+		prev = &libs.WatchResult{}
+	}
+
+	if reorged(prev, cur) {
+		event(bnd.SWAP_EVENT_REORG)
+	}
+	if !cur.Found {
+		return events
+	}
+	if cur.Confirmations == 0 && (!prev.Found || prev.Confirmations > 0) {
+		event(bnd.SWAP_EVENT_MEMPOOL)
+	}
+	if (cur.Confirmations > 0 && cur.Confirmations != prev.Confirmations) ||
+		(spendFound(cur) && cur.SpendConfirmations != prev.SpendConfirmations) {
+		event(bnd.SWAP_EVENT_CONFIRMATIONS)
+	}
+	if spendFound(cur) && (!spendFound(prev) || cur.SpendTxid != prev.SpendTxid) {
+		if cur.Redeemed {
+			event(bnd.SWAP_EVENT_REDEEMED)
+		} else {
+			event(bnd.SWAP_EVENT_REFUNDED)
+		}
+	}
+	if !cur.Spent && locktimeReached(cur) && (!locktimeReached(prev) || prev.Spent) {
+		event(bnd.SWAP_EVENT_LOCKTIME)
+	}
+	return events
+}
+
+// reorged checks if the contract or the spend tx has left the block or the
+// chain it was in
+func reorged(prev, cur *libs.WatchResult) bool {
+	if prev.Found && (!cur.Found || (prev.Blockhash != "" && cur.Blockhash != prev.Blockhash)) {
+		return true
+	}
+	if spendFound(prev) {
+		if !cur.Spent || (cur.SpendTxid != prev.SpendTxid && cur.SpendTxid != "") {
+			return true
+		}
+		if cur.SpendTxid == prev.SpendTxid && cur.SpendConfirmations < prev.SpendConfirmations {
+			return true
+		}
+	}
+	return false
+}
+
+// spendFound checks if the spend tx of a spent contract is known. The eth
+// swap contract reports a mined spend without the tx
+func spendFound(r *libs.WatchResult) bool {
+	return r.Spent && (r.SpendTxid != "" || r.SpendConfirmations > 0)
+}
+
+// lockTimeThreshold is the lock time below which it is a block height
+const lockTimeThreshold = 500000000
+
+// locktimeReached checks if the refund locktime of the contract is reached
+func locktimeReached(r *libs.WatchResult) bool {
+	if r.RefundLocktime <= 0 {
+		return false
+	}
+	if r.RefundLocktime < lockTimeThreshold {
+		return r.Blocks >= r.RefundLocktime
+	}
+	return r.MedianTime >= r.RefundLocktime
+}
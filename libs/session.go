@@ -0,0 +1,148 @@
+package libs
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Wallet sessions. Commands made concurrently on one wallet, such as by the
+// server for several clients, must not interleave their wallet changes nor
+// lock an encrypted wallet while another command is signing with it. A
+// WalletSession serializes the wallet-mutating commands of a wallet and
+// counts the commands needing the wallet unlocked: the first unlocks it and
+// the last locks it again
+
+// WalletSession is the session of one wallet node in this process
+type WalletSession struct {
+	key  string
+	turn sync.Mutex // held by the running mutating command
+	lock sync.Mutex // serializes the wallet unlocks and locks
+
+	mu        sync.Mutex // guards the unlock count and the stats
+	unlocked  int
+	queued    int
+	waits     uint64
+	totalWait time.Duration
+	maxWait   time.Duration
+}
+
+// SessionStats are the queue and unlock state of a wallet session
+type SessionStats struct {
+	Wallet    string        // coin/network/host:port
+	Queued    int           // mutating commands running or waiting their turn
+	Unlocked  int           // commands needing the wallet unlocked
+	Waits     uint64        // mutating commands that have had their turn
+	TotalWait time.Duration // time the commands waited for their turn
+	MaxWait   time.Duration // longest wait for a turn
+}
+
+var sessions = struct {
+	sync.Mutex
+	m map[string]*WalletSession
+}{m: make(map[string]*WalletSession)}
+
+// Session gets the session of the wallet node of a coin at hostport
+func Session(coin string, network Network, hostport string) *WalletSession {
+	key := fmt.Sprintf("%s/%s/%s", coin, network, hostport)
+	sessions.Lock()
+	defer sessions.Unlock()
+	s, ok := sessions.m[key]
+	if !ok {
+		s = &WalletSession{key: key}
+		sessions.m[key] = s
+	}
+	return s
+}
+
+// Sessions gets the stats of the wallet sessions in wallet order
+func Sessions() []SessionStats {
+	sessions.Lock()
+	all := make([]*WalletSession, 0, len(sessions.m))
+	for _, s := range sessions.m {
+		all = append(all, s)
+	}
+	sessions.Unlock()
+	stats := make([]SessionStats, 0, len(all))
+	for _, s := range all {
+		stats = append(stats, s.Stats())
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Wallet < stats[j].Wallet })
+	return stats
+}
+
+// Stats gets the stats of the session
+func (s *WalletSession) Stats() SessionStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return SessionStats{
+		Wallet:    s.key,
+		Queued:    s.queued,
+		Unlocked:  s.unlocked,
+		Waits:     s.waits,
+		TotalWait: s.totalWait,
+		MaxWait:   s.maxWait,
+	}
+}
+
+// Begin waits for the turn of a wallet-mutating command and returns the func
+// ending it, which lets the next command run
+func (s *WalletSession) Begin() (end func()) {
+	s.mu.Lock()
+	s.queued++
+	s.mu.Unlock()
+	start := time.Now()
+	s.turn.Lock()
+	wait := time.Since(start)
+	s.mu.Lock()
+	s.waits++
+	s.totalWait += wait
+	if wait > s.maxWait {
+		s.maxWait = wait
+	}
+	s.mu.Unlock()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			s.queued--
+			s.mu.Unlock()
+			s.turn.Unlock()
+		})
+	}
+}
+
+// Unlock unlocks the wallet for a command with unlock. It is called even when
+// the wallet is unlocked for another command so the passphrase is checked.
+// Each successful Unlock must be matched by a Release
+func (s *WalletSession) Unlock(unlock func() error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	err := unlock()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.unlocked++
+	s.mu.Unlock()
+	return nil
+}
+
+// Release ends a command's need of the unlocked wallet. The last command
+// needing it locks the wallet with lock
+func (s *WalletSession) Release(lock func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.mu.Lock()
+	if s.unlocked == 0 {
+		s.mu.Unlock()
+		return
+	}
+	s.unlocked--
+	last := s.unlocked == 0
+	s.mu.Unlock()
+	if last {
+		lock()
+	}
+}
@@ -0,0 +1,395 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package utxo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/go-ini/ini"
+)
+
+// Signature hash modes supported in a coin definition
+const (
+	// SigHashModeAll is the original bitcoin SIGHASH_ALL signature hash
+	SigHashModeAll = "all"
+	// SigHashModeForkID is the BIP143 style SIGHASH_ALL|SIGHASH_FORKID
+	// signature hash used by bitcoin cash and its descendants. The signature
+	// commits to the amount of the output being spent
+	SigHashModeForkID = "forkid"
+)
+
+// sigHashForkID is the SIGHASH_FORKID flag
+const sigHashForkID txscript.SigHashType = 0x40
+
+// NetDef holds the network specific part of a coin definition
+type NetDef struct {
+	// Net is the network magic
+	Net uint32
+	// PubKeyHashAddrID is the P2PKH address version byte
+	PubKeyHashAddrID byte
+	// ScriptHashAddrID is the P2SH address version byte
+	ScriptHashAddrID byte
+	// PrivateKeyID is the WIF version byte
+	PrivateKeyID byte
+	// Bech32HRP is the human readable part of segwit addresses. Empty if the
+	// coin has no segwit
+	Bech32HRP string
+	// RPCPort is the default wallet node RPC port
+	RPCPort string
+	// Chain is the chain name the node reports in getblockchaininfo. It
+	// defaults to the bitcoin core name of the network (main, test..)
+	Chain string
+
+	params *chaincfg.Params
+}
+
+// CoinDef is a definition of a bitcoin derived coin read from a coin
+// definition file. It holds everything the generic UTXO driver needs to know
+// to swap the coin
+type CoinDef struct {
+	// Name is the full coin name, e.g. Vertcoin
+	Name string
+	// Symbol is the ticker symbol, e.g. VTC. It is also the key a coin
+	// definition is found by
+	Symbol string
+
+	// MainNet and TestNet network definitions
+	MainNet NetDef
+	TestNet NetDef
+	// RegTest and SigNet network definitions. Nil if the coin definition
+	// has no section for the network
+	RegTest *NetDef
+	SigNet  *NetDef
+
+	// TxVersion is the version of transactions built
+	TxVersion int32
+	// SigHashMode is one of the SigHashModeXXX constants
+	SigHashMode string
+	// ForkID is the fork id used with SigHashModeForkID
+	ForkID uint32
+
+	// MinRelayFee is the lowest fee/kB the node will relay, in atomic units.
+	// The fee used is never allowed to go lower than this
+	MinRelayFee btcutil.Amount
+	// FallbackFee is the fee/kB used when neither the wallet nor the node can
+	// supply a fee, in atomic units. Zero means use the relay fee
+	FallbackFee btcutil.Amount
+	// DustLimit is a fixed dust threshold in atomic units. Zero means use the
+	// bitcoin relay fee based dust rule
+	DustLimit btcutil.Amount
+
+	// EstimateSmartFee is true if the node supports estimatesmartfee
+	EstimateSmartFee bool
+	// EstimateFee is true if the node supports the older estimatefee
+	EstimateFee bool
+	// FundOptions is true if fundrawtransaction takes an options object
+	FundOptions bool
+	// AddressType is the address type parameter passed to getnewaddress and
+	// getrawchangeaddress. Empty if the node takes no address type
+	AddressType string
+}
+
+// LoadCoinDef reads a coin definition file
+func LoadCoinDef(path string) (*CoinDef, error) {
+	cfg, err := ini.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("coin definition %s: %v", path, err)
+	}
+
+	def := &CoinDef{}
+
+	// [DEFAULT]
+	root := cfg.Section("")
+	def.Name = root.Key("name").String()
+	def.Symbol = strings.ToUpper(root.Key("symbol").String())
+
+	// [mainnet] & [testnet]
+	def.MainNet, err = loadNetDef(cfg.Section("mainnet"))
+	if err != nil {
+		return nil, fmt.Errorf("coin definition %s: [mainnet] %v", path, err)
+	}
+	def.TestNet, err = loadNetDef(cfg.Section("testnet"))
+	if err != nil {
+		return nil, fmt.Errorf("coin definition %s: [testnet] %v", path, err)
+	}
+
+	// optional [regtest] & [signet]
+	def.RegTest, err = loadOptionalNetDef(cfg, "regtest")
+	if err != nil {
+		return nil, fmt.Errorf("coin definition %s: [regtest] %v", path, err)
+	}
+	def.SigNet, err = loadOptionalNetDef(cfg, "signet")
+	if err != nil {
+		return nil, fmt.Errorf("coin definition %s: [signet] %v", path, err)
+	}
+
+	// [tx]
+	txSection := cfg.Section("tx")
+	def.TxVersion = int32(txSection.Key("version").MustInt(1))
+	def.SigHashMode = strings.ToLower(txSection.Key("sighash").MustString(SigHashModeAll))
+	def.ForkID = uint32(txSection.Key("fork_id").MustUint(0))
+
+	// [policy]
+	policySection := cfg.Section("policy")
+	def.MinRelayFee, err = btcutil.NewAmount(policySection.Key("min_relay_fee").MustFloat64(0))
+	if err != nil {
+		return nil, fmt.Errorf("coin definition %s: min_relay_fee: %v", path, err)
+	}
+	def.FallbackFee, err = btcutil.NewAmount(policySection.Key("fallback_fee").MustFloat64(0))
+	if err != nil {
+		return nil, fmt.Errorf("coin definition %s: fallback_fee: %v", path, err)
+	}
+	def.DustLimit = btcutil.Amount(policySection.Key("dust_limit").MustInt64(0))
+
+	// [rpc]
+	rpcSection := cfg.Section("rpc")
+	def.EstimateSmartFee = rpcSection.Key("estimatesmartfee").MustBool(true)
+	def.EstimateFee = rpcSection.Key("estimatefee").MustBool(false)
+	def.FundOptions = rpcSection.Key("fundrawtransaction_options").MustBool(true)
+	def.AddressType = rpcSection.Key("address_type").String()
+
+	err = def.validate()
+	if err != nil {
+		return nil, fmt.Errorf("coin definition %s: %v", path, err)
+	}
+
+	for _, network := range def.Networks() {
+		n := def.netDef(network)
+		n.params, err = def.buildParams(n, network)
+		if err != nil {
+			return nil, fmt.Errorf("coin definition %s: [%s] %v", path, network, err)
+		}
+		if n.Chain == "" {
+			n.Chain = libs.CoreChainName(network)
+		}
+	}
+
+	return def, nil
+}
+
+// LoadCoinDefs reads all the coin definition (*.ini) files in a directory
+// and returns them keyed by symbol
+func LoadCoinDefs(dir string) (map[string]*CoinDef, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.ini"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		if _, err := os.Stat(dir); err != nil {
+			return nil, err
+		}
+	}
+	defs := make(map[string]*CoinDef)
+	for _, path := range paths {
+		def, err := LoadCoinDef(path)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := defs[def.Symbol]; ok {
+			return nil, fmt.Errorf("coin definition %s: duplicate symbol %s", path, def.Symbol)
+		}
+		defs[def.Symbol] = def
+	}
+	return defs, nil
+}
+
+// loadNetDef reads the network specific section of a coin definition
+func loadNetDef(section *ini.Section) (NetDef, error) {
+	var netDef NetDef
+	net, err := parseUint(section, "net", 32)
+	if err != nil {
+		return netDef, err
+	}
+	netDef.Net = uint32(net)
+	pkh, err := parseUint(section, "pubkey_hash_addr_id", 8)
+	if err != nil {
+		return netDef, err
+	}
+	netDef.PubKeyHashAddrID = byte(pkh)
+	sh, err := parseUint(section, "script_hash_addr_id", 8)
+	if err != nil {
+		return netDef, err
+	}
+	netDef.ScriptHashAddrID = byte(sh)
+	wif, err := parseUint(section, "private_key_id", 8)
+	if err != nil {
+		return netDef, err
+	}
+	netDef.PrivateKeyID = byte(wif)
+	netDef.Bech32HRP = section.Key("bech32_hrp").String()
+	netDef.RPCPort = section.Key("rpc_port").String()
+	if netDef.RPCPort == "" {
+		return netDef, errors.New("rpc_port is required")
+	}
+	netDef.Chain = section.Key("chain").String()
+	return netDef, nil
+}
+
+// loadOptionalNetDef reads the network specific section of a coin definition
+// for a network the coin need not define
+func loadOptionalNetDef(cfg *ini.File, name string) (*NetDef, error) {
+	section, err := cfg.GetSection(name)
+	if err != nil {
+		return nil, nil
+	}
+	netDef, err := loadNetDef(section)
+	if err != nil {
+		return nil, err
+	}
+	return &netDef, nil
+}
+
+// parseUint parses a required decimal or 0x prefixed hex key
+func parseUint(section *ini.Section, key string, bitSize int) (uint64, error) {
+	if !section.HasKey(key) {
+		return 0, fmt.Errorf("%s is required", key)
+	}
+	v, err := strconv.ParseUint(section.Key(key).String(), 0, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %v", key, err)
+	}
+	return v, nil
+}
+
+// validate checks a coin definition for missing or conflicting values
+func (def *CoinDef) validate() error {
+	if def.Name == "" {
+		return errors.New("name is required")
+	}
+	if def.Symbol == "" {
+		return errors.New("symbol is required")
+	}
+	switch def.SigHashMode {
+	case SigHashModeAll, SigHashModeForkID:
+	default:
+		return fmt.Errorf("unknown sighash mode %q", def.SigHashMode)
+	}
+	if def.TxVersion < 1 {
+		return fmt.Errorf("bad tx version %d", def.TxVersion)
+	}
+	nets := make(map[uint32]libs.Network)
+	for _, network := range def.Networks() {
+		n := def.netDef(network)
+		if other, ok := nets[n.Net]; ok {
+			return fmt.Errorf("%s and %s have the same network magic", other, network)
+		}
+		nets[n.Net] = network
+		if n.PubKeyHashAddrID == n.ScriptHashAddrID {
+			return fmt.Errorf("%s P2PKH and P2SH address version bytes are the same", network)
+		}
+	}
+	return nil
+}
+
+// buildParams makes btcsuite chain parameters for a network of the coin and
+// registers them so that addresses for the coin can be decoded
+func (def *CoinDef) buildParams(n *NetDef, network libs.Network) (*chaincfg.Params, error) {
+	var params chaincfg.Params
+	switch network {
+	case libs.Testnet, libs.Signet:
+		params = chaincfg.TestNet3Params
+	case libs.Regtest:
+		params = chaincfg.RegressionNetParams
+	default:
+		params = chaincfg.MainNetParams
+	}
+	params.Name = strings.ToLower(def.Symbol) + "-" + network.String()
+	params.Net = wire.BitcoinNet(n.Net)
+	params.DefaultPort = ""
+	params.DNSSeeds = nil
+	params.Checkpoints = nil
+	params.PubKeyHashAddrID = n.PubKeyHashAddrID
+	params.ScriptHashAddrID = n.ScriptHashAddrID
+	params.PrivateKeyID = n.PrivateKeyID
+	params.Bech32HRPSegwit = n.Bech32HRP
+
+	err := registerParams(&params)
+	if err != nil {
+		return nil, err
+	}
+	return &params, nil
+}
+
+// registerParams registers chain parameters so that bech32 addresses with the
+// segwit prefix of the params can be decoded. Base58 addresses are decoded
+// against the params passed, so a network magic already registered, by another
+// network or by loading the definition again, is only an error when the bech32
+// prefix is not registered with it
+func registerParams(params *chaincfg.Params) error {
+	err := chaincfg.Register(params)
+	if err != chaincfg.ErrDuplicateNet {
+		return err
+	}
+	if params.Bech32HRPSegwit == "" || chaincfg.IsBech32SegwitPrefix(params.Bech32HRPSegwit+"1") {
+		return nil
+	}
+	return fmt.Errorf("network magic %#x is already registered so bech32 prefix %q cannot be",
+		uint32(params.Net), params.Bech32HRPSegwit)
+}
+
+// Networks gets the networks the coin definition has
+func (def *CoinDef) Networks() []libs.Network {
+	networks := []libs.Network{libs.Mainnet, libs.Testnet}
+	if def.RegTest != nil {
+		networks = append(networks, libs.Regtest)
+	}
+	if def.SigNet != nil {
+		networks = append(networks, libs.Signet)
+	}
+	return networks
+}
+
+// checkNetwork checks the coin definition has the network
+func (def *CoinDef) checkNetwork(network libs.Network) error {
+	err := libs.CheckNetwork(network, def.Networks()...)
+	if err != nil {
+		return fmt.Errorf("%s: %v", def.Symbol, err)
+	}
+	return nil
+}
+
+// netDef gets the network definition. Nil if the coin definition does not
+// have the network
+func (def *CoinDef) netDef(network libs.Network) *NetDef {
+	switch network {
+	case libs.Mainnet:
+		return &def.MainNet
+	case libs.Testnet:
+		return &def.TestNet
+	case libs.Regtest:
+		return def.RegTest
+	case libs.Signet:
+		return def.SigNet
+	}
+	return nil
+}
+
+// chainParams gets all of the chain parameters for a network
+func (def *CoinDef) chainParams(network libs.Network) *chaincfg.Params {
+	return def.netDef(network).params
+}
+
+// walletPort gets the default wallet port
+func (def *CoinDef) walletPort(network libs.Network) string {
+	return def.netDef(network).RPCPort
+}
+
+// sigHashType gets the signature hash type to sign with
+func (def *CoinDef) sigHashType() txscript.SigHashType {
+	if def.SigHashMode == SigHashModeForkID {
+		return txscript.SigHashAll | sigHashForkID | txscript.SigHashType(def.ForkID<<8)
+	}
+	return txscript.SigHashAll
+}
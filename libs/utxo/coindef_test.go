@@ -0,0 +1,233 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package utxo
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// testCoinDef is a coin definition for the tests with a network magic and
+// bech32 prefixes no other network has
+const testCoinDef = `
+name = Testcoin
+symbol = tst
+
+[mainnet]
+net = 0x54535401
+pubkey_hash_addr_id = 66
+script_hash_addr_id = 0x1c
+private_key_id = 194
+bech32_hrp = tstc
+rpc_port = 7001
+
+[testnet]
+net = 0x54535402
+pubkey_hash_addr_id = 67
+script_hash_addr_id = 0x1d
+private_key_id = 195
+bech32_hrp = ttstc
+rpc_port = 17001
+
+[regtest]
+net = 0x54535403
+pubkey_hash_addr_id = 67
+script_hash_addr_id = 0x1d
+private_key_id = 195
+bech32_hrp = rtstc
+rpc_port = 18001
+chain = testcoin-regtest
+
+[tx]
+version = 2
+sighash = ForkID
+fork_id = 79
+
+[policy]
+min_relay_fee = 0.00001
+fallback_fee = 0.0002
+dust_limit = 546
+
+[rpc]
+estimatesmartfee = false
+estimatefee = true
+fundrawtransaction_options = false
+`
+
+// writeCoinDef writes a coin definition file to dir
+func writeCoinDef(t *testing.T, dir, name, def string) string {
+	path := filepath.Join(dir, name)
+	err := ioutil.WriteFile(path, []byte(def), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "coindef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoadCoinDef(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	path := writeCoinDef(t, dir, "tst.ini", testCoinDef)
+
+	def, err := LoadCoinDef(path)
+	if err != nil {
+		t.Fatalf("LoadCoinDef: %v", err)
+	}
+	if def.Name != "Testcoin" || def.Symbol != "TST" {
+		t.Errorf("name %q symbol %q", def.Name, def.Symbol)
+	}
+	if def.TxVersion != 2 || def.SigHashMode != SigHashModeForkID || def.ForkID != 79 {
+		t.Errorf("tx version %d sighash %q fork id %d", def.TxVersion, def.SigHashMode, def.ForkID)
+	}
+	if def.MinRelayFee != 1000 || def.FallbackFee != 20000 || def.DustLimit != 546 {
+		t.Errorf("min relay fee %d fallback fee %d dust limit %d", def.MinRelayFee, def.FallbackFee, def.DustLimit)
+	}
+	if def.EstimateSmartFee || !def.EstimateFee || def.FundOptions || def.AddressType != "" {
+		t.Errorf("rpc options %v %v %v %q", def.EstimateSmartFee, def.EstimateFee, def.FundOptions, def.AddressType)
+	}
+	if def.SigNet != nil {
+		t.Error("signet defined")
+	}
+	networks := def.Networks()
+	if len(networks) != 3 || networks[2] != libs.Regtest {
+		t.Errorf("networks %v", networks)
+	}
+	if def.walletPort(libs.Testnet) != "17001" {
+		t.Errorf("testnet port %s", def.walletPort(libs.Testnet))
+	}
+	if def.MainNet.Chain != "main" || def.RegTest.Chain != "testcoin-regtest" {
+		t.Errorf("chains %q %q", def.MainNet.Chain, def.RegTest.Chain)
+	}
+	if def.checkNetwork(libs.Signet) == nil {
+		t.Error("signet accepted")
+	}
+
+	params := def.chainParams(libs.Mainnet)
+	if params.Name != "tst-mainnet" || params.PubKeyHashAddrID != 66 || params.ScriptHashAddrID != 0x1c ||
+		params.PrivateKeyID != 194 {
+		t.Errorf("mainnet params %s %d %d %d", params.Name, params.PubKeyHashAddrID,
+			params.ScriptHashAddrID, params.PrivateKeyID)
+	}
+	// the params are registered so bech32 addresses of the coin decode
+	hash := make([]byte, 20)
+	witnessAddr, err := btcutil.NewAddressWitnessPubKeyHash(hash, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(witnessAddr.EncodeAddress(), "tstc1") {
+		t.Errorf("witness address %s", witnessAddr.EncodeAddress())
+	}
+	decoded, err := btcutil.DecodeAddress(witnessAddr.EncodeAddress(), params)
+	if err != nil {
+		t.Fatalf("DecodeAddress: %v", err)
+	}
+	if !decoded.IsForNet(params) {
+		t.Error("witness address is not for the network")
+	}
+
+	if def.sigHashType() != txscript.SigHashAll|sigHashForkID|79<<8 {
+		t.Errorf("sighash type %#x", def.sigHashType())
+	}
+
+	// loading the definition again registers the same params
+	_, err = LoadCoinDef(path)
+	if err != nil {
+		t.Fatalf("LoadCoinDef again: %v", err)
+	}
+}
+
+func TestLoadCoinDefs(t *testing.T) {
+	defs, err := LoadCoinDefs("coins")
+	if err != nil {
+		t.Fatalf("LoadCoinDefs: %v", err)
+	}
+	for _, symbol := range []string{"MONA", "VIA", "VTC"} {
+		def, ok := defs[symbol]
+		if !ok {
+			t.Errorf("no %s definition", symbol)
+			continue
+		}
+		if def.SigHashMode != SigHashModeAll {
+			t.Errorf("%s sighash %q", symbol, def.SigHashMode)
+		}
+	}
+	// the vtc mainnet magic is the bitcoin regtest magic
+	if defs["VTC"].chainParams(libs.Mainnet).Net != chaincfg.RegressionNetParams.Net {
+		t.Error("vtc mainnet magic")
+	}
+
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	writeCoinDef(t, dir, "a.ini", testCoinDef)
+	writeCoinDef(t, dir, "b.ini", testCoinDef)
+	_, err = LoadCoinDefs(dir)
+	if err == nil || !strings.Contains(err.Error(), "duplicate symbol TST") {
+		t.Errorf("duplicate symbol error %v", err)
+	}
+
+	_, err = LoadCoinDefs(filepath.Join(dir, "missing"))
+	if err == nil {
+		t.Error("missing directory loaded")
+	}
+}
+
+func TestLoadCoinDefErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		err  string
+	}{
+		{"no name", "name = Testcoin", "", "name is required"},
+		{"no symbol", "symbol = tst", "", "symbol is required"},
+		{"no net", "net = 0x54535401", "", "[mainnet] net is required"},
+		{"bad net", "net = 0x54535401", "net = 0x1545354010", "[mainnet] net:"},
+		{"bad address id", "pubkey_hash_addr_id = 66", "pubkey_hash_addr_id = 256", "pubkey_hash_addr_id:"},
+		{"no rpc port", "rpc_port = 17001", "", "[testnet] rpc_port is required"},
+		{"bad regtest", "private_key_id = 195\nbech32_hrp = rtstc", "bech32_hrp = rtstc", "[regtest] private_key_id is required"},
+		{"bad sighash", "sighash = ForkID", "sighash = anyonecanpay", `unknown sighash mode "anyonecanpay"`},
+		{"bad tx version", "version = 2", "version = 0", "bad tx version 0"},
+		{"same magic", "net = 0x54535402", "net = 0x54535401", "mainnet and testnet have the same network magic"},
+		{"same address ids", "script_hash_addr_id = 0x1c", "script_hash_addr_id = 66",
+			"mainnet P2PKH and P2SH address version bytes are the same"},
+		// the bitcoin mainnet magic is registered without the coin's prefix
+		{"registered magic", "net = 0x54535401\npubkey_hash_addr_id = 66\nscript_hash_addr_id = 0x1c\nprivate_key_id = 194\nbech32_hrp = tstc",
+			"net = 0xd9b4bef9\npubkey_hash_addr_id = 66\nscript_hash_addr_id = 0x1c\nprivate_key_id = 194\nbech32_hrp = btstc",
+			`bech32 prefix "btstc" cannot be`},
+	}
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	for _, test := range tests {
+		if !strings.Contains(testCoinDef, test.old) {
+			t.Fatalf("%s: %q not in the test definition", test.name, test.old)
+		}
+		def := strings.Replace(testCoinDef, test.old, test.new, 1)
+		path := writeCoinDef(t, dir, "tst.ini", def)
+		_, err := LoadCoinDef(path)
+		if err == nil {
+			t.Errorf("%s: loaded", test.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: error %q, want %q", test.name, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2017/2019 The Decred developers
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package utxo
+
+////////////////////////////////////////////////////////////////////////////
+// Public command interface for the generic UTXO coin atomic swap library //
+////////////////////////////////////////////////////////////////////////////
+
+import (
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// Every command takes the definition of the coin being swapped. Coin
+// definitions are read from coin definition files with LoadCoinDef; see the
+// coins directory for examples.
+
+const verify = true
+
+const secretSize = 32
+
+// PingRPC tests if wallet node RPC is available and reports the chain, version
+// and capabilities of the node
+func PingRPC(def *CoinDef, network libs.Network, rpcinfo libs.RPCInfo) (*libs.PingResult, error) {
+	err := def.checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return pingrpc(def, network, rpcinfo)
+}
+
+// GetNewAddress gets a new address from the controlled wallet
+func GetNewAddress(def *CoinDef, network libs.Network, rpcinfo libs.RPCInfo) (string, error) {
+	err := def.checkNetwork(network)
+	if err != nil {
+		return "", err
+	}
+	return newaddress(def, network, rpcinfo)
+}
+
+// Initiate command builds a P2SH contract and a transaction to fund it
+func Initiate(def *CoinDef, network libs.Network, rpcinfo libs.RPCInfo, params libs.InitiateParams) (*libs.InitiateResult, error) {
+	err := def.checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return initiate(def, network, rpcinfo, params)
+}
+
+// Participate command builds a P2SH contract and a transaction to fund it
+func Participate(def *CoinDef, network libs.Network, rpcinfo libs.RPCInfo, params libs.ParticipateParams) (*libs.ParticipateResult, error) {
+	err := def.checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return participate(def, network, rpcinfo, params)
+}
+
+// Redeem command builds a transaction to redeem a contract
+func Redeem(def *CoinDef, network libs.Network, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs.RedeemResult, error) {
+	err := def.checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return redeem(def, network, rpcinfo, params)
+}
+
+// Refund command builds a refund transaction for an unredeemed contract
+func Refund(def *CoinDef, network libs.Network, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs.RefundResult, error) {
+	err := def.checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return refund(def, network, rpcinfo, params)
+}
+
+// AuditContract command
+func AuditContract(def *CoinDef, network libs.Network, params libs.AuditParams) (*libs.AuditResult, error) {
+	err := def.checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return auditContract(def, network, params)
+}
+
+// Publish command broadcasts a raw hex transaction
+func Publish(def *CoinDef, network libs.Network, rpcinfo libs.RPCInfo, tx string) (string, error) {
+	err := def.checkNetwork(network)
+	if err != nil {
+		return "", err
+	}
+	txhash, err := publish(def, network, rpcinfo, tx)
+	if err != nil {
+		return "", err
+	}
+	return txhash, nil
+}
+
+// ExtractSecret returns a secret from the scriptSig of a transaction redeeming a contract
+func ExtractSecret(redemptionTx string, secretHash string) (string, error) {
+	return extractSecret(redemptionTx, secretHash)
+}
+
+// GetTx gets info on a broadcasted transaction
+func GetTx(def *CoinDef, network libs.Network, rpcinfo libs.RPCInfo, txid string) (*libs.GetTxResult, error) {
+	err := def.checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return getTx(def, network, rpcinfo, txid)
+}
+
+// WatchContract gets the state of a contract on the chain: the contract tx
+// confirmations and the spend, if any, with the secret of a redeem
+func WatchContract(def *CoinDef, network libs.Network, rpcinfo libs.RPCInfo, params libs.WatchParams) (*libs.WatchResult, error) {
+	err := def.checkNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	return watchContract(def, network, rpcinfo, params)
+}
+
+//...
@@ -0,0 +1,113 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package utxo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// The native P2WPKH example of BIP143. The fork id signature hash is the BIP143
+// signature hash so the example is a vector for it with SIGHASH_ALL
+const (
+	bip143Tx = "0100000002fff7f7881a8099afa6940d42d1e7f6362bec38171ea3edf433541db4e4ad969f" +
+		"0000000000eeffffffef51e1b804cc89d182d279655c3aa89e815b1b309fe287d9b2b55d57b9" +
+		"0ec68a0100000000ffffffff02202cb206000000001976a9148280b37df378db99f66f85c95a" +
+		"783a76ac7a6d5988ac9093510d000000001976a9143bde42dbee7e4dbe6a21b2d50ce2f0167f" +
+		"aa815988ac11000000"
+	bip143Input      = 1
+	bip143Amount     = 6e8
+	bip143ScriptCode = "76a9141d0f172a0ecb48aee1be1f2687d2963ae33f71a188ac"
+	bip143Key        = "619c335025c7f4012e556c2a58b2506e30b8511b53ade95ea316fd8c3286feb9"
+	bip143SigHash    = "c37af31116d1b27caf68aae9e3ac82f1477929014d5b917657d0eb49478cb670"
+	bip143Sig        = "304402203609e17b84f6a7d30c80bfa610b5b4542f32a8a0d5447a12fb1366d7f0" +
+		"1cc44a0220573a954c4518331561406f90300e8f3358f51928d43c212a8caed02de67eebee"
+)
+
+func bip143Vector(t *testing.T) (*wire.MsgTx, []byte, *btcec.PrivateKey) {
+	rawTx, _ := hex.DecodeString(bip143Tx)
+	tx := wire.NewMsgTx(wire.TxVersion)
+	err := tx.Deserialize(bytes.NewReader(rawTx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	scriptCode, _ := hex.DecodeString(bip143ScriptCode)
+	keyBytes, _ := hex.DecodeString(bip143Key)
+	key, _ := btcec.PrivKeyFromBytes(btcec.S256(), keyBytes)
+	return tx, scriptCode, key
+}
+
+func TestForkIDSigHash(t *testing.T) {
+	tx, scriptCode, key := bip143Vector(t)
+
+	hash, err := forkIDSigHash(tx, bip143Input, scriptCode, txscript.SigHashAll, bip143Amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(hash) != bip143SigHash {
+		t.Errorf("signature hash %x, want %s", hash, bip143SigHash)
+	}
+	sig, err := key.Sign(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(sig.Serialize()) != bip143Sig {
+		t.Errorf("signature %x, want %s", sig.Serialize(), bip143Sig)
+	}
+
+	// the amount is committed to
+	hash, err = forkIDSigHash(tx, bip143Input, scriptCode, txscript.SigHashAll, bip143Amount+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(hash) == bip143SigHash {
+		t.Error("signature hash does not commit to the amount")
+	}
+}
+
+func TestSignInput(t *testing.T) {
+	tx, scriptCode, key := bip143Vector(t)
+
+	tests := []struct {
+		name     string
+		def      CoinDef
+		hashType txscript.SigHashType
+	}{
+		{"bch", CoinDef{SigHashMode: SigHashModeForkID}, 0x41},
+		{"fork id 79", CoinDef{SigHashMode: SigHashModeForkID, ForkID: 79}, 0x4f41},
+		{"all", CoinDef{SigHashMode: SigHashModeAll}, txscript.SigHashAll},
+	}
+	for _, test := range tests {
+		sig, err := signInput(&test.def, tx, bip143Input, scriptCode, key, bip143Amount)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		// only the low byte of the hash type is appended
+		if sig[len(sig)-1] != byte(test.hashType) {
+			t.Errorf("%s: hash type byte %#x", test.name, sig[len(sig)-1])
+		}
+		var hash []byte
+		if test.def.SigHashMode == SigHashModeForkID {
+			hash, err = forkIDSigHash(tx, bip143Input, scriptCode, test.hashType, bip143Amount)
+		} else {
+			hash, err = txscript.CalcSignatureHash(scriptCode, test.hashType, tx, bip143Input)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature, err := btcec.ParseDERSignature(sig[:len(sig)-1], btcec.S256())
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !signature.Verify(hash, key.PubKey()) {
+			t.Errorf("%s: signature does not verify", test.name)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package libs
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Contract watching for the bitcoin-like coins. The coin packages decode the
+// contract and start the RPC client, WatchContract polls the node with the
+// bitcoin core JSON-RPC methods the coins share
+
+// watchLabel is the wallet label of imported contract scripts
+const watchLabel = "atomicswap"
+
+// WatchNode is the wallet node of a bitcoin-like coin. The RPC clients of the
+// coin packages are WatchNodes
+type WatchNode interface {
+	// RawRequest calls a JSON-RPC method with the params
+	RawRequest(method string, params []json.RawMessage) (json.RawMessage, error)
+}
+
+// WatchedContract is the coin specific part of a watched contract
+type WatchedContract struct {
+	PkScript   []byte // P2SH output script of the contract
+	SecretHash []byte
+	LockTime   int64
+	// ExtractSecret gets the secret from a transaction redeeming the contract
+	ExtractSecret func(redemptionTx string, secretHash string) (string, error)
+}
+
+// WatchContract gets the state of a contract on the chain. The contract
+// script is imported to the wallet watch-only so the wallet picks up the
+// transaction spending the contract whoever publishes it
+func WatchContract(node WatchNode, contract WatchedContract, params WatchParams) (*WatchResult, error) {
+	var info struct {
+		Blocks        int64  `json:"blocks"`
+		BestBlockhash string `json:"bestblockhash"`
+		MedianTime    int64  `json:"mediantime"`
+	}
+	err := watchRequest(node, "getblockchaininfo", &info)
+	if err != nil {
+		return nil, fmt.Errorf("getblockchaininfo: %v", err)
+	}
+	result := &WatchResult{
+		Blocks:         info.Blocks,
+		BestBlockhash:  info.BestBlockhash,
+		MedianTime:     info.MedianTime,
+		RefundLocktime: contract.LockTime,
+	}
+
+	if params.Import {
+		err = watchRequest(node, "importaddress", nil, params.Contract, watchLabel, params.Rescan, true)
+		if err != nil {
+			return nil, fmt.Errorf("importaddress: %v", err)
+		}
+	}
+
+	contractTx, err := getWatchedTx(node, params.ContractTxid)
+	if err != nil || contractTx.Confirmations < 0 {
+		// not in the wallet, mempool or chain (yet)
+		return result, nil
+	}
+	vout := -1
+	for _, out := range contractTx.Vout {
+		if out.ScriptPubKey.Hex == hex.EncodeToString(contract.PkScript) {
+			vout = int(out.N)
+			break
+		}
+	}
+	if vout == -1 {
+		return nil, errors.New("transaction does not contain the contract output")
+	}
+	result.Found = true
+	result.Confirmations = contractTx.Confirmations
+	result.Blockhash = contractTx.Blockhash
+
+	// gettxout is null for a spent output, the mempool included
+	var txOut *struct {
+		Confirmations int64 `json:"confirmations"`
+	}
+	err = watchRequest(node, "gettxout", &txOut, params.ContractTxid, vout, true)
+	if err != nil {
+		return nil, fmt.Errorf("gettxout: %v", err)
+	}
+	if txOut != nil {
+		return result, nil
+	}
+	result.Spent = true
+
+	spendTx, err := findSpend(node, params.ContractTxid, vout, params.SpendTxid)
+	if err != nil {
+		return nil, err
+	}
+	if spendTx == nil {
+		// spent by a transaction the wallet does not know
+		return result, nil
+	}
+	result.SpendTxid = spendTx.Txid
+	result.SpendTx = spendTx.Hex
+	result.SpendConfirmations = spendTx.Confirmations
+	secret, err := contract.ExtractSecret(spendTx.Hex, hex.EncodeToString(contract.SecretHash))
+	if err == nil {
+		result.Redeemed = true
+		result.Secret = secret
+	}
+	return result, nil
+}
+
+// watchedTx is a wallet, watch-only included, or node transaction
+type watchedTx struct {
+	Txid          string `json:"txid"`
+	Confirmations int64  `json:"confirmations"`
+	Blockhash     string `json:"blockhash"`
+	Hex           string `json:"hex"`
+	Vin           []struct {
+		Txid string `json:"txid"`
+		Vout int    `json:"vout"`
+	} `json:"vin"`
+	Vout []struct {
+		N            uint32 `json:"n"`
+		ScriptPubKey struct {
+			Hex string `json:"hex"`
+		} `json:"scriptPubKey"`
+	} `json:"vout"`
+}
+
+// getWatchedTx gets a transaction with the gettransaction JSON-RPC method,
+// decoded with decoderawtransaction, or if not in the wallet with the verbose
+// getrawtransaction method. The node needs a transaction index (-txindex) for
+// mined transactions that are not in the wallet
+func getWatchedTx(node WatchNode, txid string) (*watchedTx, error) {
+	var tx watchedTx
+	err := watchRequest(node, "gettransaction", &tx, txid, true)
+	if err != nil {
+		err = watchRequest(node, "getrawtransaction", &tx, txid, 1)
+		if err != nil {
+			return nil, err
+		}
+		return &tx, nil
+	}
+	var decoded watchedTx
+	err = watchRequest(node, "decoderawtransaction", &decoded, tx.Hex)
+	if err != nil {
+		return nil, fmt.Errorf("decoderawtransaction: %v", err)
+	}
+	tx.Vin = decoded.Vin
+	tx.Vout = decoded.Vout
+	return &tx, nil
+}
+
+// findSpend finds the wallet transaction spending the contract output. The
+// spend found by an earlier poll is checked first. It is nil if the wallet
+// does not have the spend
+func findSpend(node WatchNode, txid string, vout int, spendTxid string) (*watchedTx, error) {
+	spends := func(tx *watchedTx) bool {
+		for _, in := range tx.Vin {
+			if in.Txid == txid && in.Vout == vout {
+				return true
+			}
+		}
+		return false
+	}
+	if spendTxid != "" {
+		tx, err := getWatchedTx(node, spendTxid)
+		if err == nil && tx.Confirmations >= 0 && spends(tx) {
+			return tx, nil
+		}
+	}
+	var since struct {
+		Transactions []struct {
+			Txid     string `json:"txid"`
+			Category string `json:"category"`
+		} `json:"transactions"`
+	}
+	err := watchRequest(node, "listsinceblock", &since, "", 1, true)
+	if err != nil {
+		return nil, fmt.Errorf("listsinceblock: %v", err)
+	}
+	seen := map[string]bool{txid: true}
+	for _, t := range since.Transactions {
+		if t.Category != "send" || seen[t.Txid] {
+			continue
+		}
+		seen[t.Txid] = true
+		tx, err := getWatchedTx(node, t.Txid)
+		if err != nil {
+			return nil, err
+		}
+		if tx.Confirmations >= 0 && spends(tx) {
+			return tx, nil
+		}
+	}
+	return nil, nil
+}
+
+// watchRequest calls a JSON-RPC method with the args and unmarshals the
+// response into result, if not nil
+func watchRequest(node WatchNode, method string, result interface{}, args ...interface{}) error {
+	params := make([]json.RawMessage, len(args))
+	for i, arg := range args {
+		param, err := json.Marshal(arg)
+		if err != nil {
+			return err
+		}
+		params[i] = param
+	}
+	rawResp, err := node.RawRequest(method, params)
+	if err != nil {
+		return err
+	}
+	if result == nil || len(rawResp) == 0 {
+		return nil
+	}
+	return json.Unmarshal(rawResp, result)
+}
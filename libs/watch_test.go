@@ -0,0 +1,161 @@
+package libs
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// testNode answers JSON-RPC methods from a map of results. A method not in the
+// map fails
+type testNode struct {
+	results map[string]interface{}
+	calls   []string
+}
+
+func (n *testNode) RawRequest(method string, params []json.RawMessage) (json.RawMessage, error) {
+	n.calls = append(n.calls, method)
+	result, ok := n.results[method]
+	if !ok {
+		return nil, errors.New("no " + method)
+	}
+	return json.Marshal(result)
+}
+
+func (n *testNode) called(method string) bool {
+	for _, call := range n.calls {
+		if call == method {
+			return true
+		}
+	}
+	return false
+}
+
+func TestWatchContract(t *testing.T) {
+	pkScript := []byte{0xa9, 0x14, 0x01, 0x87}
+	contract := WatchedContract{
+		PkScript:   pkScript,
+		SecretHash: []byte{0xab},
+		LockTime:   1561000000,
+		ExtractSecret: func(tx string, secretHash string) (string, error) {
+			if tx != "redeem" || secretHash != "ab" {
+				return "", errors.New("not a redeem")
+			}
+			return "secret", nil
+		},
+	}
+	params := WatchParams{Contract: "a91401", ContractTxid: "contract", Import: true}
+	chainInfo := map[string]interface{}{"blocks": 100, "bestblockhash": "best", "mediantime": 1560000000}
+	contractTx := map[string]interface{}{
+		"txid": "contract", "confirmations": 2, "blockhash": "block", "hex": "00",
+		"vout": []interface{}{
+			map[string]interface{}{"n": 0, "scriptPubKey": map[string]interface{}{"hex": "76"}},
+			map[string]interface{}{"n": 1, "scriptPubKey": map[string]interface{}{"hex": "a9140187"}},
+		},
+	}
+	spend := func(hex string) map[string]interface{} {
+		return map[string]interface{}{
+			"txid": "spend", "confirmations": 1, "hex": hex,
+			"vin": []interface{}{map[string]interface{}{"txid": "contract", "vout": 1}},
+		}
+	}
+
+	// not in the wallet or the chain
+	node := &testNode{results: map[string]interface{}{
+		"getblockchaininfo": chainInfo,
+		"importaddress":     nil,
+	}}
+	result, err := WatchContract(node, contract, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Found || result.Blocks != 100 || result.MedianTime != 1560000000 || result.RefundLocktime != 1561000000 {
+		t.Errorf("unpublished result %+v", result)
+	}
+	if !node.called("importaddress") {
+		t.Error("contract not imported")
+	}
+
+	// published and unspent
+	node = &testNode{results: map[string]interface{}{
+		"getblockchaininfo": chainInfo,
+		"getrawtransaction": contractTx,
+		"gettxout":          map[string]interface{}{"confirmations": 2},
+	}}
+	result, err = WatchContract(node, contract, WatchParams{Contract: "a91401", ContractTxid: "contract"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Found || result.Confirmations != 2 || result.Blockhash != "block" || result.Spent {
+		t.Errorf("unspent result %+v", result)
+	}
+	if node.called("importaddress") {
+		t.Error("contract imported")
+	}
+
+	// redeemed, the spend found in the wallet transactions
+	for _, test := range []struct {
+		hex      string
+		redeemed bool
+	}{
+		{"redeem", true},
+		{"refund", false},
+	} {
+		node = &testNode{results: map[string]interface{}{
+			"getblockchaininfo": chainInfo,
+			"importaddress":     nil,
+			"gettxout":          nil,
+			"listsinceblock": map[string]interface{}{"transactions": []interface{}{
+				map[string]interface{}{"txid": "other", "category": "receive"},
+				map[string]interface{}{"txid": "spend", "category": "send"},
+			}},
+		}}
+		// getrawtransaction gets the contract and the spend
+		txs := map[string]interface{}{"contract": contractTx, "spend": spend(test.hex)}
+		getTx := &txNode{testNode: node, txs: txs}
+		result, err = WatchContract(getTx, contract, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.Spent || result.SpendTxid != "spend" || result.SpendConfirmations != 1 ||
+			result.Redeemed != test.redeemed {
+			t.Errorf("%s result %+v", test.hex, result)
+		}
+		if test.redeemed && result.Secret != "secret" {
+			t.Errorf("secret %q", result.Secret)
+		}
+	}
+
+	// the contract transaction does not pay the contract
+	node = &testNode{results: map[string]interface{}{
+		"getblockchaininfo": chainInfo,
+		"getrawtransaction": spend("redeem"),
+	}}
+	_, err = WatchContract(node, contract, WatchParams{Contract: "a91401", ContractTxid: "contract"})
+	if err == nil {
+		t.Error("contract output found")
+	}
+}
+
+// txNode answers getrawtransaction with the transaction of the txid param
+type txNode struct {
+	*testNode
+	txs map[string]interface{}
+}
+
+func (n *txNode) RawRequest(method string, params []json.RawMessage) (json.RawMessage, error) {
+	if method != "getrawtransaction" {
+		return n.testNode.RawRequest(method, params)
+	}
+	n.calls = append(n.calls, method)
+	var txid string
+	err := json.Unmarshal(params[0], &txid)
+	if err != nil {
+		return nil, err
+	}
+	tx, ok := n.txs[txid]
+	if !ok {
+		return nil, errors.New("no transaction " + txid)
+	}
+	return json.Marshal(tx)
+}
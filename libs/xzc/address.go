@@ -9,8 +9,8 @@ import (
 )
 
 // newaddress gets a new wallet address from the controlled wallet
-func newaddress(testnet bool, rpcinfo libs.RPCInfo) (string, error) {
-	rpcclient, err := startRPC(testnet, rpcinfo)
+func newaddress(network libs.Network, rpcinfo libs.RPCInfo) (string, error) {
+	rpcclient, err := startRPC(network, rpcinfo)
 	if err != nil {
 		return "", err
 	}
@@ -18,8 +18,10 @@ func newaddress(testnet bool, rpcinfo libs.RPCInfo) (string, error) {
 		rpcclient.Shutdown()
 		rpcclient.WaitForShutdown()
 	}()
+	end := rpcclient.session.Begin()
+	defer end()
 
-	addr, err := getNewAddress(testnet, rpcclient)
+	addr, err := getNewAddress(network, rpcclient)
 	if err != nil {
 		return "", err
 	}
@@ -18,8 +18,8 @@ import (
 )
 
 // auditContract pulls out information from the counterparty's contract
-func auditContract(testnet bool, params libs.AuditParams) (*libs.AuditResult, error) {
-	chainParams := getChainParams(testnet)
+func auditContract(network libs.Network, params libs.AuditParams) (*libs.AuditResult, error) {
+	chainParams := getChainParams(network)
 
 	contract, err := hex.DecodeString(params.Contract)
 	if err != nil {
@@ -4,8 +4,8 @@ import (
 	"github.com/devwarrior777/atomicswap/libs"
 )
 
-func getTx(testnet bool, rpcinfo libs.RPCInfo, txid string) (*libs.GetTxResult, error) {
-	rpcclient, err := startRPC(testnet, rpcinfo)
+func getTx(network libs.Network, rpcinfo libs.RPCInfo, txid string) (*libs.GetTxResult, error) {
+	rpcclient, err := startRPC(network, rpcinfo)
 	if err != nil {
 		return nil, err
 	}
@@ -14,7 +14,7 @@ import (
 )
 
 // Publish (broadcast) transaction to the network.
-func publish(testnet bool, rpcinfo libs.RPCInfo, tx string) (string, error) {
+func publish(network libs.Network, rpcinfo libs.RPCInfo, tx string) (string, error) {
 	txBytes, err := hex.DecodeString(tx)
 	if err != nil {
 		return "", fmt.Errorf("failed to decode broadcast transaction bytes: %v", err)
@@ -26,7 +26,7 @@ func publish(testnet bool, rpcinfo libs.RPCInfo, tx string) (string, error) {
 		return "", fmt.Errorf("failed to decode broadcast transaction: %v", err)
 	}
 
-	rpcclient, err := startRPC(testnet, rpcinfo)
+	rpcclient, err := startRPC(network, rpcinfo)
 	if err != nil {
 		return "", err
 	}
@@ -21,8 +21,8 @@ import (
 
 // Build a transaction that can redeem the coins in the passed in contract using
 // the (shared) secret
-func redeem(testnet bool, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs.RedeemResult, error) {
-	chainParams := getChainParams(testnet)
+func redeem(network libs.Network, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs.RedeemResult, error) {
+	chainParams := getChainParams(network)
 
 	contract, err := hex.DecodeString(params.Contract)
 	if err != nil {
@@ -81,7 +81,7 @@ func redeem(testnet bool, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs
 		Index: uint32(contractOutIdx),
 	}
 
-	rpcclient, err := startRPC(testnet, rpcinfo)
+	rpcclient, err := startRPC(network, rpcinfo)
 	if err != nil {
 		return nil, err
 	}
@@ -90,11 +90,13 @@ func redeem(testnet bool, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs
 		rpcclient.WaitForShutdown()
 	}()
 
-	err = walletLock(rpcclient, rpcinfo.WalletPass, 1)
+	end := rpcclient.session.Begin()
+	defer end()
+	release, err := unlockWallet(rpcclient, rpcinfo.WalletPass)
 	if err != nil {
 		return nil, err
 	}
-	defer walletUnlock(rpcclient, rpcinfo.WalletPass)
+	defer release()
 
 	feePerKb, minFeePerKb, err := getFeePerKb(rpcclient)
 	if err != nil {
@@ -112,7 +114,7 @@ func redeem(testnet bool, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs
 		return nil, fmt.Errorf("redeem output value of %v is dust", xzcutil.Amount(redeemTx.TxOut[0].Value))
 	}
 
-	redeemSig, redeemPubKey, err := createSig(testnet, redeemTx, 0, contract, recipientAddr, rpcclient)
+	redeemSig, redeemPubKey, err := createSig(redeemTx, 0, contract, recipientAddr, rpcclient)
 	if err != nil {
 		return nil, err
 	}
@@ -12,7 +12,6 @@ import (
 
 	"github.com/devwarrior777/atomicswap/libs"
 	"github.com/zcoinofficial/xzcd/chaincfg/chainhash"
-	rpc "github.com/zcoinofficial/xzcd/rpcclient"
 	"github.com/zcoinofficial/xzcd/txscript"
 	"github.com/zcoinofficial/xzcd/wire"
 	"github.com/zcoinofficial/xzcutil"
@@ -20,8 +19,8 @@ import (
 )
 
 // Build a transaction that can refund the coins back to the contract creator
-func refund(testnet bool, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs.RefundResult, error) {
-	chainParams := getChainParams(testnet)
+func refund(network libs.Network, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs.RefundResult, error) {
+	chainParams := getChainParams(network)
 
 	contract, err := hex.DecodeString(params.Contract)
 	if err != nil {
@@ -46,7 +45,7 @@ func refund(testnet bool, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs
 		return nil, errors.New("contract is not an atomic swap script recognized by this tool")
 	}
 
-	rpcclient, err := startRPC(testnet, rpcinfo)
+	rpcclient, err := startRPC(network, rpcinfo)
 	if err != nil {
 		return nil, err
 	}
@@ -55,11 +54,13 @@ func refund(testnet bool, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs
 		rpcclient.WaitForShutdown()
 	}()
 
-	err = walletLock(rpcclient, rpcinfo.WalletPass, 1)
+	end := rpcclient.session.Begin()
+	defer end()
+	release, err := unlockWallet(rpcclient, rpcinfo.WalletPass)
 	if err != nil {
 		return nil, err
 	}
-	defer walletUnlock(rpcclient, rpcinfo.WalletPass)
+	defer release()
 
 	feePerKb, minFeePerKb, err := getFeePerKb(rpcclient)
 	if err != nil {
@@ -87,7 +88,7 @@ func refund(testnet bool, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs
 		return nil, errors.New("contract tx does not contain a P2SH contract payment")
 	}
 
-	refundAddress, err := getRawChangeAddress(testnet, rpcclient)
+	refundAddress, err := getRawChangeAddress(network, rpcclient)
 	if err != nil {
 		return nil, fmt.Errorf("getrawchangeaddress: %v", err)
 	}
@@ -115,7 +116,7 @@ func refund(testnet bool, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs
 	txIn.Sequence = 0
 	refundTx.AddTxIn(txIn)
 
-	refundSig, refundPubKey, err := createSig(testnet, refundTx, 0, contract, refundAddr, rpcclient)
+	refundSig, refundPubKey, err := createSig(refundTx, 0, contract, refundAddr, rpcclient)
 	if err != nil {
 		return nil, err
 	}
@@ -158,8 +159,8 @@ func refund(testnet bool, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs
 }
 
 // Build a transaction that can refund the coins back to the contract creator
-func buildContractRefund(testnet bool, rpcclient *rpc.Client, contract []byte, contractTx *wire.MsgTx, feePerKb, minFeePerKb xzcutil.Amount) (refundTx *wire.MsgTx, refundFee xzcutil.Amount, err error) {
-	chainParams := getChainParams(testnet)
+func buildContractRefund(network libs.Network, rpcclient *rpcClient, contract []byte, contractTx *wire.MsgTx, feePerKb, minFeePerKb xzcutil.Amount) (refundTx *wire.MsgTx, refundFee xzcutil.Amount, err error) {
+	chainParams := getChainParams(network)
 
 	contractP2SH, err := xzcutil.NewAddressScriptHash(contract, chainParams)
 	if err != nil {
@@ -182,7 +183,7 @@ func buildContractRefund(testnet bool, rpcclient *rpc.Client, contract []byte, c
 		return nil, 0, errors.New("contract tx does not contain a P2SH contract payment")
 	}
 
-	refundAddress, err := getRawChangeAddress(testnet, rpcclient)
+	refundAddress, err := getRawChangeAddress(network, rpcclient)
 	if err != nil {
 		return nil, 0, fmt.Errorf("getrawchangeaddress: %v", err)
 	}
@@ -212,7 +213,7 @@ func buildContractRefund(testnet bool, rpcclient *rpc.Client, contract []byte, c
 	txIn.Sequence = 0
 	refundTx.AddTxIn(txIn)
 
-	refundSig, refundPubKey, err := createSig(testnet, refundTx, 0, contract, refundAddr, rpcclient)
+	refundSig, refundPubKey, err := createSig(refundTx, 0, contract, refundAddr, rpcclient)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -8,8 +8,8 @@ import (
 	"bytes"
 	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/devwarrior777/atomicswap/libs"
@@ -22,8 +22,8 @@ import (
 
 // startRPC - starts a new RPC client for the network and address specified
 //            along with rpc user & rpc password, in RPCInfo
-func startRPC(testnet bool, rpcinfo libs.RPCInfo) (*rpc.Client, error) {
-	hostport, err := getNormalizedAddress(testnet, rpcinfo.HostPort)
+func startRPC(network libs.Network, rpcinfo libs.RPCInfo) (*rpcClient, error) {
+	hostport, err := getNormalizedAddress(network, rpcinfo.HostPort)
 	if err != nil {
 		return nil, fmt.Errorf("wallet server address: %v", err)
 	}
@@ -34,26 +34,60 @@ func startRPC(testnet bool, rpcinfo libs.RPCInfo) (*rpc.Client, error) {
 		DisableTLS:   true, // bitcoin-like coins abandoned SSL for RPC
 		HTTPPostMode: true,
 	}
-	client, err := rpc.New(connConfig, nil)
+	c, err := rpc.New(connConfig, nil)
 	if err != nil {
-		return client, fmt.Errorf("rpc connect: %v", err)
+		return nil, fmt.Errorf("rpc connect: %v", err)
 	}
-	return client, err
+	client := &rpcClient{
+		Client:   c,
+		observer: rpcinfo.Observer,
+		log:      rpcinfo.Log,
+		session:  libs.Session("xzc", network, hostport),
+	}
+	err = checkChain(network, client)
+	if err != nil {
+		stopRPC(client)
+		return nil, err
+	}
+	return client, nil
 }
 
 // stopRPC - Explicit stop when not using defer()
-func stopRPC(client *rpc.Client) {
+func stopRPC(client *rpcClient) {
 	client.Shutdown()
 	client.WaitForShutdown()
 }
 
+// rpcClient is a wallet RPC client that tells the RPCInfo observer of its calls
+// and logs to the RPCInfo log. Its session serializes the wallet-mutating
+// commands of the wallet
+type rpcClient struct {
+	*rpc.Client
+	observer libs.RPCObserver
+	log      *libs.Log
+	session  *libs.WalletSession
+}
+
+// RawRequest makes a JSON-RPC request
+func (c *rpcClient) RawRequest(method string, params []json.RawMessage) (json.RawMessage, error) {
+	done := c.observer.Observe(method)
+	result, err := c.Client.RawRequest(method, params)
+	done(err)
+	return result, err
+}
+
+// observe tells the observer of a call made with a typed client method
+func (c *rpcClient) observe(method string) func(err error) {
+	return c.observer.Observe(method)
+}
+
 ///////////////
 // RPC funcs //
 ///////////////
 
 // walletLock allows access to an encrypted wallet for 't' seconds
 // If 'p' == "" (empty string) we assume the wallet is not encrypted
-func walletLock(rpcclient *rpc.Client, p string, t int) error {
+func walletLock(rpcclient *rpcClient, p string, t int) error {
 	if len(p) == 0 {
 		return nil
 	}
@@ -75,16 +109,40 @@ func walletLock(rpcclient *rpc.Client, p string, t int) error {
 
 // Re-lock an unlocked (encrypted) wallet
 // If 'p' == "" (empty string) we assume the wallet is not encrypted
-func walletUnlock(rpcclient *rpc.Client, p string) {
+func walletUnlock(rpcclient *rpcClient, p string) {
 	if len(p) == 0 {
 		return
 	}
 	_, _ = rpcclient.RawRequest("walletlock", nil)
 }
 
+// unlockTimeout is the longest (seconds) an encrypted wallet is unlocked for
+// the commands of this process. The wallet is locked again as soon as the last
+// command needing it ends; the timeout only bounds a failed relock
+const unlockTimeout = 60
+
+// unlockWallet unlocks an encrypted wallet for a command and returns the func
+// ending the command's need of it. The wallet stays unlocked while any command
+// of the wallet session needs it and is locked when the last one ends
+// If 'p' == "" (empty string) we assume the wallet is not encrypted
+func unlockWallet(rpcclient *rpcClient, p string) (func(), error) {
+	if len(p) == 0 {
+		return func() {}, nil
+	}
+	err := rpcclient.session.Unlock(func() error {
+		return walletLock(rpcclient, p, unlockTimeout)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		rpcclient.session.Release(func() { walletUnlock(rpcclient, p) })
+	}, nil
+}
+
 // getBlockCount calls the getblockcount JSON-RPC method. It is
 // currently used as a simple 'ping' to discover if node RPC is available
-func getBlockCount(rpcclient *rpc.Client) (int, error) {
+func getBlockCount(rpcclient *rpcClient) (int, error) {
 	rawResp, err := rpcclient.RawRequest("getblockcount", nil)
 	if err != nil {
 		return -1, err
@@ -97,7 +155,93 @@ func getBlockCount(rpcclient *rpc.Client) (int, error) {
 	return blockCount, nil
 }
 
-func getTransaction(rpcclient *rpc.Client, txid string) (*libs.GetTxResult, error) {
+// checkChain checks the node is on the chain of the network with the
+// getblockchaininfo JSON-RPC method
+func checkChain(network libs.Network, rpcclient *rpcClient) error {
+	info, err := getBlockchainInfo(rpcclient)
+	if err != nil {
+		return fmt.Errorf("getblockchaininfo: %v", err)
+	}
+	return libs.CheckChain(network, info.Chain, libs.CoreChainName(network))
+}
+
+// blockchainInfo is the part of the getblockchaininfo result used
+type blockchainInfo struct {
+	Chain         string `json:"chain"`
+	Blocks        int64  `json:"blocks"`
+	BestBlockhash string `json:"bestblockhash"`
+	MedianTime    int64  `json:"mediantime"`
+}
+
+// getBlockchainInfo calls the getblockchaininfo JSON-RPC method
+func getBlockchainInfo(rpcclient *rpcClient) (*blockchainInfo, error) {
+	rawResp, err := rpcclient.RawRequest("getblockchaininfo", nil)
+	if err != nil {
+		return nil, err
+	}
+	var info blockchainInfo
+	err = json.Unmarshal(rawResp, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// getNodeInfo gets the chain, version and RPC capabilities of the node from
+// getblockchaininfo, getnetworkinfo and the help for the methods used
+func getNodeInfo(rpcclient *rpcClient) (*libs.PingResult, error) {
+	info, err := getBlockchainInfo(rpcclient)
+	if err != nil {
+		return nil, fmt.Errorf("getblockchaininfo: %v", err)
+	}
+	rawResp, err := rpcclient.RawRequest("getnetworkinfo", nil)
+	if err != nil {
+		return nil, fmt.Errorf("getnetworkinfo: %v", err)
+	}
+	var netInfo struct {
+		Version         int64  `json:"version"`
+		Subversion      string `json:"subversion"`
+		ProtocolVersion int64  `json:"protocolversion"`
+	}
+	err = json.Unmarshal(rawResp, &netInfo)
+	if err != nil {
+		return nil, fmt.Errorf("getnetworkinfo: %v", err)
+	}
+
+	result := &libs.PingResult{
+		Chain:           info.Chain,
+		Blocks:          info.Blocks,
+		Version:         strconv.FormatInt(netInfo.Version, 10),
+		UserAgent:       netInfo.Subversion,
+		ProtocolVersion: netInfo.ProtocolVersion,
+	}
+	fundHelp := getHelp(rpcclient, "fundrawtransaction")
+	result.EstimateSmartFee = getHelp(rpcclient, "estimatesmartfee") != ""
+	result.FundFeeRate = strings.Contains(fundHelp, "feeRate")
+	result.ChangeType = strings.Contains(fundHelp, "change_type")
+	return result, nil
+}
+
+// getHelp gets the help text of a JSON-RPC method. It is empty if the node
+// does not have the method
+func getHelp(rpcclient *rpcClient, method string) string {
+	param, err := json.Marshal(method)
+	if err != nil {
+		return ""
+	}
+	rawResp, err := rpcclient.RawRequest("help", []json.RawMessage{param})
+	if err != nil {
+		return ""
+	}
+	var help string
+	err = json.Unmarshal(rawResp, &help)
+	if err != nil || strings.HasPrefix(help, "help: unknown command") {
+		return ""
+	}
+	return help
+}
+
+func getTransaction(rpcclient *rpcClient, txid string) (*libs.GetTxResult, error) {
 	txidBytes, err := json.Marshal(txid)
 	if err != nil {
 		return nil, err
@@ -135,8 +279,8 @@ func getTransaction(rpcclient *rpc.Client, txid string) (*libs.GetTxResult, erro
 }
 
 // getNewAddress calls the getnewaddress JSON-RPC method.
-func getNewAddress(testnet bool, rpcclient *rpc.Client) (xzcutil.Address, error) {
-	chainParams := getChainParams(testnet)
+func getNewAddress(network libs.Network, rpcclient *rpcClient) (xzcutil.Address, error) {
+	chainParams := getChainParams(network)
 	rawResp, err := rpcclient.RawRequest("getnewaddress", nil)
 	if err != nil {
 		return nil, err
@@ -164,8 +308,8 @@ func getNewAddress(testnet bool, rpcclient *rpc.Client) (xzcutil.Address, error)
 // getRawChangeAddress calls the getrawchangeaddress JSON-RPC method.  It is
 // implemented manually as the rpcclient implementation always passes the
 // account parameter which was removed in Bitcoin Core 0.15.
-func getRawChangeAddress(testnet bool, rpcclient *rpc.Client) (xzcutil.Address, error) {
-	chainParams := getChainParams(testnet)
+func getRawChangeAddress(network libs.Network, rpcclient *rpcClient) (xzcutil.Address, error) {
+	chainParams := getChainParams(network)
 	rawResp, err := rpcclient.RawRequest("getrawchangeaddress", nil)
 	if err != nil {
 		return nil, err
@@ -195,27 +339,52 @@ func getRawChangeAddress(testnet bool, rpcclient *rpc.Client) (xzcutil.Address,
 // wallet.  If unset, it attempts to find an estimate using estimatesmartfee 6.
 // If both of these fail, it falls back to mempool relay fee policy.
 //
-// For Zcoin this will always fall back until there is a statistically significant
+// For Firo this will often fall back until there is a statistically significant
 // number of transactions per block
-func getFeePerKb(rpcclient *rpc.Client) (useFee, relayFee xzcutil.Amount, err error) {
+func getFeePerKb(rpcclient *rpcClient) (useFee, relayFee xzcutil.Amount, err error) {
+	var netInfoResp struct {
+		RelayFee float64 `json:"relayfee"`
+	}
+	var walletInfoResp struct {
+		PayTxFee float64 `json:"paytxfee"`
+	}
 	var estimateResp struct {
 		FeeRate float64 `json:"feerate"`
 	}
-	info, err := rpcclient.GetInfo()
+
+	netInfoRawResp, err := rpcclient.RawRequest("getnetworkinfo", nil)
 	if err != nil {
-		return 0, 0, fmt.Errorf("getinfo: %v", err)
+		return 0, 0, fmt.Errorf("getnetworkinfo: %v", err)
 	}
-	relayFee, err = xzcutil.NewAmount(info.RelayFee)
+	err = json.Unmarshal(netInfoRawResp, &netInfoResp)
 	if err != nil {
 		return 0, 0, err
 	}
-	maxFee := info.PaytxFee
-	if info.PaytxFee != 0 {
-		if info.RelayFee > maxFee {
-			maxFee = info.RelayFee
+	walletInfoRawResp, err := rpcclient.RawRequest("getwalletinfo", nil)
+	if err == nil {
+		err = json.Unmarshal(walletInfoRawResp, &walletInfoResp)
+		if err != nil {
+			return 0, 0, err
 		}
-		useFee, err = xzcutil.NewAmount(maxFee)
-		return useFee, relayFee, err
+	}
+
+	relayFee, err = xzcutil.NewAmount(netInfoResp.RelayFee)
+	if err != nil {
+		return 0, 0, err
+	}
+	payTxFee, err := xzcutil.NewAmount(walletInfoResp.PayTxFee)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Use user-set wallet fee when set and not lower than the network relay
+	// fee.
+	if payTxFee != 0 {
+		maxFee := payTxFee
+		if relayFee > maxFee {
+			maxFee = relayFee
+		}
+		return maxFee, relayFee, nil
 	}
 
 	params := []json.RawMessage{[]byte("6")}
@@ -232,15 +401,14 @@ func getFeePerKb(rpcclient *rpc.Client) (useFee, relayFee xzcutil.Amount, err er
 		return useFee, relayFee, err
 	}
 
-	fmt.Println("warning: falling back to mempool relay fee policy")
-	useFee, err = xzcutil.NewAmount(info.RelayFee)
-	return useFee, relayFee, err
+	rpcclient.log.Warn("falling back to mempool relay fee policy")
+	return relayFee, relayFee, nil
 }
 
 // fundRawTransaction calls the fundrawtransaction JSON-RPC method.  It is
 // implemented manually as client support is currently missing from the
 // xzcd/rpcclient package.
-func fundRawTransaction(rpcclient *rpc.Client, tx *wire.MsgTx, feePerKb xzcutil.Amount) (fundedTx *wire.MsgTx, fee xzcutil.Amount, err error) {
+func fundRawTransaction(rpcclient *rpcClient, tx *wire.MsgTx, feePerKb xzcutil.Amount) (fundedTx *wire.MsgTx, fee xzcutil.Amount, err error) {
 	var buf bytes.Buffer
 	buf.Grow(tx.SerializeSize())
 	tx.Serialize(&buf)
@@ -286,112 +454,61 @@ func fundRawTransaction(rpcclient *rpc.Client, tx *wire.MsgTx, feePerKb xzcutil.
 	return fundedTx, feeAmount, nil
 }
 
-// createSig creates and returns the serialized raw signature and compressed
-// pubkey for a transaction input signature.  Due to limitations of the Zcoin
-// Core RPC API, this requires dumping a private key and signing in the client,
-// rather than letting the wallet sign.
-func createSig(testnet bool, tx *wire.MsgTx, idx int, pkScript []byte, addr xzcutil.Address,
-	rpcclient *rpc.Client) (sig, pubkey []byte, err error) {
-
-	wif, err := dpk(testnet, rpcclient, addr)
-	if err != nil {
-		return nil, nil, err
+// fundsError makes a libs.FundsError of an insufficient funds error from
+// fundrawtransaction, with the wallet balance as the available amount
+func fundsError(rpcclient *rpcClient, required xzcutil.Amount, err error) error {
+	if !libs.IsInsufficientFunds(err) {
+		return err
 	}
-	sig, err = txscript.RawTxInSignature(tx, idx, pkScript, txscript.SigHashAll, wif.PrivKey)
+	fundsErr := &libs.FundsError{Required: int64(required), Available: -1, Err: err}
+	rawResp, err := rpcclient.RawRequest("getbalance", nil)
 	if err != nil {
-		return nil, nil, err
-	}
-	return sig, wif.PrivKey.PubKey().SerializeCompressed(), nil
-}
-
-func dpk(testnet bool, rpcclient *rpc.Client, addr xzcutil.Address) (wif *xzcutil.WIF, err error) {
-	chainParams := getChainParams(testnet)
-	addrStr := addr.EncodeAddress()
-	if !addr.IsForNet(chainParams) {
-		return nil, fmt.Errorf("address %v is not intended for use on %v",
-			addrStr, chainParams.Name)
+		return fundsErr
 	}
-	param0, err := json.Marshal(addrStr)
+	var balance float64
+	err = json.Unmarshal(rawResp, &balance)
 	if err != nil {
-		return nil, err
+		return fundsErr
 	}
-	params := []json.RawMessage{param0}
-
-	// This should always fail the first time as Zcoin added a one-time authoriz-
-	// ation key returned in error string. Along with a warning. The idea is that
-	// inexperienced people are warned if scammers propose they use `dumpprivkey'
-	_, err = rpcclient.RawRequest("dumpprivkey", params)
+	available, err := xzcutil.NewAmount(balance)
 	if err == nil {
-		unexpected := errors.New("dpk: No authorization challenge")
-		return nil, unexpected
-	}
-
-	errStr := err.Error()
-	searchStr := "authorization code is: "
-	i0 := strings.Index(errStr, searchStr)
-	if i0 == -1 {
-		return nil, err
-	}
-	i := i0 + len(searchStr)
-	authStr := errStr[i : i+4]
-	//
-	param1, err := json.Marshal(authStr)
-	if err != nil {
-		return nil, err
-	}
-	params2 := []json.RawMessage{param0, param1}
-	rawResp2, err := rpcclient.RawRequest("dumpprivkey", params2)
-	if err != nil {
-		return nil, err
-	}
-	var sk string
-	err = json.Unmarshal(rawResp2, &sk)
-	if err != nil {
-		return nil, err
+		fundsErr.Available = int64(available)
 	}
-
-	w, err := xzcutil.DecodeWIF(sk)
-	if err != nil {
-		return nil, err
-	}
-
-	return w, nil
+	return fundsErr
 }
 
-// The unsafe version when Zcoin built without auth built into `getprivkey`
-func dpk2(testnet bool, rpcclient *rpc.Client, addr xzcutil.Address) (wif *xzcutil.WIF, err error) {
-	chainParams := getChainParams(testnet)
-	addrStr := addr.EncodeAddress()
-	if !addr.IsForNet(chainParams) {
-		return nil, fmt.Errorf("address %v is not intended for use on %v",
-			addrStr, chainParams.Name)
-	}
-	param0, err := json.Marshal(addrStr)
-	if err != nil {
-		return nil, err
-	}
-	params := []json.RawMessage{param0}
+// createSig creates and returns the serialized raw signature and compressed
+// pubkey for a transaction input signature.  The wallet cannot sign the
+// contract input: Firo's signrawtransaction signs only the standard script
+// templates, and given the contract as the redeem script it returns a
+// signature script holding just the redeem script push.  So this dumps a
+// private key and signs in the client, as for the other bitcoin-like coins.
+// A Firo node asking for a one-time authorization code on dumpprivkey gives
+// the code only in the text of its error; the error is returned as is
+func createSig(tx *wire.MsgTx, idx int, contract []byte, addr xzcutil.Address,
+	rpcclient *rpcClient) (sig, pubkey []byte, err error) {
 
-	rawResponse, err := rpcclient.RawRequest("dumpprivkey", params)
+	done := rpcclient.observe("dumpprivkey")
+	wif, err := rpcclient.DumpPrivKey(addr)
+	done(err)
 	if err != nil {
-		return nil, err
+		return nil, nil, fmt.Errorf("dumpprivkey: %v", err)
 	}
-	var sk string
-	err = json.Unmarshal(rawResponse, &sk)
-	if err != nil {
-		return nil, err
+	pubkey = wif.PrivKey.PubKey().SerializeCompressed()
+	if !bytes.Equal(xzcutil.Hash160(pubkey), addr.ScriptAddress()) {
+		return nil, nil, fmt.Errorf("dumpprivkey: key is not of %v", addr)
 	}
-
-	w, err := xzcutil.DecodeWIF(sk)
+	sig, err = txscript.RawTxInSignature(tx, idx, contract, txscript.SigHashAll, wif.PrivKey)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-
-	return w, nil
+	return sig, pubkey, nil
 }
 
-func sendRawTransaction(rpcclient *rpc.Client, tx *wire.MsgTx) (*chainhash.Hash, error) {
+func sendRawTransaction(rpcclient *rpcClient, tx *wire.MsgTx) (*chainhash.Hash, error) {
+	done := rpcclient.observe("sendrawtransaction")
 	txHash, err := rpcclient.SendRawTransaction(tx, false)
+	done(err)
 	if err != nil {
 		return nil, fmt.Errorf("sendrawtransaction: %v", err)
 	}
@@ -0,0 +1,167 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package xzc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/devwarrior777/atomicswap/libs"
+	"github.com/zcoinofficial/xzcd/btcec"
+	rpc "github.com/zcoinofficial/xzcd/rpcclient"
+	"github.com/zcoinofficial/xzcd/txscript"
+	"github.com/zcoinofficial/xzcd/wire"
+	"github.com/zcoinofficial/xzcutil"
+)
+
+// testNode is a Firo node answering JSON-RPC methods from a map of results
+type testNode struct {
+	results map[string]interface{}
+	calls   []string
+}
+
+func (n *testNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Method string          `json:"method"`
+		ID     json.RawMessage `json:"id"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+	n.calls = append(n.calls, req.Method)
+	resp := map[string]interface{}{"id": req.ID, "result": nil, "error": nil}
+	result, ok := n.results[req.Method]
+	if ok {
+		resp["result"] = result
+	} else {
+		resp["error"] = map[string]interface{}{"code": -4, "message": "no " + req.Method}
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// testClient is an RPC client of the node
+func testClient(t *testing.T, node *testNode) (*rpcClient, func()) {
+	server := httptest.NewServer(node)
+	c, err := rpc.New(&rpc.ConnConfig{
+		Host:         strings.TrimPrefix(server.URL, "http://"),
+		DisableTLS:   true,
+		HTTPPostMode: true,
+	}, nil)
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	client := &rpcClient{Client: c, session: libs.Session("xzc", libs.Regtest, server.URL)}
+	return client, func() {
+		stopRPC(client)
+		server.Close()
+	}
+}
+
+func TestCreateSig(t *testing.T) {
+	chainParams := getChainParams(libs.Regtest)
+	key, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	wif, err := xzcutil.NewWIF(key, chainParams, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := xzcutil.NewAddressPubKeyHash(xzcutil.Hash160(wif.SerializePubKey()), chainParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret := bytes.Repeat([]byte{0x5a}, secretSize)
+	secretHash := sha256.Sum256(secret)
+	var pkhThem, pkhMe [20]byte
+	copy(pkhThem[:], addr.ScriptAddress())
+	contract, err := atomicSwapContract(&pkhMe, &pkhThem, 1561000000, secretHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	contractP2SH, err := xzcutil.NewAddressScriptHash(contract, chainParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contractPkScript, err := txscript.PayToAddrScript(contractP2SH)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contractTx := wire.NewMsgTx(txVersion)
+	contractTx.AddTxOut(wire.NewTxOut(1e8, contractPkScript))
+	contractTxHash := contractTx.TxHash()
+
+	redeemTx := wire.NewMsgTx(txVersion)
+	redeemTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&contractTxHash, 0), nil, nil))
+	redeemTx.AddTxOut(wire.NewTxOut(1e8-1e5, []byte{txscript.OP_TRUE}))
+
+	// what the wallet signs of the contract input: the redeem script push only
+	redeemScriptOnly, err := txscript.NewScriptBuilder().AddData(contract).Script()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedTx := redeemTx.Copy()
+	signedTx.TxIn[0].SignatureScript = redeemScriptOnly
+	var buf bytes.Buffer
+	signedTx.Serialize(&buf)
+
+	node := &testNode{results: map[string]interface{}{
+		"dumpprivkey": wif.String(),
+		"signrawtransaction": map[string]interface{}{
+			"hex":      hex.EncodeToString(buf.Bytes()),
+			"complete": false,
+			"errors":   []interface{}{map[string]interface{}{"error": "Operation not valid with the current stack size"}},
+		},
+	}}
+	client, stop := testClient(t, node)
+	defer stop()
+
+	sig, pubkey, err := createSig(redeemTx, 0, contract, addr, client)
+	if err != nil {
+		t.Fatalf("createSig: %v", err)
+	}
+	for _, call := range node.calls {
+		if call != "dumpprivkey" {
+			t.Errorf("called %s", call)
+		}
+	}
+	sigScript, err := redeemP2SHContract(contract, sig, pubkey, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	redeemTx.TxIn[0].SignatureScript = sigScript
+	e, err := txscript.NewEngine(contractPkScript, redeemTx, 0, txscript.StandardVerifyFlags,
+		txscript.NewSigCache(10), txscript.NewTxSigHashes(redeemTx), 1e8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = e.Execute()
+	if err != nil {
+		t.Errorf("redeem signature script: %v", err)
+	}
+
+	// the key of another address
+	other, err := xzcutil.NewAddressPubKeyHash(pkhMe[:], chainParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = createSig(redeemTx, 0, contract, other, client)
+	if err == nil {
+		t.Error("signed with the key of another address")
+	}
+
+	// a node refusing dumpprivkey, as one asking for an authorization code does
+	delete(node.results, "dumpprivkey")
+	_, _, err = createSig(redeemTx, 0, contract, addr, client)
+	if err == nil || !strings.Contains(err.Error(), "no dumpprivkey") {
+		t.Errorf("dumpprivkey error %v", err)
+	}
+}
@@ -8,17 +8,19 @@ import (
 	"crypto/sha256"
 	"net"
 
+	"github.com/devwarrior777/atomicswap/libs"
 	"github.com/zcoinofficial/xzcd/chaincfg"
+	"github.com/zcoinofficial/xzcd/wire"
 	"github.com/zcoinofficial/xzcutil"
 )
 
 // Get a normalized address from `addr' which can be of form Host[:Port]`
-func getNormalizedAddress(testnet bool, addr string) (hostport string, err error) {
+func getNormalizedAddress(network libs.Network, addr string) (hostport string, err error) {
 	host, port, origErr := net.SplitHostPort(addr)
 	if origErr == nil {
 		return net.JoinHostPort(host, port), nil
 	}
-	defaultPort := getWalletPort(testnet)
+	defaultPort := getWalletPort(network)
 	addr = net.JoinHostPort(addr, defaultPort)
 	_, _, err = net.SplitHostPort(addr)
 	if err != nil {
@@ -27,20 +29,57 @@ func getNormalizedAddress(testnet bool, addr string) (hostport string, err error
 	return addr, nil
 }
 
+// networks are the networks the library supports
+var networks = []libs.Network{libs.Mainnet, libs.Testnet, libs.Regtest}
+
+// checkNetwork checks the network is one the library supports
+func checkNetwork(network libs.Network) error {
+	return libs.CheckNetwork(network, networks...)
+}
+
 // Get the default wallet port
-func getWalletPort(testnet bool) string {
-	if testnet {
+func getWalletPort(network libs.Network) string {
+	switch network {
+	case libs.Testnet:
 		return "18888"
+	case libs.Regtest:
+		return "28888"
 	}
 	return "8888"
 }
 
+// Firo (formerly Zcoin) chain parameters. Only the fields used to encode and
+// decode addresses and keys differ from bitcoin. They are not registered as
+// addresses are decoded against the params passed
+var (
+	mainNetParams = newChainParams(&chaincfg.MainNetParams, "firo-mainnet", 0xf1fed9e3, 0x52, 0x07, 0xd2)
+	testNetParams = newChainParams(&chaincfg.TestNet3Params, "firo-testnet", 0xeabefccf, 0x41, 0xb2, 0xb9)
+	regTestParams = newChainParams(&chaincfg.RegressionNetParams, "firo-regtest", 0xdab5bffa, 0x41, 0xb2, 0xef)
+)
+
+func newChainParams(base *chaincfg.Params, name string, net uint32, pubKeyHashAddrID, scriptHashAddrID, privateKeyID byte) *chaincfg.Params {
+	params := *base
+	params.Name = name
+	params.Net = wire.BitcoinNet(net)
+	params.DefaultPort = ""
+	params.DNSSeeds = nil
+	params.Checkpoints = nil
+	params.PubKeyHashAddrID = pubKeyHashAddrID
+	params.ScriptHashAddrID = scriptHashAddrID
+	params.PrivateKeyID = privateKeyID
+	params.Bech32HRPSegwit = "" // no segwit
+	return &params
+}
+
 // Get all of the chain parameters for a network
-func getChainParams(testnet bool) *chaincfg.Params {
-	if testnet {
-		return &chaincfg.TestNet3Params
+func getChainParams(network libs.Network) *chaincfg.Params {
+	switch network {
+	case libs.Testnet:
+		return testNetParams
+	case libs.Regtest:
+		return regTestParams
 	}
-	return &chaincfg.MainNetParams
+	return mainNetParams
 }
 
 func sha256Hash(x []byte) []byte {
@@ -0,0 +1,40 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package zec
+
+import (
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcwallet/wallet/txrules"
+)
+
+// ZIP-317 proportional transfer fee mechanism. The fee depends on the number
+// of logical actions, not the size of the transaction
+const (
+	marginalFee  = btcutil.Amount(5000)
+	graceActions = 2
+)
+
+// minRelayFeePerKb is the zcashd default minimum relay fee of 100 zats/kB. It
+// is only used for the dust rule
+const minRelayFeePerKb = btcutil.Amount(100)
+
+// conventionalFee gets the ZIP-317 conventional fee for a transparent only
+// transaction
+func conventionalFee(numIn, numOut int) btcutil.Amount {
+	actions := numIn
+	if numOut > actions {
+		actions = numOut
+	}
+	if actions < graceActions {
+		actions = graceActions
+	}
+	return marginalFee * btcutil.Amount(actions)
+}
+
+// isDustOutput determines whether a transaction output is considered dust
+func isDustOutput(output *wire.TxOut) bool {
+	return txrules.IsDustOutput(output, minRelayFeePerKb)
+}
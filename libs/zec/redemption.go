@@ -0,0 +1,147 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package zec
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// Build a transaction that can redeem the coins in the passed in contract using
+// the (shared) secret
+func redeem(network libs.Network, rpcinfo libs.RPCInfo, params libs.RedeemParams) (*libs.RedeemResult, error) {
+	netParams := getNetParams(network)
+
+	contract, err := hex.DecodeString(params.Contract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract: %v", err)
+	}
+
+	contractTxBytes, err := hex.DecodeString(params.ContractTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract transaction: %v", err)
+	}
+	contractTx, err := decodeTx(contractTxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract transaction: %v", err)
+	}
+
+	secret, err := hex.DecodeString(params.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode secret: %v", err)
+	}
+
+	pushes, err := txscript.ExtractAtomicSwapDataPushes(0, contract)
+	if err != nil {
+		return nil, err
+	}
+	if pushes == nil {
+		return nil, errors.New("contract is not an atomic swap script recognized by this tool")
+	}
+	recipientAddr, err := newAddressPubKeyHash(pushes.RecipientHash160[:], netParams)
+	if err != nil {
+		return nil, err
+	}
+	outScript, err := recipientAddr.payToAddrScript()
+	if err != nil {
+		return nil, err
+	}
+
+	contractOutIdx, err := findContractOut(contractTx, contract, netParams)
+	if err != nil {
+		return nil, err
+	}
+	contractTxHash, err := contractTx.TxHash()
+	if err != nil {
+		return nil, err
+	}
+	contractOutPoint := wire.OutPoint{
+		Hash:  contractTxHash,
+		Index: uint32(contractOutIdx),
+	}
+	contractOut := contractTx.TxOut[contractOutIdx]
+
+	rpcclient, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		rpcclient.Shutdown()
+		rpcclient.WaitForShutdown()
+	}()
+
+	end := rpcclient.session.Begin()
+	defer end()
+	release, err := unlockWallet(rpcclient, rpcinfo.WalletPass)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	branchID, err := getConsensusBranchID(rpcclient)
+	if err != nil {
+		return nil, fmt.Errorf("getblockchaininfo: %v", err)
+	}
+
+	redeemTx := newTx(branchID)
+	redeemTx.LockTime = uint32(pushes.LockTime)
+	redeemTx.TxIn = append(redeemTx.TxIn, wire.NewTxIn(&contractOutPoint, nil, nil))
+	redeemTx.TxOut = append(redeemTx.TxOut, wire.NewTxOut(0, outScript)) // amount set below
+	redeemFee := conventionalFee(len(redeemTx.TxIn), len(redeemTx.TxOut))
+	redeemTx.TxOut[0].Value = contractOut.Value - int64(redeemFee)
+	if isDustOutput(redeemTx.TxOut[0]) {
+		return nil, fmt.Errorf("redeem output value of %v is dust", btcutil.Amount(redeemTx.TxOut[0].Value))
+	}
+
+	redeemSig, redeemPubKey, err := createSig(redeemTx, 0, contract, contractOut, recipientAddr, rpcclient)
+	if err != nil {
+		return nil, err
+	}
+	redeemSigScript, err := redeemP2SHContract(contract, redeemSig, redeemPubKey, secret)
+	if err != nil {
+		return nil, err
+	}
+	redeemTx.TxIn[0].SignatureScript = redeemSigScript
+
+	redeemTxBytes, err := redeemTx.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	strRedeemTx := hex.EncodeToString(redeemTxBytes)
+
+	redeemTxHash, err := redeemTx.TxHash()
+	if err != nil {
+		return nil, err
+	}
+	strRedeemTxHash := redeemTxHash.String()
+
+	var result = &libs.RedeemResult{}
+
+	result.RedeemTx = strRedeemTx
+	result.RedeemTxHash = strRedeemTxHash
+	result.RedeemFee = int64(redeemFee)
+	result.RedeemFeePerKb = calcFeePerKb(redeemFee, len(redeemTxBytes))
+
+	return result, nil
+}
+
+// redeemP2SHContract returns the signature script to redeem a contract output
+// using the redeemer's signature and the initiator's secret.  This function
+// assumes P2SH and appends the contract as the final data push.
+func redeemP2SHContract(contract, sig, pubkey, secret []byte) ([]byte, error) {
+	b := txscript.NewScriptBuilder()
+	b.AddData(sig)
+	b.AddData(pubkey)
+	b.AddData(secret)
+	b.AddInt64(1)
+	b.AddData(contract)
+	return b.Script()
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package zec
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// Build a transaction that can refund the coins back to the contract creator
+func refund(network libs.Network, rpcinfo libs.RPCInfo, params libs.RefundParams) (*libs.RefundResult, error) {
+	netParams := getNetParams(network)
+
+	contract, err := hex.DecodeString(params.Contract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract: %v", err)
+	}
+
+	contractTxBytes, err := hex.DecodeString(params.ContractTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract transaction: %v", err)
+	}
+	contractTx, err := decodeTx(contractTxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract transaction: %v", err)
+	}
+
+	pushes, err := txscript.ExtractAtomicSwapDataPushes(0, contract)
+	if err != nil {
+		return nil, err
+	}
+	if pushes == nil {
+		return nil, errors.New("contract is not an atomic swap script recognized by this tool")
+	}
+
+	contractOutIdx, err := findContractOut(contractTx, contract, netParams)
+	if err != nil {
+		return nil, err
+	}
+	contractTxHash, err := contractTx.TxHash()
+	if err != nil {
+		return nil, err
+	}
+	contractOutPoint := wire.OutPoint{
+		Hash:  contractTxHash,
+		Index: uint32(contractOutIdx),
+	}
+	contractOut := contractTx.TxOut[contractOutIdx]
+
+	rpcclient, err := startRPC(network, rpcinfo)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		rpcclient.Shutdown()
+		rpcclient.WaitForShutdown()
+	}()
+
+	end := rpcclient.session.Begin()
+	defer end()
+	release, err := unlockWallet(rpcclient, rpcinfo.WalletPass)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	branchID, err := getConsensusBranchID(rpcclient)
+	if err != nil {
+		return nil, fmt.Errorf("getblockchaininfo: %v", err)
+	}
+
+	refundAddress, err := getRawChangeAddress(network, rpcclient)
+	if err != nil {
+		return nil, fmt.Errorf("getrawchangeaddress: %v", err)
+	}
+	refundOutScript, err := refundAddress.payToAddrScript()
+	if err != nil {
+		return nil, err
+	}
+
+	refundAddr, err := newAddressPubKeyHash(pushes.RefundHash160[:], netParams)
+	if err != nil {
+		return nil, err
+	}
+
+	refundTx := newTx(branchID)
+	refundTx.LockTime = uint32(pushes.LockTime)
+	refundTx.TxOut = append(refundTx.TxOut, wire.NewTxOut(0, refundOutScript)) // amount set below
+	txIn := wire.NewTxIn(&contractOutPoint, nil, nil)
+	txIn.Sequence = 0
+	refundTx.TxIn = append(refundTx.TxIn, txIn)
+	refundFee := conventionalFee(len(refundTx.TxIn), len(refundTx.TxOut))
+	refundTx.TxOut[0].Value = contractOut.Value - int64(refundFee)
+	if isDustOutput(refundTx.TxOut[0]) {
+		return nil, fmt.Errorf("refund output value of %v is dust", btcutil.Amount(refundTx.TxOut[0].Value))
+	}
+
+	refundSig, refundPubKey, err := createSig(refundTx, 0, contract, contractOut, refundAddr, rpcclient)
+	if err != nil {
+		return nil, err
+	}
+	refundSigScript, err := refundP2SHContract(contract, refundSig, refundPubKey)
+	if err != nil {
+		return nil, err
+	}
+	refundTx.TxIn[0].SignatureScript = refundSigScript
+
+	refundTxBytes, err := refundTx.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	strRefundTx := hex.EncodeToString(refundTxBytes)
+
+	refundTxHash, err := refundTx.TxHash()
+	if err != nil {
+		return nil, err
+	}
+	strRefundTxHash := refundTxHash.String()
+
+	var result = &libs.RefundResult{}
+
+	result.RefundTx = strRefundTx
+	result.RefundTxHash = strRefundTxHash
+	result.RefundFee = int64(refundFee)
+	result.RefundFeePerKb = calcFeePerKb(refundFee, len(refundTxBytes))
+
+	return result, nil
+}
+
+// refundP2SHContract returns the signature script to refund a contract output
+// using the contract author's signature after the locktime has been reached.
+// This function assumes P2SH and appends the contract as the final data push.
+func refundP2SHContract(contract, sig, pubkey []byte) ([]byte, error) {
+	b := txscript.NewScriptBuilder()
+	b.AddData(sig)
+	b.AddData(pubkey)
+	b.AddInt64(0)
+	b.AddData(contract)
+	return b.Script()
+}
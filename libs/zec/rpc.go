@@ -0,0 +1,503 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package zec
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	rpc "github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/devwarrior777/atomicswap/libs"
+)
+
+// startRPC - starts a new RPC client for the network and address specified
+//            along with rpc user & rpc password, in RPCInfo
+func startRPC(network libs.Network, rpcinfo libs.RPCInfo) (*rpcClient, error) {
+	hostport, err := getNormalizedAddress(network, rpcinfo.HostPort)
+	if err != nil {
+		return nil, fmt.Errorf("wallet server address: %v", err)
+	}
+	connConfig := &rpc.ConnConfig{
+		Host:         hostport,
+		User:         rpcinfo.User,
+		Pass:         rpcinfo.Pass,
+		DisableTLS:   true, // bitcoin-like coins abandoned SSL for RPC
+		HTTPPostMode: true,
+	}
+	c, err := rpc.New(connConfig, nil)
+	if err != nil {
+		return nil, fmt.Errorf("rpc connect: %v", err)
+	}
+	client := &rpcClient{
+		Client:   c,
+		observer: rpcinfo.Observer,
+		log:      rpcinfo.Log,
+		session:  libs.Session("zec", network, hostport),
+	}
+	err = checkChain(network, client)
+	if err != nil {
+		stopRPC(client)
+		return nil, err
+	}
+	return client, nil
+}
+
+// stopRPC - Explicit stop when not using defer()
+func stopRPC(client *rpcClient) {
+	client.Shutdown()
+	client.WaitForShutdown()
+}
+
+// rpcClient is a wallet RPC client that tells the RPCInfo observer of its calls
+// and logs to the RPCInfo log. Its session serializes the wallet-mutating
+// commands of the wallet
+type rpcClient struct {
+	*rpc.Client
+	observer libs.RPCObserver
+	log      *libs.Log
+	session  *libs.WalletSession
+}
+
+// RawRequest makes a JSON-RPC request
+func (c *rpcClient) RawRequest(method string, params []json.RawMessage) (json.RawMessage, error) {
+	done := c.observer.Observe(method)
+	result, err := c.Client.RawRequest(method, params)
+	done(err)
+	return result, err
+}
+
+// observe tells the observer of a call made with a typed client method
+func (c *rpcClient) observe(method string) func(err error) {
+	return c.observer.Observe(method)
+}
+
+///////////////
+// RPC funcs //
+///////////////
+
+// walletLock allows access to an encrypted wallet for 't' seconds
+// If 'p' == "" (empty string) we assume the wallet is not encrypted
+func walletLock(rpcclient *rpcClient, p string, t int) error {
+	if len(p) == 0 {
+		return nil
+	}
+	pass, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	timeout, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	params := []json.RawMessage{pass, timeout}
+	_, err = rpcclient.RawRequest("walletpassphrase", params)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Re-lock an unlocked (encrypted) wallet
+// If 'p' == "" (empty string) we assume the wallet is not encrypted
+func walletUnlock(rpcclient *rpcClient, p string) {
+	if len(p) == 0 {
+		return
+	}
+	_, _ = rpcclient.RawRequest("walletlock", nil)
+}
+
+// unlockTimeout is the longest (seconds) an encrypted wallet is unlocked for
+// the commands of this process. The wallet is locked again as soon as the last
+// command needing it ends; the timeout only bounds a failed relock
+const unlockTimeout = 60
+
+// unlockWallet unlocks an encrypted wallet for a command and returns the func
+// ending the command's need of it. The wallet stays unlocked while any command
+// of the wallet session needs it and is locked when the last one ends
+// If 'p' == "" (empty string) we assume the wallet is not encrypted
+func unlockWallet(rpcclient *rpcClient, p string) (func(), error) {
+	if len(p) == 0 {
+		return func() {}, nil
+	}
+	err := rpcclient.session.Unlock(func() error {
+		return walletLock(rpcclient, p, unlockTimeout)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		rpcclient.session.Release(func() { walletUnlock(rpcclient, p) })
+	}, nil
+}
+
+// getBlockCount calls the getblockcount JSON-RPC method. It is
+// currently used as a simple 'ping' to discover if node RPC is available
+func getBlockCount(rpcclient *rpcClient) (int, error) {
+	rawResp, err := rpcclient.RawRequest("getblockcount", nil)
+	if err != nil {
+		return -1, err
+	}
+	var blockCount int
+	err = json.Unmarshal(rawResp, &blockCount)
+	if err != nil {
+		return -1, err
+	}
+	return blockCount, nil
+}
+
+// checkChain checks the node is on the chain of the network with the
+// getblockchaininfo JSON-RPC method
+func checkChain(network libs.Network, rpcclient *rpcClient) error {
+	info, err := getBlockchainInfo(rpcclient)
+	if err != nil {
+		return fmt.Errorf("getblockchaininfo: %v", err)
+	}
+	return libs.CheckChain(network, info.Chain, libs.CoreChainName(network))
+}
+
+// blockchainInfo is the part of the getblockchaininfo result used
+type blockchainInfo struct {
+	Chain         string `json:"chain"`
+	Blocks        int64  `json:"blocks"`
+	BestBlockhash string `json:"bestblockhash"`
+	MedianTime    int64  `json:"mediantime"`
+}
+
+// getBlockchainInfo calls the getblockchaininfo JSON-RPC method
+func getBlockchainInfo(rpcclient *rpcClient) (*blockchainInfo, error) {
+	rawResp, err := rpcclient.RawRequest("getblockchaininfo", nil)
+	if err != nil {
+		return nil, err
+	}
+	var info blockchainInfo
+	err = json.Unmarshal(rawResp, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// getNodeInfo gets the chain, version and RPC capabilities of the node from
+// getblockchaininfo, getnetworkinfo and the help for the methods used
+func getNodeInfo(rpcclient *rpcClient) (*libs.PingResult, error) {
+	info, err := getBlockchainInfo(rpcclient)
+	if err != nil {
+		return nil, fmt.Errorf("getblockchaininfo: %v", err)
+	}
+	rawResp, err := rpcclient.RawRequest("getnetworkinfo", nil)
+	if err != nil {
+		return nil, fmt.Errorf("getnetworkinfo: %v", err)
+	}
+	var netInfo struct {
+		Version         int64  `json:"version"`
+		Subversion      string `json:"subversion"`
+		ProtocolVersion int64  `json:"protocolversion"`
+	}
+	err = json.Unmarshal(rawResp, &netInfo)
+	if err != nil {
+		return nil, fmt.Errorf("getnetworkinfo: %v", err)
+	}
+
+	result := &libs.PingResult{
+		Chain:           info.Chain,
+		Blocks:          info.Blocks,
+		Version:         strconv.FormatInt(netInfo.Version, 10),
+		UserAgent:       netInfo.Subversion,
+		ProtocolVersion: netInfo.ProtocolVersion,
+	}
+	fundHelp := getHelp(rpcclient, "fundrawtransaction")
+	result.EstimateSmartFee = getHelp(rpcclient, "estimatesmartfee") != ""
+	result.FundFeeRate = strings.Contains(fundHelp, "feeRate")
+	result.ChangeType = strings.Contains(fundHelp, "change_type")
+	return result, nil
+}
+
+// getHelp gets the help text of a JSON-RPC method. It is empty if the node
+// does not have the method
+func getHelp(rpcclient *rpcClient, method string) string {
+	param, err := json.Marshal(method)
+	if err != nil {
+		return ""
+	}
+	rawResp, err := rpcclient.RawRequest("help", []json.RawMessage{param})
+	if err != nil {
+		return ""
+	}
+	var help string
+	err = json.Unmarshal(rawResp, &help)
+	if err != nil || strings.HasPrefix(help, "help: unknown command") {
+		return ""
+	}
+	return help
+}
+
+func getTransaction(rpcclient *rpcClient, txid string) (*libs.GetTxResult, error) {
+	txidBytes, err := json.Marshal(txid)
+	if err != nil {
+		return nil, err
+	}
+	param := []json.RawMessage{txidBytes}
+	rawResp, err := rpcclient.RawRequest("gettransaction", param)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Confirmations uint64 `json:"confirmations"`
+		Blockhash     string `json:"blockhash"`
+		Blockindex    int    `json:"blockindex"`
+		Blocktime     uint64 `json:"blocktime"`
+		Time          uint64 `json:"time"`
+		TimeReceived  uint64 `json:"timereceived"`
+		Hex           string `json:"hex"`
+	}
+
+	err = json.Unmarshal(rawResp, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var result libs.GetTxResult
+	result.Confirmations = resp.Confirmations
+	result.Blockhash = resp.Blockhash
+	result.Blockindex = resp.Blockindex
+	result.Blocktime = resp.Blocktime
+	result.Time = resp.Time
+	result.TimeReceived = resp.TimeReceived
+	result.Hex = resp.Hex
+	return &result, nil
+}
+
+// getNewAddress calls the getnewaddress JSON-RPC method.  zcashd returns a
+// transparent P2PKH address.  Newer zcashd releases need to be started with
+// -allowdeprecated=getnewaddress.
+func getNewAddress(network libs.Network, rpcclient *rpcClient) (*tAddress, error) {
+	return getAddress(network, rpcclient, "getnewaddress")
+}
+
+// getRawChangeAddress calls the getrawchangeaddress JSON-RPC method.  Newer
+// zcashd releases need to be started with -allowdeprecated=getrawchangeaddress.
+func getRawChangeAddress(network libs.Network, rpcclient *rpcClient) (*tAddress, error) {
+	return getAddress(network, rpcclient, "getrawchangeaddress")
+}
+
+func getAddress(network libs.Network, rpcclient *rpcClient, method string) (*tAddress, error) {
+	params := getNetParams(network)
+	rawResp, err := rpcclient.RawRequest(method, nil)
+	if err != nil {
+		return nil, err
+	}
+	var addrStr string
+	err = json.Unmarshal(rawResp, &addrStr)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := decodeAddress(addrStr, params)
+	if err != nil {
+		return nil, err
+	}
+	if addr.p2sh {
+		return nil, fmt.Errorf("%s: address %v is not P2PKH", method, addr)
+	}
+	return addr, nil
+}
+
+// getConsensusBranchID gets the consensus branch id of the next block from
+// getblockchaininfo.  Transactions are signed for this branch.
+func getConsensusBranchID(rpcclient *rpcClient) (uint32, error) {
+	rawResp, err := rpcclient.RawRequest("getblockchaininfo", nil)
+	if err != nil {
+		return 0, err
+	}
+	var resp struct {
+		Consensus struct {
+			NextBlock string `json:"nextblock"`
+		} `json:"consensus"`
+	}
+	err = json.Unmarshal(rawResp, &resp)
+	if err != nil {
+		return 0, err
+	}
+	branchID, err := strconv.ParseUint(resp.Consensus.NextBlock, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("bad consensus branch id %q: %v", resp.Consensus.NextBlock, err)
+	}
+	return uint32(branchID), nil
+}
+
+// createRawTransaction calls the createrawtransaction JSON-RPC method to make
+// an unfunded transaction paying amount to addr.  It is implemented manually
+// as the rpcclient implementation takes btcutil addresses.
+func createRawTransaction(rpcclient *rpcClient, addr *tAddress, amount btcutil.Amount) (string, error) {
+	param0 := []byte("[]")
+	param1, err := json.Marshal(map[string]float64{
+		addr.String(): amount.ToBTC(),
+	})
+	if err != nil {
+		return "", err
+	}
+	params := []json.RawMessage{param0, param1}
+	rawResp, err := rpcclient.RawRequest("createrawtransaction", params)
+	if err != nil {
+		return "", err
+	}
+	var txHex string
+	err = json.Unmarshal(rawResp, &txHex)
+	if err != nil {
+		return "", err
+	}
+	return txHex, nil
+}
+
+// fundRawTransaction calls the fundrawtransaction JSON-RPC method.  zcashd
+// takes no options and pays the ZIP-317 conventional fee.
+func fundRawTransaction(rpcclient *rpcClient, txHex string) (fundedTxHex string, fee btcutil.Amount, err error) {
+	param0, err := json.Marshal(txHex)
+	if err != nil {
+		return "", 0, err
+	}
+	params := []json.RawMessage{param0}
+	rawResp, err := rpcclient.RawRequest("fundrawtransaction", params)
+	if err != nil {
+		return "", 0, err
+	}
+	var resp struct {
+		Hex       string  `json:"hex"`
+		Fee       float64 `json:"fee"`
+		ChangePos float64 `json:"changepos"`
+	}
+	err = json.Unmarshal(rawResp, &resp)
+	if err != nil {
+		return "", 0, err
+	}
+	feeAmount, err := btcutil.NewAmount(resp.Fee)
+	if err != nil {
+		return "", 0, err
+	}
+	return resp.Hex, feeAmount, nil
+}
+
+// fundsError makes a libs.FundsError of an insufficient funds error from
+// fundrawtransaction, with the wallet balance as the available amount
+func fundsError(rpcclient *rpcClient, required btcutil.Amount, err error) error {
+	if !libs.IsInsufficientFunds(err) {
+		return err
+	}
+	fundsErr := &libs.FundsError{Required: int64(required), Available: -1, Err: err}
+	rawResp, err := rpcclient.RawRequest("getbalance", nil)
+	if err != nil {
+		return fundsErr
+	}
+	var balance float64
+	err = json.Unmarshal(rawResp, &balance)
+	if err != nil {
+		return fundsErr
+	}
+	available, err := btcutil.NewAmount(balance)
+	if err == nil {
+		fundsErr.Available = int64(available)
+	}
+	return fundsErr
+}
+
+// signRawTransaction calls the signrawtransaction JSON-RPC method to have the
+// wallet sign its own inputs.
+func signRawTransaction(rpcclient *rpcClient, txHex string) (signedTxHex string, complete bool, err error) {
+	param0, err := json.Marshal(txHex)
+	if err != nil {
+		return "", false, err
+	}
+	params := []json.RawMessage{param0}
+	rawResp, err := rpcclient.RawRequest("signrawtransaction", params)
+	if err != nil {
+		return "", false, err
+	}
+	var resp struct {
+		Hex      string `json:"hex"`
+		Complete bool   `json:"complete"`
+	}
+	err = json.Unmarshal(rawResp, &resp)
+	if err != nil {
+		return "", false, err
+	}
+	return resp.Hex, resp.Complete, nil
+}
+
+// dumpPrivKey calls the dumpprivkey JSON-RPC method.  zcash WIF uses the
+// bitcoin version bytes so btcutil can decode it.
+func dumpPrivKey(rpcclient *rpcClient, addr *tAddress) (*btcutil.WIF, error) {
+	param0, err := json.Marshal(addr.String())
+	if err != nil {
+		return nil, err
+	}
+	params := []json.RawMessage{param0}
+	rawResp, err := rpcclient.RawRequest("dumpprivkey", params)
+	if err != nil {
+		return nil, err
+	}
+	var wifStr string
+	err = json.Unmarshal(rawResp, &wifStr)
+	if err != nil {
+		return nil, err
+	}
+	return btcutil.DecodeWIF(wifStr)
+}
+
+// createSig creates and returns the serialized raw signature and compressed
+// pubkey for a transaction input signature.  The btcd txscript signature hash
+// does not know the zcash ZIP-243/ZIP-244 signature hashes so this dumps a
+// private key and signs in the client.  prevOut is the contract output spent
+// by the input.
+func createSig(t *tx, idx int, contract []byte, prevOut *wire.TxOut, addr *tAddress,
+	rpcclient *rpcClient) (sig, pubkey []byte, err error) {
+
+	wif, err := dumpPrivKey(rpcclient, addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	hash, err := t.sigHash(idx, contract, []*wire.TxOut{prevOut}, txscript.SigHashAll)
+	if err != nil {
+		return nil, nil, err
+	}
+	signature, err := wif.PrivKey.Sign(hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if verify && !signature.Verify(hash, wif.PrivKey.PubKey()) {
+		return nil, nil, errors.New("signature does not verify")
+	}
+	sig = append(signature.Serialize(), byte(txscript.SigHashAll))
+	return sig, wif.PrivKey.PubKey().SerializeCompressed(), nil
+}
+
+// sendRawTransaction calls the sendrawtransaction JSON-RPC method
+func sendRawTransaction(rpcclient *rpcClient, t *tx) (string, error) {
+	b, err := t.Bytes()
+	if err != nil {
+		return "", err
+	}
+	param0, err := json.Marshal(hex.EncodeToString(b))
+	if err != nil {
+		return "", err
+	}
+	params := []json.RawMessage{param0}
+	rawResp, err := rpcclient.RawRequest("sendrawtransaction", params)
+	if err != nil {
+		return "", fmt.Errorf("sendrawtransaction: %v", err)
+	}
+	var txHash string
+	err = json.Unmarshal(rawResp, &txHash)
+	if err != nil {
+		return "", err
+	}
+	return txHash, nil
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package zec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/dchest/blake2b"
+)
+
+// blake2b256 hashes data with BLAKE2b-256 and a 16 byte personalization
+func blake2b256(person []byte, data ...[]byte) []byte {
+	h, err := blake2b.New(&blake2b.Config{Size: 32, Person: person})
+	if err != nil {
+		// only happens with a bad config
+		panic(err)
+	}
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// branchPerson makes a personalization ending in the consensus branch id
+func branchPerson(prefix string, branchID uint32) []byte {
+	person := make([]byte, 16)
+	copy(person, prefix)
+	binary.LittleEndian.PutUint32(person[12:], branchID)
+	return person
+}
+
+func (t *tx) serializePrevouts() []byte {
+	var buf bytes.Buffer
+	for _, in := range t.TxIn {
+		writeOutPoint(&buf, &in.PreviousOutPoint)
+	}
+	return buf.Bytes()
+}
+
+func (t *tx) serializeSequences() []byte {
+	var buf bytes.Buffer
+	for _, in := range t.TxIn {
+		writeUint32(&buf, in.Sequence)
+	}
+	return buf.Bytes()
+}
+
+func (t *tx) serializeOutputs() []byte {
+	var buf bytes.Buffer
+	for _, out := range t.TxOut {
+		writeTxOut(&buf, out)
+	}
+	return buf.Bytes()
+}
+
+// sigHash gets the signature hash for transparent input idx.  prevOuts are the
+// outputs spent by every input of the transaction, in order.  scriptCode is
+// the script being satisfied, the contract for a P2SH contract spend.  Only
+// SIGHASH_ALL is supported.
+func (t *tx) sigHash(idx int, scriptCode []byte, prevOuts []*wire.TxOut, hashType txscript.SigHashType) ([]byte, error) {
+	if hashType != txscript.SigHashAll {
+		return nil, errors.New("only SIGHASH_ALL is supported")
+	}
+	if idx < 0 || idx >= len(t.TxIn) {
+		return nil, errors.New("input index out of range")
+	}
+	if len(prevOuts) != len(t.TxIn) {
+		return nil, errors.New("a previous output is needed for every input")
+	}
+	if t.Version == txVersionNU5 {
+		return t.sigHashV5(idx, scriptCode, prevOuts, hashType), nil
+	}
+	return t.sigHashV4(idx, scriptCode, prevOuts[idx].Value, hashType), nil
+}
+
+// sigHashV4 is the ZIP-243 signature hash
+func (t *tx) sigHashV4(idx int, scriptCode []byte, amount int64, hashType txscript.SigHashType) []byte {
+	var zero [32]byte
+	in := t.TxIn[idx]
+
+	var buf bytes.Buffer
+	writeUint32(&buf, t.Version|overwinteredFlag)
+	writeUint32(&buf, t.versionGroupID())
+	buf.Write(blake2b256([]byte("ZcashPrevoutHash"), t.serializePrevouts()))
+	buf.Write(blake2b256([]byte("ZcashSequencHash"), t.serializeSequences()))
+	buf.Write(blake2b256([]byte("ZcashOutputsHash"), t.serializeOutputs()))
+	buf.Write(zero[:]) // hashJoinSplits
+	buf.Write(zero[:]) // hashShieldedSpends
+	buf.Write(zero[:]) // hashShieldedOutputs
+	writeUint32(&buf, t.LockTime)
+	writeUint32(&buf, t.ExpiryHeight)
+	writeUint64(&buf, 0) // valueBalance
+	writeUint32(&buf, uint32(hashType))
+	writeOutPoint(&buf, &in.PreviousOutPoint)
+	wire.WriteVarBytes(&buf, 0, scriptCode)
+	writeUint64(&buf, uint64(amount))
+	writeUint32(&buf, in.Sequence)
+
+	return blake2b256(branchPerson("ZcashSigHash", t.ConsensusBranchID), buf.Bytes())
+}
+
+// headerDigest is the ZIP-244 header digest
+func (t *tx) headerDigest() []byte {
+	var buf bytes.Buffer
+	writeUint32(&buf, t.Version|overwinteredFlag)
+	writeUint32(&buf, t.versionGroupID())
+	writeUint32(&buf, t.ConsensusBranchID)
+	writeUint32(&buf, t.LockTime)
+	writeUint32(&buf, t.ExpiryHeight)
+	return blake2b256([]byte("ZTxIdHeadersHash"), buf.Bytes())
+}
+
+// emptyShieldedDigests are the ZIP-244 sapling and orchard digests of a
+// transaction with no shielded parts
+func emptyShieldedDigests() []byte {
+	sapling := blake2b256([]byte("ZTxIdSaplingHash"))
+	orchard := blake2b256([]byte("ZTxIdOrchardHash"))
+	return append(sapling, orchard...)
+}
+
+// txIDDigest is the ZIP-244 transaction id of a v5 transaction
+func (t *tx) txIDDigest() (chainhash.Hash, error) {
+	var transparent []byte
+	if len(t.TxIn) == 0 && len(t.TxOut) == 0 {
+		transparent = blake2b256([]byte("ZTxIdTranspaHash"))
+	} else {
+		transparent = blake2b256([]byte("ZTxIdTranspaHash"),
+			blake2b256([]byte("ZTxIdPrevoutHash"), t.serializePrevouts()),
+			blake2b256([]byte("ZTxIdSequencHash"), t.serializeSequences()),
+			blake2b256([]byte("ZTxIdOutputsHash"), t.serializeOutputs()))
+	}
+	digest := blake2b256(branchPerson("ZcashTxHash_", t.ConsensusBranchID),
+		t.headerDigest(), transparent, emptyShieldedDigests())
+	var hash chainhash.Hash
+	copy(hash[:], digest)
+	return hash, nil
+}
+
+// sigHashV5 is the ZIP-244 signature hash for a transparent input
+func (t *tx) sigHashV5(idx int, scriptCode []byte, prevOuts []*wire.TxOut, hashType txscript.SigHashType) []byte {
+	in := t.TxIn[idx]
+
+	var amounts, scriptPubKeys bytes.Buffer
+	for _, prevOut := range prevOuts {
+		writeUint64(&amounts, uint64(prevOut.Value))
+		wire.WriteVarBytes(&scriptPubKeys, 0, prevOut.PkScript)
+	}
+
+	var txIn bytes.Buffer
+	writeOutPoint(&txIn, &in.PreviousOutPoint)
+	writeUint64(&txIn, uint64(prevOuts[idx].Value))
+	wire.WriteVarBytes(&txIn, 0, scriptCode)
+	writeUint32(&txIn, in.Sequence)
+
+	transparent := blake2b256([]byte("ZTxIdTranspaHash"),
+		[]byte{byte(hashType)},
+		blake2b256([]byte("ZTxIdPrevoutHash"), t.serializePrevouts()),
+		blake2b256([]byte("ZTxTrAmountsHash"), amounts.Bytes()),
+		blake2b256([]byte("ZTxTrScriptsHash"), scriptPubKeys.Bytes()),
+		blake2b256([]byte("ZTxIdSequencHash"), t.serializeSequences()),
+		blake2b256([]byte("ZTxIdOutputsHash"), t.serializeOutputs()),
+		blake2b256([]byte("Zcash___TxInHash"), txIn.Bytes()))
+
+	return blake2b256(branchPerson("ZcashTxHash_", t.ConsensusBranchID),
+		t.headerDigest(), transparent, emptyShieldedDigests())
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package zec
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// zip243Vectors are the ZIP-243 test vectors with transparent parts only. The
+// other vectors have shielded parts, which tx does not support
+var zip243Vectors = []struct {
+	tx         string
+	scriptCode string
+	input      int
+	amount     int64
+	hashType   txscript.SigHashType
+	branchID   uint32
+	sigHash    string
+}{
+	// test vector 3
+	{
+		tx: "0400008085202f8901a8c685478265f4c14dada651969c45a65e1aeb8cd6791f2f5bb6a1d9952104d9" +
+			"010000006b483045022100a61e5d557568c2ddc1d9b03a7173c6ce7c996c4daecab007ac8f34bee01e6b97" +
+			"02204d38fdc0bcf2728a69fde78462a10fb45a9baa27873e6a5fc45fb5c76764202a01210365ffea3efa39" +
+			"08918a8b8627724af852fc9b86d7375b103ab0543cf418bcaa7ffeffffff02005a6202000000001976a914" +
+			"8132712c3ff19f3a151234616777420a6d7ef22688ac8b959800000000001976a9145453e4698f02a38abd" +
+			"aa521cd1ff2dee6fac187188ac29b0040048b004000000000000000000000000",
+		scriptCode: "76a914507173527b4c3318a2aecd793bf1cfed705950cf88ac",
+		input:      0,
+		amount:     50000000,
+		hashType:   txscript.SigHashAll,
+		branchID:   branchIDSapling,
+		sigHash:    "f3148f80dfab5e573d5edfe7a850f5fd39234f80b5429d3a57edcc11e34c585b",
+	},
+}
+
+func TestSigHashZIP243(t *testing.T) {
+	for i, v := range zip243Vectors {
+		raw, _ := hex.DecodeString(v.tx)
+		tx, err := decodeTx(raw)
+		if err != nil {
+			t.Fatalf("vector %d: %v", i, err)
+		}
+		// a v4 transaction does not serialize its branch id
+		tx.ConsensusBranchID = v.branchID
+
+		b, err := tx.Bytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, raw) {
+			t.Errorf("vector %d: serialized %x", i, b)
+		}
+
+		scriptCode, _ := hex.DecodeString(v.scriptCode)
+		prevOuts := make([]*wire.TxOut, len(tx.TxIn))
+		for j := range prevOuts {
+			prevOuts[j] = &wire.TxOut{}
+		}
+		prevOuts[v.input].Value = v.amount
+		hash, err := tx.sigHash(v.input, scriptCode, prevOuts, v.hashType)
+		if err != nil {
+			t.Fatalf("vector %d: %v", i, err)
+		}
+		if hex.EncodeToString(hash) != v.sigHash {
+			t.Errorf("vector %d: signature hash %x, want %s", i, hash, v.sigHash)
+		}
+	}
+}
+
+// The ZIP-244 signature hash commits to the amounts and scripts spent by every
+// input and to the consensus branch id, which the ZIP-243 hash does not
+func TestSigHashZIP244Commitments(t *testing.T) {
+	scriptCode, _ := hex.DecodeString("76a914507173527b4c3318a2aecd793bf1cfed705950cf88ac")
+	v5 := newTx(0xc2d6d0b4)
+	v5.TxIn = []*wire.TxIn{
+		wire.NewTxIn(&wire.OutPoint{Index: 0}, nil, nil),
+		wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil),
+	}
+	v5.TxOut = []*wire.TxOut{wire.NewTxOut(1e8, scriptCode)}
+	if v5.Version != txVersionNU5 {
+		t.Fatalf("version %d", v5.Version)
+	}
+	prevOuts := func(amount int64, script []byte) []*wire.TxOut {
+		return []*wire.TxOut{wire.NewTxOut(5e7, scriptCode), wire.NewTxOut(amount, script)}
+	}
+	sigHash := func(t5 *tx, prevOuts []*wire.TxOut) string {
+		hash, err := t5.sigHash(0, scriptCode, prevOuts, txscript.SigHashAll)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hex.EncodeToString(hash)
+	}
+
+	hash := sigHash(v5, prevOuts(6e7, scriptCode))
+	if sigHash(v5, prevOuts(6e7+1, scriptCode)) == hash {
+		t.Error("other input amount not committed to")
+	}
+	if sigHash(v5, prevOuts(6e7, []byte{txscript.OP_TRUE})) == hash {
+		t.Error("other input script not committed to")
+	}
+	other := *v5
+	other.ConsensusBranchID++
+	if sigHash(&other, prevOuts(6e7, scriptCode)) == hash {
+		t.Error("branch id not committed to")
+	}
+}
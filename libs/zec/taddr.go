@@ -0,0 +1,119 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package zec
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/base58"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// netParams are the zcash network parameters used by this library.  Zcash
+// transparent addresses have two byte version prefixes so the btcd chaincfg
+// and btcutil address types cannot be used.
+type netParams struct {
+	Name             string
+	PubKeyHashAddrID [2]byte
+	ScriptHashAddrID [2]byte
+}
+
+var (
+	mainNetParams = &netParams{
+		Name:             "mainnet",
+		PubKeyHashAddrID: [2]byte{0x1c, 0xb8}, // t1
+		ScriptHashAddrID: [2]byte{0x1c, 0xbd}, // t3
+	}
+	testNetParams = &netParams{
+		Name:             "testnet",
+		PubKeyHashAddrID: [2]byte{0x1d, 0x25}, // tm
+		ScriptHashAddrID: [2]byte{0x1c, 0xba}, // t2
+	}
+	regTestParams = &netParams{
+		Name:             "regtest",
+		PubKeyHashAddrID: [2]byte{0x1d, 0x25}, // tm
+		ScriptHashAddrID: [2]byte{0x1c, 0xba}, // t2
+	}
+)
+
+// tAddress is a P2PKH or P2SH transparent address
+type tAddress struct {
+	hash160 [ripemd160.Size]byte
+	p2sh    bool
+	params  *netParams
+}
+
+// newAddressPubKeyHash makes a P2PKH address from a pubkey hash
+func newAddressPubKeyHash(pkHash []byte, params *netParams) (*tAddress, error) {
+	if len(pkHash) != ripemd160.Size {
+		return nil, errors.New("pkHash must be 20 bytes")
+	}
+	a := &tAddress{params: params}
+	copy(a.hash160[:], pkHash)
+	return a, nil
+}
+
+// newAddressScriptHash makes a P2SH address for a script
+func newAddressScriptHash(script []byte, params *netParams) *tAddress {
+	a := &tAddress{p2sh: true, params: params}
+	copy(a.hash160[:], btcutil.Hash160(script))
+	return a
+}
+
+// decodeAddress decodes a transparent address for the network
+func decodeAddress(addr string, params *netParams) (*tAddress, error) {
+	decoded, version, err := base58.CheckDecode(addr)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) != 1+ripemd160.Size {
+		return nil, errors.New("not a transparent address")
+	}
+	prefix := [2]byte{version, decoded[0]}
+	a := &tAddress{params: params}
+	switch prefix {
+	case params.PubKeyHashAddrID:
+	case params.ScriptHashAddrID:
+		a.p2sh = true
+	default:
+		return nil, fmt.Errorf("address %v is not intended for use on %v", addr, params.Name)
+	}
+	copy(a.hash160[:], decoded[1:])
+	return a, nil
+}
+
+// String encodes the address
+func (a *tAddress) String() string {
+	prefix := a.params.PubKeyHashAddrID
+	if a.p2sh {
+		prefix = a.params.ScriptHashAddrID
+	}
+	return base58.CheckEncode(append([]byte{prefix[1]}, a.hash160[:]...), prefix[0])
+}
+
+// Hash160 gets the pubkey or script hash
+func (a *tAddress) Hash160() *[ripemd160.Size]byte {
+	return &a.hash160
+}
+
+// payToAddrScript makes the output script paying to the address
+func (a *tAddress) payToAddrScript() ([]byte, error) {
+	b := txscript.NewScriptBuilder()
+	if a.p2sh {
+		b.AddOp(txscript.OP_HASH160)
+		b.AddData(a.hash160[:])
+		b.AddOp(txscript.OP_EQUAL)
+		return b.Script()
+	}
+	b.AddOp(txscript.OP_DUP)
+	b.AddOp(txscript.OP_HASH160)
+	b.AddData(a.hash160[:])
+	b.AddOp(txscript.OP_EQUALVERIFY)
+	b.AddOp(txscript.OP_CHECKSIG)
+	return b.Script()
+}
@@ -0,0 +1,379 @@
+// Copyright (c) 2018/2019 The DevCo developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package zec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// Transaction versions and version group ids. Only the overwintered formats
+// with a transparent part are supported
+const (
+	overwinteredFlag = 1 << 31
+
+	txVersionSapling = 4
+	txVersionNU5     = 5
+
+	versionGroupIDSapling = 0x892f2085
+	versionGroupIDNU5     = 0x26a7270a
+)
+
+// Consensus branch ids of the network upgrades that use v4 transactions. Any
+// other (later) branch id uses v5
+const (
+	branchIDSapling   = 0x76b809bb
+	branchIDBlossom   = 0x2bb40e60
+	branchIDHeartwood = 0xf5b9230b
+	branchIDCanopy    = 0xe9ff75a6
+)
+
+// maxScriptSize is the largest script read when deserializing
+const maxScriptSize = 10000
+
+// tx is a zcash v4 (Sapling) or v5 (NU5) transaction with transparent inputs
+// and outputs only.  The btcd wire.MsgTx cannot be used as the zcash format
+// adds a version group, an expiry height and the shielded parts.
+type tx struct {
+	// Version is 4 or 5, without the overwintered flag
+	Version uint32
+	// ConsensusBranchID is the branch the transaction is valid for. It is
+	// serialized for v5 only but both v4 and v5 signature hashes commit to it
+	ConsensusBranchID uint32
+	LockTime          uint32
+	ExpiryHeight      uint32
+	TxIn              []*wire.TxIn
+	TxOut             []*wire.TxOut
+}
+
+// newTx makes an empty transaction in the format used by a consensus branch
+func newTx(branchID uint32) *tx {
+	version := uint32(txVersionNU5)
+	switch branchID {
+	case branchIDSapling, branchIDBlossom, branchIDHeartwood, branchIDCanopy:
+		version = txVersionSapling
+	}
+	return &tx{
+		Version:           version,
+		ConsensusBranchID: branchID,
+	}
+}
+
+// versionGroupID gets the version group id for the transaction version
+func (t *tx) versionGroupID() uint32 {
+	if t.Version == txVersionNU5 {
+		return versionGroupIDNU5
+	}
+	return versionGroupIDSapling
+}
+
+// Serialize writes the transaction in zcash wire format
+func (t *tx) Serialize(w io.Writer) error {
+	if t.Version != txVersionSapling && t.Version != txVersionNU5 {
+		return fmt.Errorf("unsupported transaction version %d", t.Version)
+	}
+	err := writeUint32(w, t.Version|overwinteredFlag)
+	if err != nil {
+		return err
+	}
+	err = writeUint32(w, t.versionGroupID())
+	if err != nil {
+		return err
+	}
+
+	if t.Version == txVersionNU5 {
+		err = writeUint32(w, t.ConsensusBranchID)
+		if err != nil {
+			return err
+		}
+		err = writeUint32(w, t.LockTime)
+		if err != nil {
+			return err
+		}
+		err = writeUint32(w, t.ExpiryHeight)
+		if err != nil {
+			return err
+		}
+		err = t.writeTransparent(w)
+		if err != nil {
+			return err
+		}
+		// nSpendsSapling, nOutputsSapling, nActionsOrchard
+		_, err = w.Write([]byte{0, 0, 0})
+		return err
+	}
+
+	err = t.writeTransparent(w)
+	if err != nil {
+		return err
+	}
+	err = writeUint32(w, t.LockTime)
+	if err != nil {
+		return err
+	}
+	err = writeUint32(w, t.ExpiryHeight)
+	if err != nil {
+		return err
+	}
+	// valueBalanceSapling
+	err = writeUint64(w, 0)
+	if err != nil {
+		return err
+	}
+	// nSpendsSapling, nOutputsSapling, nJoinSplit
+	_, err = w.Write([]byte{0, 0, 0})
+	return err
+}
+
+func (t *tx) writeTransparent(w io.Writer) error {
+	err := wire.WriteVarInt(w, 0, uint64(len(t.TxIn)))
+	if err != nil {
+		return err
+	}
+	for _, in := range t.TxIn {
+		err = writeOutPoint(w, &in.PreviousOutPoint)
+		if err != nil {
+			return err
+		}
+		err = wire.WriteVarBytes(w, 0, in.SignatureScript)
+		if err != nil {
+			return err
+		}
+		err = writeUint32(w, in.Sequence)
+		if err != nil {
+			return err
+		}
+	}
+	err = wire.WriteVarInt(w, 0, uint64(len(t.TxOut)))
+	if err != nil {
+		return err
+	}
+	for _, out := range t.TxOut {
+		err = writeTxOut(w, out)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deserialize reads a transaction in zcash wire format.  Transactions with
+// shielded parts are rejected.
+func (t *tx) Deserialize(r io.Reader) error {
+	header, err := readUint32(r)
+	if err != nil {
+		return err
+	}
+	if header&overwinteredFlag == 0 {
+		return errors.New("transaction is not overwintered")
+	}
+	t.Version = header &^ overwinteredFlag
+	if t.Version != txVersionSapling && t.Version != txVersionNU5 {
+		return fmt.Errorf("unsupported transaction version %d", t.Version)
+	}
+	groupID, err := readUint32(r)
+	if err != nil {
+		return err
+	}
+	if groupID != t.versionGroupID() {
+		return fmt.Errorf("bad version group id %x for a v%d transaction", groupID, t.Version)
+	}
+
+	if t.Version == txVersionNU5 {
+		t.ConsensusBranchID, err = readUint32(r)
+		if err != nil {
+			return err
+		}
+		t.LockTime, err = readUint32(r)
+		if err != nil {
+			return err
+		}
+		t.ExpiryHeight, err = readUint32(r)
+		if err != nil {
+			return err
+		}
+		err = t.readTransparent(r)
+		if err != nil {
+			return err
+		}
+		return readNoShielded(r, 3)
+	}
+
+	err = t.readTransparent(r)
+	if err != nil {
+		return err
+	}
+	t.LockTime, err = readUint32(r)
+	if err != nil {
+		return err
+	}
+	t.ExpiryHeight, err = readUint32(r)
+	if err != nil {
+		return err
+	}
+	valueBalance, err := readUint64(r)
+	if err != nil {
+		return err
+	}
+	if valueBalance != 0 {
+		return errors.New("shielded transactions are not supported")
+	}
+	return readNoShielded(r, 3)
+}
+
+func (t *tx) readTransparent(r io.Reader) error {
+	count, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return err
+	}
+	t.TxIn = make([]*wire.TxIn, 0, count)
+	for i := uint64(0); i < count; i++ {
+		in := &wire.TxIn{}
+		_, err = io.ReadFull(r, in.PreviousOutPoint.Hash[:])
+		if err != nil {
+			return err
+		}
+		in.PreviousOutPoint.Index, err = readUint32(r)
+		if err != nil {
+			return err
+		}
+		in.SignatureScript, err = wire.ReadVarBytes(r, 0, maxScriptSize, "script")
+		if err != nil {
+			return err
+		}
+		in.Sequence, err = readUint32(r)
+		if err != nil {
+			return err
+		}
+		t.TxIn = append(t.TxIn, in)
+	}
+	count, err = wire.ReadVarInt(r, 0)
+	if err != nil {
+		return err
+	}
+	t.TxOut = make([]*wire.TxOut, 0, count)
+	for i := uint64(0); i < count; i++ {
+		value, err := readUint64(r)
+		if err != nil {
+			return err
+		}
+		pkScript, err := wire.ReadVarBytes(r, 0, maxScriptSize, "pkScript")
+		if err != nil {
+			return err
+		}
+		t.TxOut = append(t.TxOut, wire.NewTxOut(int64(value), pkScript))
+	}
+	return nil
+}
+
+// readNoShielded reads the shielded part counts and fails if any are non zero
+func readNoShielded(r io.Reader, counts int) error {
+	for i := 0; i < counts; i++ {
+		n, err := wire.ReadVarInt(r, 0)
+		if err != nil {
+			return err
+		}
+		if n != 0 {
+			return errors.New("shielded transactions are not supported")
+		}
+	}
+	return nil
+}
+
+// Bytes gets the serialized transaction
+func (t *tx) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	err := t.Serialize(&buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SerializeSize gets the size of the serialized transaction
+func (t *tx) SerializeSize() int {
+	b, err := t.Bytes()
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
+
+// TxHash gets the transaction id.  For v4 it is the double sha256 of the
+// serialized transaction, for v5 it is the ZIP-244 transaction digest.
+func (t *tx) TxHash() (chainhash.Hash, error) {
+	if t.Version == txVersionNU5 {
+		return t.txIDDigest()
+	}
+	b, err := t.Bytes()
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+	return chainhash.DoubleHashH(b), nil
+}
+
+// decodeTx deserializes a transaction from bytes
+func decodeTx(b []byte) (*tx, error) {
+	t := &tx{}
+	err := t.Deserialize(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func writeUint32(w io.Writer, v uint32) error {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	_, err := w.Write(b[:])
+	return err
+}
+
+func writeUint64(w io.Writer, v uint64) error {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	_, err := w.Write(b[:])
+	return err
+}
+
+func readUint32(r io.Reader) (uint32, error) {
+	var b [4]byte
+	_, err := io.ReadFull(r, b[:])
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b[:]), nil
+}
+
+func readUint64(r io.Reader) (uint64, error) {
+	var b [8]byte
+	_, err := io.ReadFull(r, b[:])
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b[:]), nil
+}
+
+func writeOutPoint(w io.Writer, op *wire.OutPoint) error {
+	_, err := w.Write(op.Hash[:])
+	if err != nil {
+		return err
+	}
+	return writeUint32(w, op.Index)
+}
+
+func writeTxOut(w io.Writer, out *wire.TxOut) error {
+	err := writeUint64(w, uint64(out.Value))
+	if err != nil {
+		return err
+	}
+	return wire.WriteVarBytes(w, 0, out.PkScript)
+}